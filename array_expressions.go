@@ -0,0 +1,334 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ComparableExpr is an SQL expression, such as a function call, that can be
+// compared with a value. A ComparableExpr can also be used directly as an
+// expression, for example in a SELECT or ORDER BY clause.
+type ComparableExpr struct {
+	clause.Expr
+
+	// TypedSQL returns the SQL of the expression when it is compared with the
+	// given value. This is used for expressions that must be converted to the
+	// type of the value before they can be compared, such as JSON_VALUE. The
+	// SQL of the expression is used unmodified if TypedSQL is nil.
+	TypedSQL func(value interface{}) string
+}
+
+// Eq returns an expression that is true if this expression is equal to the
+// given value.
+func (e ComparableExpr) Eq(value interface{}) clause.Expression {
+	return e.compare("=", value)
+}
+
+// Neq returns an expression that is true if this expression is not equal to
+// the given value.
+func (e ComparableExpr) Neq(value interface{}) clause.Expression {
+	return e.compare("<>", value)
+}
+
+// Gt returns an expression that is true if this expression is greater than
+// the given value.
+func (e ComparableExpr) Gt(value interface{}) clause.Expression {
+	return e.compare(">", value)
+}
+
+// Gte returns an expression that is true if this expression is greater than
+// or equal to the given value.
+func (e ComparableExpr) Gte(value interface{}) clause.Expression {
+	return e.compare(">=", value)
+}
+
+// Lt returns an expression that is true if this expression is less than the
+// given value.
+func (e ComparableExpr) Lt(value interface{}) clause.Expression {
+	return e.compare("<", value)
+}
+
+// Lte returns an expression that is true if this expression is less than or
+// equal to the given value.
+func (e ComparableExpr) Lte(value interface{}) clause.Expression {
+	return e.compare("<=", value)
+}
+
+func (e ComparableExpr) compare(operator string, value interface{}) clause.Expression {
+	sql := e.SQL
+	if e.TypedSQL != nil {
+		sql = e.TypedSQL(value)
+	}
+	return clause.Expr{SQL: sql + " " + operator + " ?", Vars: append(slices.Clone(e.Vars), value)}
+}
+
+// ArrayContains returns an expression that is true if the given array column
+// contains the given value. The expression uses `@value IN UNNEST(column)`.
+//
+// Example:
+//
+//	db.Where(spannergorm.ArrayContains("tags", "rock")).Find(&albums)
+func ArrayContains(column string, value interface{}) clause.Expression {
+	return clause.Expr{SQL: "? IN UNNEST(?)", Vars: []interface{}{value, clause.Column{Name: column}}}
+}
+
+// ArrayIncludes returns an expression that is true if the given array column
+// contains the given value. The expression uses the ARRAY_INCLUDES function.
+func ArrayIncludes(column string, value interface{}) clause.Expression {
+	return clause.Expr{SQL: "ARRAY_INCLUDES(?, ?)", Vars: []interface{}{clause.Column{Name: column}, value}}
+}
+
+// ArrayIncludesAny returns an expression that is true if the given array
+// column contains at least one of the given values.
+func ArrayIncludesAny[T any](column string, values []T) clause.Expression {
+	return clause.Expr{SQL: "ARRAY_INCLUDES_ANY(?, ?)", Vars: []interface{}{clause.Column{Name: column}, Array[T](values)}}
+}
+
+// ArrayIncludesAll returns an expression that is true if the given array
+// column contains all the given values.
+func ArrayIncludesAll[T any](column string, values []T) clause.Expression {
+	return clause.Expr{SQL: "ARRAY_INCLUDES_ALL(?, ?)", Vars: []interface{}{clause.Column{Name: column}, Array[T](values)}}
+}
+
+// ArrayLength returns the length of the given array column.
+//
+// Example:
+//
+//	db.Where(spannergorm.ArrayLength("tags").Gt(2)).Find(&albums)
+func ArrayLength(column string) ComparableExpr {
+	return ComparableExpr{Expr: clause.Expr{SQL: "ARRAY_LENGTH(?)", Vars: []interface{}{clause.Column{Name: column}}}}
+}
+
+// InArray returns an expression that is true if the value of the given
+// column is one of the given values. The values are sent to Spanner as a
+// single array parameter, which means that the SQL string is the same for
+// any number of values.
+func InArray[T any](column string, values []T) clause.Expression {
+	return clause.Expr{SQL: "? IN UNNEST(?)", Vars: []interface{}{clause.Column{Name: column}, Array[T](values)}}
+}
+
+// arrayParam is a slice that is sent to Spanner as a single array parameter.
+type arrayParam struct {
+	values interface{}
+}
+
+func (p arrayParam) Value() (driver.Value, error) {
+	return arrayValue(p.values)
+}
+
+// InListToArray returns a callback that rewrites IN lists with at least
+// threshold values in the WHERE clause of a statement to a comparison with a
+// single array parameter. This ensures that the SQL string of a statement
+// does not depend on the number of values in the IN list, which allows
+// Spanner to cache the query plan of the statement. The rewrite uses
+// `column IN UNNEST(@values)` for GoogleSQL and `column = ANY($1)` for
+// PostgreSQL.
+//
+// The callback is registered automatically if Config.InListThreshold is set,
+// see also RegisterInListToArray.
+func InListToArray(threshold int) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		c, ok := db.Statement.Clauses["WHERE"]
+		if !ok {
+			return
+		}
+		where, ok := c.Expression.(clause.Where)
+		if !ok {
+			return
+		}
+		where.Exprs = rewriteInLists(where.Exprs, threshold, isPostgreSQL(db))
+		c.Expression = where
+		db.Statement.Clauses["WHERE"] = c
+	}
+}
+
+// RegisterInListToArray registers the InListToArray callback for queries,
+// updates and deletes. It does nothing if threshold is not positive.
+//
+// This function can be used for both GoogleSQL-dialect and PostgreSQL-dialect databases.
+func RegisterInListToArray(db *gorm.DB, threshold int) error {
+	if threshold <= 0 {
+		return nil
+	}
+	callback := InListToArray(threshold)
+	if err := db.Callback().Query().Before("gorm:query").Register("gorm:spanner:in_list_to_array", callback); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register("gorm:spanner:in_list_to_array", callback); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("gorm:spanner:in_list_to_array", callback); err != nil {
+		return err
+	}
+	return db.Callback().Delete().Before("gorm:delete").Register("gorm:spanner:in_list_to_array", callback)
+}
+
+// rewriteInLists returns a copy of the given expressions where all IN lists
+// with at least threshold values have been replaced by a comparison with an
+// array parameter.
+func rewriteInLists(exprs []clause.Expression, threshold int, postgreSQL bool) []clause.Expression {
+	res := make([]clause.Expression, len(exprs))
+	for i, expr := range exprs {
+		switch e := expr.(type) {
+		case clause.IN:
+			res[i] = e
+			if len(e.Values) < threshold {
+				break
+			}
+			if values, ok := inListArray(e.Values); ok {
+				column := e.Column
+				if name, ok := column.(string); ok {
+					column = clause.Column{Name: name}
+				}
+				if postgreSQL {
+					res[i] = clause.Expr{SQL: "? = ANY(?)", Vars: []interface{}{column, values}}
+				} else {
+					res[i] = clause.Expr{SQL: "? IN UNNEST(?)", Vars: []interface{}{column, values}}
+				}
+			}
+		case clause.Expr:
+			res[i] = rewriteInExpr(e, threshold, postgreSQL)
+		case clause.AndConditions:
+			res[i] = clause.AndConditions{Exprs: rewriteInLists(e.Exprs, threshold, postgreSQL)}
+		case clause.OrConditions:
+			res[i] = clause.OrConditions{Exprs: rewriteInLists(e.Exprs, threshold, postgreSQL)}
+		case clause.NotConditions:
+			res[i] = clause.NotConditions{Exprs: rewriteInLists(e.Exprs, threshold, postgreSQL)}
+		default:
+			res[i] = expr
+		}
+	}
+	return res
+}
+
+var (
+	inOperatorRegexp    = regexp.MustCompile(`(?i)\bIN\s*$`)
+	notInOperatorRegexp = regexp.MustCompile(`(?i)\bNOT\s+IN\s*$`)
+)
+
+// rewriteInExpr rewrites `column IN ?` in a raw SQL expression if the
+// parameter is a slice with at least threshold values.
+func rewriteInExpr(expr clause.Expr, threshold int, postgreSQL bool) clause.Expr {
+	if !strings.Contains(expr.SQL, "?") {
+		return expr
+	}
+	var (
+		sql     strings.Builder
+		vars    = slices.Clone(expr.Vars)
+		idx     int
+		changed bool
+	)
+	for i := 0; i < len(expr.SQL); i++ {
+		if expr.SQL[i] != '?' || idx >= len(vars) {
+			sql.WriteByte(expr.SQL[i])
+			continue
+		}
+		values, ok := inListVar(vars[idx], threshold)
+		prefix := sql.String()
+		switch {
+		case ok && notInOperatorRegexp.MatchString(prefix):
+			prefix = notInOperatorRegexp.ReplaceAllString(prefix, "")
+			if postgreSQL {
+				prefix += "<> ALL(?)"
+			} else {
+				prefix += "NOT IN UNNEST(?)"
+			}
+		case ok && inOperatorRegexp.MatchString(prefix):
+			prefix = inOperatorRegexp.ReplaceAllString(prefix, "")
+			if postgreSQL {
+				prefix += "= ANY(?)"
+			} else {
+				prefix += "IN UNNEST(?)"
+			}
+		default:
+			sql.WriteByte('?')
+			idx++
+			continue
+		}
+		sql.Reset()
+		sql.WriteString(prefix)
+		vars[idx] = values
+		idx++
+		changed = true
+	}
+	if !changed {
+		return expr
+	}
+	return clause.Expr{SQL: sql.String(), Vars: vars, WithoutParentheses: expr.WithoutParentheses}
+}
+
+// inListVar returns the given variable as an array parameter if it is a
+// slice with at least threshold values.
+func inListVar(v interface{}, threshold int) (interface{}, bool) {
+	if _, ok := v.(driver.Valuer); ok {
+		return nil, false
+	}
+	if _, ok := v.([]byte); ok {
+		return nil, false
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice || rv.Len() < threshold {
+		return nil, false
+	}
+	if values, ok := v.([]interface{}); ok {
+		return inListArray(values)
+	}
+	if !isInListElementType(rv.Type().Elem()) {
+		return nil, false
+	}
+	return arrayParam{values: v}, true
+}
+
+// inListArray converts the values of an IN list to an array parameter. This
+// is only possible if all values are non-null values of the same type.
+func inListArray(values []interface{}) (interface{}, bool) {
+	if len(values) == 0 || values[0] == nil {
+		return nil, false
+	}
+	t := reflect.TypeOf(values[0])
+	if !isInListElementType(t) {
+		return nil, false
+	}
+	res := reflect.MakeSlice(reflect.SliceOf(t), len(values), len(values))
+	for i, v := range values {
+		if v == nil || reflect.TypeOf(v) != t {
+			return nil, false
+		}
+		res.Index(i).Set(reflect.ValueOf(v))
+	}
+	return arrayParam{values: res.Interface()}, true
+}
+
+// isInListElementType returns true if values of the given type can be
+// elements of an array parameter that replaces an IN list. Pointers, maps and
+// nested slices, such as the values of an IN list for a composite key, are
+// not supported.
+func isInListElementType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Interface:
+		return false
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.Uint8
+	}
+	return true
+}
@@ -0,0 +1,194 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/googleapis/go-sql-spanner/testutil"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type taggedAlbum struct {
+	ID   int64
+	Tags Array[string]
+}
+
+func TestArrayExpressions(t *testing.T) {
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+	dryRun := db.Session(&gorm.Session{DryRun: true})
+
+	for _, test := range []struct {
+		query func(db *gorm.DB) *gorm.DB
+		sql   string
+		vars  []interface{}
+	}{
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(ArrayContains("tags", "rock")) },
+			sql:   "SELECT * FROM `tagged_albums` WHERE ? IN UNNEST(`tags`)",
+			vars:  []interface{}{"rock"},
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Not(ArrayContains("tags", "rock")) },
+			sql:   "SELECT * FROM `tagged_albums` WHERE NOT ? IN UNNEST(`tags`)",
+			vars:  []interface{}{"rock"},
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(ArrayIncludes("tags", "rock")) },
+			sql:   "SELECT * FROM `tagged_albums` WHERE ARRAY_INCLUDES(`tags`, ?)",
+			vars:  []interface{}{"rock"},
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(ArrayIncludesAny("tags", []string{"rock", "pop"})).Or(ArrayIncludesAll("tags", []string{"jazz", "blues"}))
+			},
+			sql:  "SELECT * FROM `tagged_albums` WHERE ARRAY_INCLUDES_ANY(`tags`, ?) OR ARRAY_INCLUDES_ALL(`tags`, ?)",
+			vars: []interface{}{Array[string]{"rock", "pop"}, Array[string]{"jazz", "blues"}},
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(ArrayLength("tags").Gt(2)) },
+			sql:   "SELECT * FROM `tagged_albums` WHERE ARRAY_LENGTH(`tags`) > ?",
+			vars:  []interface{}{2},
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(ArrayLength("tags").Eq(0)).Or(ArrayLength("tags").Lte(5)) },
+			sql:   "SELECT * FROM `tagged_albums` WHERE ARRAY_LENGTH(`tags`) = ? OR ARRAY_LENGTH(`tags`) <= ?",
+			vars:  []interface{}{0, 5},
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(InArray("id", []int64{1, 2, 3})) },
+			sql:   "SELECT * FROM `tagged_albums` WHERE `id` IN UNNEST(?)",
+			vars:  []interface{}{Array[int64]{1, 2, 3}},
+		},
+	} {
+		stmt := test.query(dryRun).Find(&[]taggedAlbum{}).Statement
+		if g, w := stmt.SQL.String(), test.sql; g != w {
+			t.Errorf("sql mismatch\n Got: %v\nWant: %v", g, w)
+		}
+		if g, w := stmt.Vars, test.vars; !reflect.DeepEqual(g, w) {
+			t.Errorf("%s: vars mismatch\n Got: %v\nWant: %v", test.sql, g, w)
+		}
+	}
+}
+
+func TestInListToArray(t *testing.T) {
+	server, _, serverTeardown := setupMockedTestServer(t)
+	db, _, teardown := setupTestGormConnectionWithDialector(t, server, serverTeardown, New(Config{
+		DriverName:      "spanner",
+		DSN:             fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true", server.Address),
+		InListThreshold: 3,
+	}))
+	defer teardown()
+	dryRun := db.Session(&gorm.Session{DryRun: true})
+
+	for _, test := range []struct {
+		query func(db *gorm.DB) *gorm.DB
+		sql   string
+		array bool
+	}{
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where([]int64{1, 2, 3}) },
+			sql:   "SELECT * FROM `tagged_albums` WHERE `tagged_albums`.`id` IN UNNEST(?)",
+			array: true,
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where([]int64{1, 2}) },
+			sql:   "SELECT * FROM `tagged_albums` WHERE `tagged_albums`.`id` IN (?,?)",
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where("id IN ?", []int64{1, 2, 3, 4}) },
+			sql:   "SELECT * FROM `tagged_albums` WHERE id IN UNNEST(?)",
+			array: true,
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where("id NOT IN ?", []string{"a", "b", "c"}) },
+			sql:   "SELECT * FROM `tagged_albums` WHERE id NOT IN UNNEST(?)",
+			array: true,
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Not(map[string]interface{}{"id": []int64{1, 2, 3}}) },
+			sql:   "SELECT * FROM `tagged_albums` WHERE NOT `tagged_albums`.`id` IN UNNEST(?)",
+			array: true,
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where("tags IS NULL").Or(clause.IN{Column: "id", Values: []interface{}{int64(1), int64(2), int64(3)}})
+			},
+			sql:   "SELECT * FROM `tagged_albums` WHERE tags IS NULL OR `id` IN UNNEST(?)",
+			array: true,
+		},
+		{
+			// Mixed types cannot be sent as one array.
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(clause.IN{Column: "id", Values: []interface{}{int64(1), "2", int64(3)}})
+			},
+			sql: "SELECT * FROM `tagged_albums` WHERE `id` IN (?,?,?)",
+		},
+	} {
+		stmt := test.query(dryRun).Find(&[]taggedAlbum{}).Statement
+		if g, w := stmt.SQL.String(), test.sql; g != w {
+			t.Errorf("sql mismatch\n Got: %v\nWant: %v", g, w)
+		}
+		if test.array {
+			if g, w := len(stmt.Vars), 1; g != w {
+				t.Errorf("%s: vars length mismatch\n Got: %v\nWant: %v", test.sql, g, w)
+			} else if _, ok := stmt.Vars[0].(arrayParam); !ok {
+				t.Errorf("%s: var type mismatch\n Got: %T\nWant: %T", test.sql, stmt.Vars[0], arrayParam{})
+			}
+		}
+	}
+
+	// The SQL string should be the same for any number of values.
+	query := "SELECT * FROM `tagged_albums` WHERE `tagged_albums`.`id` IN UNNEST(@p1)"
+	_ = server.TestSpanner.PutStatementResult(query, &testutil.StatementResult{
+		Type: testutil.StatementResultResultSet,
+		ResultSet: &spannerpb.ResultSet{
+			Metadata: &spannerpb.ResultSetMetadata{
+				RowType: &spannerpb.StructType{
+					Fields: []*spannerpb.StructType_Field{
+						{Type: &spannerpb.Type{Code: spannerpb.TypeCode_INT64}, Name: "id"},
+					},
+				},
+			},
+			Rows: []*structpb.ListValue{},
+		},
+	})
+	for _, n := range []int{3, 10, 100} {
+		ids := make([]int64, n)
+		for i := range ids {
+			ids[i] = int64(i)
+		}
+		var albums []taggedAlbum
+		if err := db.Find(&albums, ids).Error; err != nil {
+			t.Fatalf("%d: failed to execute query: %v", n, err)
+		}
+		request := getLastSqlRequest(server)
+		if g, w := request.Sql, query; g != w {
+			t.Fatalf("%d: sql mismatch\n Got: %v\nWant: %v", n, g, w)
+		}
+		if g, w := request.ParamTypes["p1"].GetArrayElementType().GetCode(), spannerpb.TypeCode_INT64; g != w {
+			t.Fatalf("%d: param type mismatch\n Got: %v\nWant: %v", n, g, w)
+		}
+		if g, w := len(request.Params.Fields["p1"].GetListValue().GetValues()), n; g != w {
+			t.Fatalf("%d: array length mismatch\n Got: %v\nWant: %v", n, g, w)
+		}
+	}
+}
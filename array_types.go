@@ -15,527 +15,626 @@
 package gorm
 
 import (
+	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/civil"
 	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
 )
 
-// NullStringArray is a named type for storing string arrays in Spanner.
+// Array is a generic type for storing arrays in Spanner.
 // We must use a named type for this to implement the driver.Valuer interface.
 // This is required, because gorm otherwise translates arrays/slices to
 // literals in the form `(item1, item2, ..., itemN)`.
-// ARRAY<STRING> is by default mapped to []spanner.NullString in the Spanner
-// database/sql driver. This is because Spanner always allows arrays to contain
-// null elements, even if the column itself is defined as NOT NULL.
-type NullStringArray []spanner.NullString
-
-func (a NullStringArray) Value() (driver.Value, error) {
-	return []spanner.NullString(a), nil
-}
-
-func (a NullStringArray) GormDataType() string {
-	return "ARRAY<STRING(MAX)>"
-}
-
-func (a NullStringArray) GormDBDataType(_ *gorm.DB, field *schema.Field) string {
-	if field.Size > 0 {
-		return fmt.Sprintf("ARRAY<STRING(%v)>", field.Size)
-	}
-	return "ARRAY<STRING(MAX)>"
-}
-
-// StringArray is a named type for storing string arrays in Spanner.
-// This type cannot contain any NULL elements.
-// We must use a named type for this to implement the driver.Valuer interface.
-// This is required, because gorm otherwise translates arrays/slices to
-// literals in the form `(item1, item2, ..., itemN)`.
-type StringArray []string
+//
+// The element type can be any type that is supported by Spanner, including
+// big.Rat for NUMERIC, spanner.NullJSON, maps and structs for JSON, proto
+// messages and proto enums, and custom types that implement driver.Valuer
+// and sql.Scanner.
+//
+// Spanner always allows arrays to contain null elements, even if the column
+// itself is defined as NOT NULL. NULL elements can only be read into an Array
+// if the element type can be nil, for example []byte or a pointer to a proto
+// message. Use NullArray or an Array of a spanner.NullXYZ type for arrays of
+// other types that can contain NULL elements.
+type Array[T any] []T
 
 //goland:noinspection GoMixedReceiverTypes
-func (a StringArray) Value() (driver.Value, error) {
-	return []string(a), nil
+func (a Array[T]) Value() (driver.Value, error) {
+	return arrayValue([]T(a))
 }
 
 //goland:noinspection GoMixedReceiverTypes
-func (a *StringArray) Scan(v any) error {
-	if val, ok := v.([]string); ok {
+func (a *Array[T]) Scan(v any) error {
+	if val, ok := v.([]T); ok {
 		*a = val
 		return nil
 	}
-	if val, ok := v.([]spanner.NullString); ok {
-		*a = make([]string, len(val))
-		for i, b := range val {
-			if !b.Valid {
-				return fmt.Errorf("index %d of string array contains a null value", i)
-			}
-			(*a)[i] = b.StringVal
-		}
-		return nil
-	}
-	return fmt.Errorf("invalid value for StringArray: %v", v)
+	return scanArray(reflect.ValueOf(a).Elem(), v)
 }
 
 //goland:noinspection GoMixedReceiverTypes
-func (a StringArray) GormDataType() string {
-	return "ARRAY<STRING(MAX)>"
+func (a Array[T]) GormDataType() string {
+	return arrayDataType(reflect.TypeFor[T](), 0, false)
 }
 
 //goland:noinspection GoMixedReceiverTypes
-func (a StringArray) GormDBDataType(_ *gorm.DB, field *schema.Field) string {
-	if field.Size > 0 {
-		return fmt.Sprintf("ARRAY<STRING(%v)>", field.Size)
-	}
-	return "ARRAY<STRING(MAX)>"
-}
-
-// NullBoolArray is a named type for storing bool arrays in Spanner.
-// We must use a named type for this to implement the driver.Valuer interface.
-// This is required, because gorm otherwise translates arrays/slices to
-// literals in the form `(item1, item2, ..., itemN)`.
-// ARRAY<BOOL> is by default mapped to []spanner.NullBool in the Spanner
-// database/sql driver. This is because Spanner always allows arrays to contain
-// null elements, even if the column itself is defined as NOT NULL.
-type NullBoolArray []spanner.NullBool
-
-func (a NullBoolArray) Value() (driver.Value, error) {
-	return []spanner.NullBool(a), nil
-}
-
-func (a NullBoolArray) GormDataType() string {
-	return "ARRAY<BOOL>"
-}
-
-func (a NullBoolArray) GormDBDataType(_ *gorm.DB, _ *schema.Field) string {
-	return "ARRAY<BOOL>"
+func (a Array[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return arrayDataType(reflect.TypeFor[T](), field.Size, isPostgreSQL(db))
 }
 
-// BoolArray is a named type for storing bool arrays in Spanner.
-// This type cannot contain any NULL elements.
-// We must use a named type for this to implement the driver.Valuer interface.
-// This is required, because gorm otherwise translates arrays/slices to
-// literals in the form `(item1, item2, ..., itemN)`.
-type BoolArray []bool
+// NullArray is a generic type for storing arrays that can contain NULL
+// elements in Spanner. NULL elements are represented by nil pointers. The
+// element type can be any type that is supported by Array.
+type NullArray[T any] []*T
 
 //goland:noinspection GoMixedReceiverTypes
-func (a BoolArray) Value() (driver.Value, error) {
-	return []bool(a), nil
+func (a NullArray[T]) Value() (driver.Value, error) {
+	return arrayValue([]*T(a))
 }
 
 //goland:noinspection GoMixedReceiverTypes
-func (a *BoolArray) Scan(v any) error {
-	if val, ok := v.([]bool); ok {
+func (a *NullArray[T]) Scan(v any) error {
+	if val, ok := v.([]*T); ok {
 		*a = val
 		return nil
 	}
-	if val, ok := v.([]spanner.NullBool); ok {
-		*a = make([]bool, len(val))
-		for i, b := range val {
-			if !b.Valid {
-				return fmt.Errorf("index %d of bool array contains a null value", i)
-			}
-			(*a)[i] = b.Bool
-		}
-		return nil
-	}
-	return fmt.Errorf("invalid value for BoolArray: %v", v)
+	return scanArray(reflect.ValueOf(a).Elem(), v)
 }
 
 //goland:noinspection GoMixedReceiverTypes
-func (a BoolArray) GormDataType() string {
-	return "ARRAY<BOOL>"
+func (a NullArray[T]) GormDataType() string {
+	return arrayDataType(reflect.TypeFor[*T](), 0, false)
 }
 
 //goland:noinspection GoMixedReceiverTypes
-func (a BoolArray) GormDBDataType(_ *gorm.DB, _ *schema.Field) string {
-	return "ARRAY<BOOL>"
+func (a NullArray[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return arrayDataType(reflect.TypeFor[*T](), field.Size, isPostgreSQL(db))
 }
 
-// BytesArray is a named type for storing bytes arrays in Spanner.
-// We must use a named type for this to implement the driver.Valuer interface.
-// This is required, because gorm otherwise translates arrays/slices to
-// literals in the form `(item1, item2, ..., itemN)`.
-type BytesArray [][]byte
+// StringArray is an Array of strings. This type cannot contain any NULL
+// elements.
+type StringArray = Array[string]
 
-//goland:noinspection GoMixedReceiverTypes
-func (a BytesArray) Value() (driver.Value, error) {
-	return [][]byte(a), nil
-}
+// NullStringArray is an Array of spanner.NullString.
+// ARRAY<STRING> is by default mapped to []spanner.NullString in the Spanner
+// database/sql driver. This is because Spanner always allows arrays to contain
+// null elements, even if the column itself is defined as NOT NULL.
+type NullStringArray = Array[spanner.NullString]
 
-//goland:noinspection GoMixedReceiverTypes
-func (a *BytesArray) Scan(v any) error {
-	if val, ok := v.([][]byte); ok {
-		*a = val
-		return nil
-	}
-	return fmt.Errorf("invalid value for BytesArray: %v", v)
-}
+// BoolArray is an Array of bools. This type cannot contain any NULL elements.
+type BoolArray = Array[bool]
 
-//goland:noinspection GoMixedReceiverTypes
-func (a BytesArray) GormDataType() string {
-	return "ARRAY<BYTES>"
-}
+// NullBoolArray is an Array of spanner.NullBool.
+type NullBoolArray = Array[spanner.NullBool]
 
-//goland:noinspection GoMixedReceiverTypes
-func (a BytesArray) GormDBDataType(_ *gorm.DB, _ *schema.Field) string {
-	return "ARRAY<BYTES>"
-}
+// BytesArray is an Array of byte slices. NULL elements are represented by nil.
+type BytesArray = Array[[]byte]
 
 // NullBytesArray is a synonym for BytesArray. It is only defined for consistency
 // with the other array data types.
-type NullBytesArray BytesArray
-
-//goland:noinspection GoMixedReceiverTypes
-func (a NullBytesArray) Value() (driver.Value, error) {
-	return BytesArray(a).Value()
-}
-
-//goland:noinspection GoMixedReceiverTypes
-func (a *NullBytesArray) Scan(v any) error {
-	return (*BytesArray)(a).Scan(v)
-}
-
-//goland:noinspection GoMixedReceiverTypes
-func (a NullBytesArray) GormDataType() string {
-	return BytesArray(a).GormDataType()
-}
-
-//goland:noinspection GoMixedReceiverTypes
-func (a NullBytesArray) GormDBDataType(db *gorm.DB, field *schema.Field) string {
-	return BytesArray(a).GormDBDataType(db, field)
-}
-
-// NullInt64Array is a named type for storing int64 arrays in Spanner.
-// We must use a named type for this to implement the driver.Valuer interface.
-// This is required, because gorm otherwise translates arrays/slices to
-// literals in the form `(item1, item2, ..., itemN)`.
-// ARRAY<INT64> is by default mapped to []spanner.NullInt64 in the Spanner
-// database/sql driver. This is because Spanner always allows arrays to contain
-// null elements, even if the column itself is defined as NOT NULL.
-type NullInt64Array []spanner.NullInt64
+type NullBytesArray = BytesArray
 
-func (a NullInt64Array) Value() (driver.Value, error) {
-	return []spanner.NullInt64(a), nil
-}
+// Int64Array is an Array of int64s. This type cannot contain any NULL elements.
+type Int64Array = Array[int64]
 
-func (a NullInt64Array) GormDataType() string {
-	return "ARRAY<INT64>"
-}
+// NullInt64Array is an Array of spanner.NullInt64.
+type NullInt64Array = Array[spanner.NullInt64]
 
-func (a NullInt64Array) GormDBDataType(_ *gorm.DB, _ *schema.Field) string {
-	return "ARRAY<INT64>"
-}
+// Float32Array is an Array of float32s. This type cannot contain any NULL
+// elements.
+type Float32Array = Array[float32]
 
-// Int64Array is a named type for storing int64 arrays in Spanner.
-// This type cannot contain any NULL elements.
-// We must use a named type for this to implement the driver.Valuer interface.
-// This is required, because gorm otherwise translates arrays/slices to
-// literals in the form `(item1, item2, ..., itemN)`.
-type Int64Array []int64
+// NullFloat32Array is an Array of spanner.NullFloat32.
+type NullFloat32Array = Array[spanner.NullFloat32]
 
-//goland:noinspection GoMixedReceiverTypes
-func (a Int64Array) Value() (driver.Value, error) {
-	return []int64(a), nil
-}
+// Float64Array is an Array of float64s. This type cannot contain any NULL
+// elements.
+type Float64Array = Array[float64]
 
-//goland:noinspection GoMixedReceiverTypes
-func (a *Int64Array) Scan(v any) error {
-	if val, ok := v.([]int64); ok {
-		*a = val
-		return nil
-	}
-	if val, ok := v.([]spanner.NullInt64); ok {
-		*a = make([]int64, len(val))
-		for i, b := range val {
-			if !b.Valid {
-				return fmt.Errorf("index %d of int64 array contains a null value", i)
-			}
-			(*a)[i] = b.Int64
-		}
-		return nil
-	}
-	return fmt.Errorf("invalid value for Int64Array: %v", v)
-}
+// NullFloat64Array is an Array of spanner.NullFloat64.
+type NullFloat64Array = Array[spanner.NullFloat64]
 
-//goland:noinspection GoMixedReceiverTypes
-func (a Int64Array) GormDataType() string {
-	return "ARRAY<INT64>"
-}
+// NumericArray is an Array of big.Rats. This type cannot contain any NULL
+// elements.
+type NumericArray = Array[big.Rat]
 
-//goland:noinspection GoMixedReceiverTypes
-func (a Int64Array) GormDBDataType(_ *gorm.DB, _ *schema.Field) string {
-	return "ARRAY<INT64>"
-}
+// NullNumericArray is an Array of spanner.NullNumeric.
+type NullNumericArray = Array[spanner.NullNumeric]
 
-// NullFloat32Array is a named type for storing float32 arrays in Spanner.
-// We must use a named type for this to implement the driver.Valuer interface.
-// This is required, because gorm otherwise translates arrays/slices to
-// literals in the form `(item1, item2, ..., itemN)`.
-// ARRAY<FLOAT32> is by default mapped to []spanner.NullFloat32 in the Spanner
-// database/sql driver. This is because Spanner always allows arrays to contain
-// null elements, even if the column itself is defined as NOT NULL.
-type NullFloat32Array []spanner.NullFloat32
+// DateArray is an Array of dates. This type cannot contain any NULL elements.
+type DateArray = Array[civil.Date]
 
-func (a NullFloat32Array) Value() (driver.Value, error) {
-	return []spanner.NullFloat32(a), nil
-}
+// NullDateArray is an Array of spanner.NullDate.
+type NullDateArray = Array[spanner.NullDate]
 
-func (a NullFloat32Array) GormDataType() string {
-	return "ARRAY<FLOAT32>"
-}
+// TimeArray is an Array of timestamps. This type cannot contain any NULL
+// elements.
+type TimeArray = Array[time.Time]
 
-func (a NullFloat32Array) GormDBDataType(_ *gorm.DB, _ *schema.Field) string {
-	return "ARRAY<FLOAT32>"
-}
+// NullTimeArray is an Array of spanner.NullTime.
+type NullTimeArray = Array[spanner.NullTime]
 
-// Float32Array is a named type for storing float32 arrays in Spanner.
-// This type cannot contain any NULL elements.
-// We must use a named type for this to implement the driver.Valuer interface.
-// This is required, because gorm otherwise translates arrays/slices to
-// literals in the form `(item1, item2, ..., itemN)`.
-type Float32Array []float32
+// NullJSONArray is an Array of spanner.NullJSON.
+type NullJSONArray = Array[spanner.NullJSON]
 
-//goland:noinspection GoMixedReceiverTypes
-func (a Float32Array) Value() (driver.Value, error) {
-	return []float32(a), nil
-}
+var (
+	protoMessageType = reflect.TypeFor[proto.Message]()
+	protoEnumType    = reflect.TypeFor[protoreflect.Enum]()
+	valuerType       = reflect.TypeFor[driver.Valuer]()
+	ratType          = reflect.TypeFor[big.Rat]()
+)
 
-//goland:noinspection GoMixedReceiverTypes
-func (a *Float32Array) Scan(v any) error {
-	if val, ok := v.([]float32); ok {
-		*a = val
-		return nil
+// arrayElementTypes contains the element types that can be sent directly to
+// Spanner, and the Spanner type that they are mapped to.
+var arrayElementTypes = map[reflect.Type]spannerpb.TypeCode{
+	reflect.TypeFor[string]():              spannerpb.TypeCode_STRING,
+	reflect.TypeFor[spanner.NullString]():  spannerpb.TypeCode_STRING,
+	reflect.TypeFor[bool]():                spannerpb.TypeCode_BOOL,
+	reflect.TypeFor[spanner.NullBool]():    spannerpb.TypeCode_BOOL,
+	reflect.TypeFor[[]byte]():              spannerpb.TypeCode_BYTES,
+	reflect.TypeFor[int64]():               spannerpb.TypeCode_INT64,
+	reflect.TypeFor[spanner.NullInt64]():   spannerpb.TypeCode_INT64,
+	reflect.TypeFor[float32]():             spannerpb.TypeCode_FLOAT32,
+	reflect.TypeFor[spanner.NullFloat32](): spannerpb.TypeCode_FLOAT32,
+	reflect.TypeFor[float64]():             spannerpb.TypeCode_FLOAT64,
+	reflect.TypeFor[spanner.NullFloat64](): spannerpb.TypeCode_FLOAT64,
+	ratType:                                spannerpb.TypeCode_NUMERIC,
+	reflect.TypeFor[spanner.NullNumeric](): spannerpb.TypeCode_NUMERIC,
+	reflect.TypeFor[spanner.PGNumeric]():   spannerpb.TypeCode_NUMERIC,
+	reflect.TypeFor[civil.Date]():          spannerpb.TypeCode_DATE,
+	reflect.TypeFor[spanner.NullDate]():    spannerpb.TypeCode_DATE,
+	reflect.TypeFor[time.Time]():           spannerpb.TypeCode_TIMESTAMP,
+	reflect.TypeFor[spanner.NullTime]():    spannerpb.TypeCode_TIMESTAMP,
+	reflect.TypeFor[spanner.NullJSON]():    spannerpb.TypeCode_JSON,
+	reflect.TypeFor[spanner.PGJsonB]():     spannerpb.TypeCode_JSON,
+	reflect.TypeFor[uuid.UUID]():           spannerpb.TypeCode_UUID,
+	reflect.TypeFor[spanner.NullUUID]():    spannerpb.TypeCode_UUID,
+}
+
+// postgreSQLArrayElementTypes contains the PostgreSQL names of the Spanner
+// types that can be used as array elements.
+var postgreSQLArrayElementTypes = map[spannerpb.TypeCode]string{
+	spannerpb.TypeCode_STRING:    "text",
+	spannerpb.TypeCode_BOOL:      "bool",
+	spannerpb.TypeCode_BYTES:     "bytea",
+	spannerpb.TypeCode_INT64:     "bigint",
+	spannerpb.TypeCode_FLOAT32:   "float4",
+	spannerpb.TypeCode_FLOAT64:   "float8",
+	spannerpb.TypeCode_NUMERIC:   "numeric",
+	spannerpb.TypeCode_DATE:      "date",
+	spannerpb.TypeCode_TIMESTAMP: "timestamptz",
+	spannerpb.TypeCode_JSON:      "jsonb",
+	spannerpb.TypeCode_PROTO:     "bytea",
+	spannerpb.TypeCode_ENUM:      "bigint",
+	spannerpb.TypeCode_UUID:      "uuid",
+	spannerpb.TypeCode_INTERVAL:  "interval",
+}
+
+var errNullArrayElement = errors.New("contains a null value, use NullArray for arrays that can contain null values")
+
+// arrayElement is the Spanner type of the elements of an array.
+type arrayElement struct {
+	code spannerpb.TypeCode
+	// protoName is the fully qualified name of the proto message or enum
+	// for PROTO and ENUM elements.
+	protoName string
+}
+
+// arrayElementTypeOf returns the Spanner type that is used for array
+// elements of the given Go type.
+func arrayElementTypeOf(t reflect.Type) arrayElement {
+	if t.Implements(protoMessageType) {
+		message := reflect.Zero(t).Interface().(proto.Message)
+		return arrayElement{code: spannerpb.TypeCode_PROTO, protoName: string(message.ProtoReflect().Descriptor().FullName())}
+	}
+	if t.Implements(protoEnumType) && t.Kind() != reflect.Pointer {
+		enum := reflect.Zero(t).Interface().(protoreflect.Enum)
+		return arrayElement{code: spannerpb.TypeCode_ENUM, protoName: string(enum.Descriptor().FullName())}
+	}
+	if code, ok := arrayElementTypes[t]; ok {
+		return arrayElement{code: code}
+	}
+	if t.Kind() == reflect.Pointer {
+		return arrayElementTypeOf(t.Elem())
 	}
-	if val, ok := v.([]spanner.NullFloat32); ok {
-		*a = make([]float32, len(val))
-		for i, b := range val {
-			if !b.Valid {
-				return fmt.Errorf("index %d of float32 array contains a null value", i)
+	if t.Implements(valuerType) {
+		// Use the type of the value that is returned by the zero value of
+		// custom driver.Valuer types.
+		if v, err := reflect.Zero(t).Interface().(driver.Valuer).Value(); err == nil && v != nil {
+			if code, ok := arrayElementTypes[reflect.TypeOf(v)]; ok {
+				return arrayElement{code: code}
+			}
+			if generic, ok := v.(spanner.GenericColumnValue); ok && generic.Type != nil {
+				return arrayElement{code: generic.Type.Code}
 			}
-			(*a)[i] = b.Float32
 		}
-		return nil
 	}
-	return fmt.Errorf("invalid value for Float32Array: %v", v)
-}
-
-//goland:noinspection GoMixedReceiverTypes
-func (a Float32Array) GormDataType() string {
-	return "ARRAY<FLOAT32>"
-}
-
-//goland:noinspection GoMixedReceiverTypes
-func (a Float32Array) GormDBDataType(_ *gorm.DB, _ *schema.Field) string {
-	return "ARRAY<FLOAT32>"
-}
-
-// NullFloat64Array is a named type for storing float64 arrays in Spanner.
-// We must use a named type for this to implement the driver.Valuer interface.
-// This is required, because gorm otherwise translates arrays/slices to
-// literals in the form `(item1, item2, ..., itemN)`.
-// ARRAY<FLOAT64> is by default mapped to []spanner.NullFloat64 in the Spanner
-// database/sql driver. This is because Spanner always allows arrays to contain
-// null elements, even if the column itself is defined as NOT NULL.
-type NullFloat64Array []spanner.NullFloat64
-
-func (a NullFloat64Array) Value() (driver.Value, error) {
-	return []spanner.NullFloat64(a), nil
+	switch t.Kind() {
+	case reflect.Bool:
+		return arrayElement{code: spannerpb.TypeCode_BOOL}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return arrayElement{code: spannerpb.TypeCode_INT64}
+	case reflect.Float32:
+		return arrayElement{code: spannerpb.TypeCode_FLOAT32}
+	case reflect.Float64:
+		return arrayElement{code: spannerpb.TypeCode_FLOAT64}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return arrayElement{code: spannerpb.TypeCode_BYTES}
+		}
+		return arrayElement{code: spannerpb.TypeCode_JSON}
+	case reflect.Map, reflect.Struct:
+		return arrayElement{code: spannerpb.TypeCode_JSON}
+	}
+	return arrayElement{code: spannerpb.TypeCode_STRING}
 }
 
-func (a NullFloat64Array) GormDataType() string {
-	return "ARRAY<FLOAT64>"
+// arrayDataType returns the data type of an array column with elements of
+// the given Go type.
+func arrayDataType(t reflect.Type, size int, postgreSQL bool) string {
+	element := arrayElementTypeOf(t)
+	if postgreSQL {
+		if element.code == spannerpb.TypeCode_STRING && size > 0 {
+			return fmt.Sprintf("varchar(%v)[]", size)
+		}
+		return postgreSQLArrayElementTypes[element.code] + "[]"
+	}
+	switch element.code {
+	case spannerpb.TypeCode_STRING, spannerpb.TypeCode_BYTES:
+		if size > 0 {
+			return fmt.Sprintf("ARRAY<%s(%v)>", element.code, size)
+		}
+		return fmt.Sprintf("ARRAY<%s(MAX)>", element.code)
+	case spannerpb.TypeCode_PROTO, spannerpb.TypeCode_ENUM:
+		return fmt.Sprintf("ARRAY<`%s`>", element.protoName)
+	}
+	return fmt.Sprintf("ARRAY<%s>", element.code)
+}
+
+func isPostgreSQL(db *gorm.DB) bool {
+	return db != nil && db.Dialector != nil && strings.HasPrefix(db.Dialector.Name(), "postgres")
+}
+
+// isSpannerArray returns true if the given slice can be sent directly to
+// Spanner without any conversion. Slices of unsigned integers are converted
+// element by element, so values that overflow INT64 are rejected.
+func isSpannerArray(v any) bool {
+	switch v.(type) {
+	case []string, []*string, []spanner.NullString,
+		[]bool, []*bool, []spanner.NullBool,
+		[][]byte,
+		[]int, []*int, []int64, []*int64, []spanner.NullInt64,
+		[]float32, []*float32, []spanner.NullFloat32,
+		[]float64, []*float64, []spanner.NullFloat64,
+		[]big.Rat, []*big.Rat, []spanner.NullNumeric, []spanner.PGNumeric,
+		[]civil.Date, []*civil.Date, []spanner.NullDate,
+		[]time.Time, []*time.Time, []spanner.NullTime,
+		[]spanner.NullJSON, []spanner.PGJsonB,
+		[]uuid.UUID, []*uuid.UUID, []spanner.NullUUID:
+		return true
+	}
+	// The Spanner client library encodes slices of proto messages and enums.
+	t := reflect.TypeOf(v).Elem()
+	return t.Implements(protoMessageType) || t.Implements(protoEnumType)
 }
 
-func (a NullFloat64Array) GormDBDataType(_ *gorm.DB, _ *schema.Field) string {
-	return "ARRAY<FLOAT64>"
+// arrayValue converts the given slice to a value that can be sent to Spanner.
+func arrayValue(v any) (driver.Value, error) {
+	if isSpannerArray(v) {
+		return v, nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return nil, nil
+	}
+	values := make([]driver.Value, rv.Len())
+	for i := range values {
+		value, err := arrayElementValue(rv.Index(i))
+		if err != nil {
+			return nil, fmt.Errorf("index %d of %s: %w", i, rv.Type(), err)
+		}
+		values[i] = value
+	}
+	switch element := arrayElementTypeOf(rv.Type().Elem()); element.code {
+	case spannerpb.TypeCode_STRING:
+		return nullArray(values, func(v string) spanner.NullString { return spanner.NullString{StringVal: v, Valid: true} })
+	case spannerpb.TypeCode_BOOL:
+		return nullArray(values, func(v bool) spanner.NullBool { return spanner.NullBool{Bool: v, Valid: true} })
+	case spannerpb.TypeCode_BYTES:
+		return nullArray(values, func(v []byte) []byte { return v })
+	case spannerpb.TypeCode_INT64, spannerpb.TypeCode_ENUM:
+		return nullArray(values, func(v int64) spanner.NullInt64 { return spanner.NullInt64{Int64: v, Valid: true} })
+	case spannerpb.TypeCode_FLOAT32:
+		return nullArray(values, func(v float32) spanner.NullFloat32 { return spanner.NullFloat32{Float32: v, Valid: true} })
+	case spannerpb.TypeCode_FLOAT64:
+		return nullArray(values, func(v float64) spanner.NullFloat64 { return spanner.NullFloat64{Float64: v, Valid: true} })
+	case spannerpb.TypeCode_NUMERIC:
+		return nullArray(values, func(v big.Rat) spanner.NullNumeric { return spanner.NullNumeric{Numeric: v, Valid: true} })
+	case spannerpb.TypeCode_DATE:
+		return nullArray(values, func(v civil.Date) spanner.NullDate { return spanner.NullDate{Date: v, Valid: true} })
+	case spannerpb.TypeCode_TIMESTAMP:
+		return nullArray(values, func(v time.Time) spanner.NullTime { return spanner.NullTime{Time: v, Valid: true} })
+	case spannerpb.TypeCode_JSON:
+		return nullArray(values, func(v any) spanner.NullJSON { return spanner.NullJSON{Value: v, Valid: true} })
+	case spannerpb.TypeCode_UUID:
+		return nullArray(values, func(v uuid.UUID) spanner.NullUUID { return spanner.NullUUID{UUID: v, Valid: true} })
+	case spannerpb.TypeCode_INTERVAL:
+		return genericArray(values, element.code)
+	default:
+		return nil, fmt.Errorf("unsupported element type for %s: %v", rv.Type(), element.code)
+	}
 }
 
-// Float64Array is a named type for storing float64 arrays in Spanner.
-// This type cannot contain any NULL elements.
-// We must use a named type for this to implement the driver.Valuer interface.
-// This is required, because gorm otherwise translates arrays/slices to
-// literals in the form `(item1, item2, ..., itemN)`.
-type Float64Array []float64
-
-//goland:noinspection GoMixedReceiverTypes
-func (a Float64Array) Value() (driver.Value, error) {
-	return []float64(a), nil
+// arrayElementValue converts a single array element to a value that can be
+// added to a typed Spanner array. NULL elements are returned as nil.
+func arrayElementValue(v reflect.Value) (driver.Value, error) {
+	if canBeNil(v.Kind()) && v.IsNil() {
+		return nil, nil
+	}
+	if _, ok := arrayElementTypes[v.Type()]; ok {
+		return v.Interface(), nil
+	}
+	if valuer, ok := v.Interface().(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		return arrayElementValue(v.Elem())
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("value %v overflows INT64", v.Uint())
+		}
+		return int64(v.Uint()), nil
+	case reflect.Float32:
+		return float32(v.Float()), nil
+	case reflect.Float64:
+		return v.Float(), nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Bytes(), nil
+		}
+		return v.Interface(), nil
+	case reflect.Map, reflect.Struct:
+		return v.Interface(), nil
+	}
+	return nil, fmt.Errorf("unsupported element type: %s", v.Type())
+}
+
+// nullArray creates a typed Spanner array from the given values. Each value
+// must either be nil, a Spanner null type N, or a value V that is converted
+// to N.
+func nullArray[V, N any](values []driver.Value, valid func(V) N) (driver.Value, error) {
+	res := make([]N, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+		case N:
+			res[i] = v
+		case V:
+			res[i] = valid(v)
+		default:
+			return nil, fmt.Errorf("index %d: invalid value for %T: %v", i, res, value)
+		}
+	}
+	return res, nil
+}
+
+// genericArray creates a Spanner array with elements of the given type from
+// the given values. Each value must either be nil or a
+// spanner.GenericColumnValue. This is used for types that the Spanner
+// database/sql driver does not support natively, such as INTERVAL.
+func genericArray(values []driver.Value, code spannerpb.TypeCode) (driver.Value, error) {
+	list := make([]*structpb.Value, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+			list[i] = structpb.NewNullValue()
+		case spanner.GenericColumnValue:
+			list[i] = v.Value
+		default:
+			return nil, fmt.Errorf("index %d: invalid value for ARRAY<%v>: %v", i, code, value)
+		}
+	}
+	return spanner.GenericColumnValue{
+		Type:  &spannerpb.Type{Code: spannerpb.TypeCode_ARRAY, ArrayElementType: &spannerpb.Type{Code: code}},
+		Value: structpb.NewListValue(&structpb.ListValue{Values: list}),
+	}, nil
 }
 
-//goland:noinspection GoMixedReceiverTypes
-func (a *Float64Array) Scan(v any) error {
-	if val, ok := v.([]float64); ok {
-		*a = val
+// scanArray assigns the array that was returned by the Spanner database/sql
+// driver to dst, which must be a settable slice.
+func scanArray(dst reflect.Value, src any) error {
+	if src == nil {
+		dst.SetZero()
 		return nil
 	}
-	if val, ok := v.([]spanner.NullFloat64); ok {
-		*a = make([]float64, len(val))
-		for i, b := range val {
-			if !b.Valid {
-				return fmt.Errorf("index %d of float64 array contains a null value", i)
-			}
-			(*a)[i] = b.Float64
+	sv := reflect.ValueOf(src)
+	if sv.Kind() != reflect.Slice {
+		return fmt.Errorf("invalid value for %s: %v", dst.Type(), src)
+	}
+	res := reflect.MakeSlice(dst.Type(), sv.Len(), sv.Len())
+	for i := 0; i < sv.Len(); i++ {
+		if err := scanArrayElement(res.Index(i), sv.Index(i)); err != nil {
+			return fmt.Errorf("index %d of %s: %w", i, dst.Type(), err)
 		}
-		return nil
 	}
-	return fmt.Errorf("invalid value for FLoat64Array: %v", v)
-}
-
-//goland:noinspection GoMixedReceiverTypes
-func (a Float64Array) GormDataType() string {
-	return "ARRAY<FLOAT64>"
-}
-
-//goland:noinspection GoMixedReceiverTypes
-func (a Float64Array) GormDBDataType(_ *gorm.DB, _ *schema.Field) string {
-	return "ARRAY<FLOAT64>"
-}
-
-// NullDateArray is a named type for storing date arrays in Spanner.
-// We must use a named type for this to implement the driver.Valuer interface.
-// This is required, because gorm otherwise translates arrays/slices to
-// literals in the form `(item1, item2, ..., itemN)`.
-// ARRAY<DATE> is by default mapped to []spanner.NullDate in the Spanner
-// database/sql driver. This is because Spanner always allows arrays to contain
-// null elements, even if the column itself is defined as NOT NULL.
-type NullDateArray []spanner.NullDate
-
-func (a NullDateArray) Value() (driver.Value, error) {
-	return []spanner.NullDate(a), nil
-}
-
-func (a NullDateArray) GormDataType() string {
-	return "ARRAY<DATE>"
-}
-
-func (a NullDateArray) GormDBDataType(_ *gorm.DB, _ *schema.Field) string {
-	return "ARRAY<DATE>"
-}
-
-// DateArray is a named type for storing date arrays in Spanner.
-// This type cannot contain any NULL elements.
-// We must use a named type for this to implement the driver.Valuer interface.
-// This is required, because gorm otherwise translates arrays/slices to
-// literals in the form `(item1, item2, ..., itemN)`.
-type DateArray []civil.Date
-
-//goland:noinspection GoMixedReceiverTypes
-func (a DateArray) Value() (driver.Value, error) {
-	return []civil.Date(a), nil
+	dst.Set(res)
+	return nil
 }
 
-//goland:noinspection GoMixedReceiverTypes
-func (a *DateArray) Scan(v any) error {
-	if val, ok := v.([]civil.Date); ok {
-		*a = val
+func scanArrayElement(dst, src reflect.Value) error {
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
 		return nil
 	}
-	if val, ok := v.([]spanner.NullDate); ok {
-		*a = make([]civil.Date, len(val))
-		for i, b := range val {
-			if !b.Valid {
-				return fmt.Errorf("index %d of date array contains a null value", i)
-			}
-			(*a)[i] = b.Date
+	value, valid, isJSON := nullValue(src)
+	if !valid {
+		if canBeNil(dst.Kind()) {
+			dst.SetZero()
+			return nil
 		}
-		return nil
+		return errNullArrayElement
 	}
-	return fmt.Errorf("invalid value for DateArray: %v", v)
-}
-
-//goland:noinspection GoMixedReceiverTypes
-func (a DateArray) GormDataType() string {
-	return "ARRAY<DATE>"
-}
-
-//goland:noinspection GoMixedReceiverTypes
-func (a DateArray) GormDBDataType(_ *gorm.DB, _ *schema.Field) string {
-	return "ARRAY<DATE>"
-}
-
-// NullTimeArray is a named type for storing timestamp arrays in Spanner.
-// We must use a named type for this to implement the driver.Valuer interface.
-// This is required, because gorm otherwise translates arrays/slices to
-// literals in the form `(item1, item2, ..., itemN)`.
-// ARRAY<TIMESTAMP> is by default mapped to []spanner.NullTime in the Spanner
-// database/sql driver. This is because Spanner always allows arrays to contain
-// null elements, even if the column itself is defined as NOT NULL.
-type NullTimeArray []spanner.NullTime
-
-func (a NullTimeArray) Value() (driver.Value, error) {
-	return []spanner.NullTime(a), nil
-}
-
-func (a NullTimeArray) GormDataType() string {
-	return "ARRAY<TIMESTAMP>"
-}
-
-func (a NullTimeArray) GormDBDataType(_ *gorm.DB, _ *schema.Field) string {
-	return "ARRAY<TIMESTAMP>"
-}
-
-// TimeArray is a named type for storing date arrays in Spanner.
-// This type cannot contain any NULL elements.
-// We must use a named type for this to implement the driver.Valuer interface.
-// This is required, because gorm otherwise translates arrays/slices to
-// literals in the form `(item1, item2, ..., itemN)`.
-type TimeArray []time.Time
-
-//goland:noinspection GoMixedReceiverTypes
-func (a TimeArray) Value() (driver.Value, error) {
-	return []time.Time(a), nil
-}
-
-//goland:noinspection GoMixedReceiverTypes
-func (a *TimeArray) Scan(v any) error {
-	if val, ok := v.([]time.Time); ok {
-		*a = val
+	if dst.Kind() == reflect.Pointer && !dst.Type().Implements(protoMessageType) {
+		p := reflect.New(dst.Type().Elem())
+		if err := scanArrayElement(p.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(p)
 		return nil
 	}
-	if val, ok := v.([]spanner.NullTime); ok {
-		*a = make([]time.Time, len(val))
-		for i, b := range val {
-			if !b.Valid {
-				return fmt.Errorf("index %d of time array contains a null value", i)
-			}
-			(*a)[i] = b.Time
+	if scanner, ok := dst.Addr().Interface().(sql.Scanner); ok {
+		if isJSON {
+			return scanner.Scan(src.Interface())
 		}
+		return scanner.Scan(value.Interface())
+	}
+	switch {
+	case isJSON:
+		b, err := json.Marshal(value.Interface())
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(b, dst.Addr().Interface())
+	case dst.Type().Implements(protoMessageType):
+		b, ok := value.Interface().([]byte)
+		if !ok {
+			break
+		}
+		message := reflect.New(dst.Type().Elem())
+		if err := proto.Unmarshal(b, message.Interface().(proto.Message)); err != nil {
+			return err
+		}
+		dst.Set(message)
+		return nil
+	case value.Type().AssignableTo(dst.Type()):
+		dst.Set(value)
+		return nil
+	case dst.Type() == ratType && value.Kind() == reflect.String:
+		// PostgreSQL NUMERIC values are returned as strings.
+		if _, ok := dst.Addr().Interface().(*big.Rat).SetString(value.String()); !ok {
+			return fmt.Errorf("invalid numeric value: %q", value.String())
+		}
+		return nil
+	case isConvertible(value.Type(), dst.Type()):
+		if err := checkNumberConversion(value, dst.Type()); err != nil {
+			return err
+		}
+		dst.Set(value.Convert(dst.Type()))
 		return nil
 	}
-	return fmt.Errorf("invalid value for TimeArray: %v", v)
-}
-
-//goland:noinspection GoMixedReceiverTypes
-func (a TimeArray) GormDataType() string {
-	return "ARRAY<TIMESTAMP>"
+	return fmt.Errorf("cannot assign %s to %s", value.Type(), dst.Type())
+}
+
+// nullValue returns the underlying value of a Spanner null type, and whether
+// the value is valid. Other values are returned unmodified. The returned
+// value of a JSON null type is the unmarshalled JSON value.
+func nullValue(v reflect.Value) (value reflect.Value, valid, isJSON bool) {
+	switch n := v.Interface().(type) {
+	case spanner.NullJSON:
+		return reflect.ValueOf(&n.Value).Elem(), n.Valid, true
+	case spanner.PGJsonB:
+		return reflect.ValueOf(&n.Value).Elem(), n.Valid, true
+	case spanner.NullableValue:
+		if n.IsNull() {
+			return v, false, false
+		}
+		if v.Kind() == reflect.Struct {
+			return v.Field(0), true, false
+		}
+	}
+	if canBeNil(v.Kind()) && v.IsNil() {
+		return v, false, false
+	}
+	return v, true, false
 }
 
-//goland:noinspection GoMixedReceiverTypes
-func (a TimeArray) GormDBDataType(_ *gorm.DB, _ *schema.Field) string {
-	return "ARRAY<TIMESTAMP>"
+func canBeNil(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	}
+	return false
 }
 
-// NullJSONArray is a named type for storing JSON arrays in Spanner.
-// We must use a named type for this to implement the driver.Valuer interface.
-// This is required, because gorm otherwise translates arrays/slices to
-// literals in the form `(item1, item2, ..., itemN)`.
-// ARRAY<JSON> is by default mapped to []spanner.NullJSON in the Spanner
-// database/sql driver. This is because Spanner always allows arrays to contain
-// null elements, even if the column itself is defined as NOT NULL.
-type NullJSONArray []spanner.NullJSON
-
-func (a NullJSONArray) Value() (driver.Value, error) {
-	return []spanner.NullJSON(a), nil
+// isConvertible returns true if a value of type from can be converted to
+// type to without changing the kind of value, for example from int64 to a
+// proto enum or from string to a named string type.
+func isConvertible(from, to reflect.Type) bool {
+	if !from.ConvertibleTo(to) {
+		return false
+	}
+	return from.Kind() == to.Kind() || isNumberKind(from.Kind()) && isNumberKind(to.Kind())
 }
 
-func (a NullJSONArray) GormDataType() string {
-	return "ARRAY<JSON>"
+// checkNumberConversion returns an error if the given value is a number that
+// cannot be converted to the number type to without overflow, or without
+// losing the fractional part of a floating point value.
+func checkNumberConversion(v reflect.Value, to reflect.Type) error {
+	if !isNumberKind(v.Kind()) || !isNumberKind(to.Kind()) {
+		return nil
+	}
+	target := reflect.Zero(to)
+	var ok bool
+	switch {
+	case target.CanInt():
+		switch {
+		case v.CanInt():
+			ok = !target.OverflowInt(v.Int())
+		case v.CanUint():
+			ok = v.Uint() <= math.MaxInt64 && !target.OverflowInt(int64(v.Uint()))
+		default:
+			f := v.Float()
+			ok = f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 && !target.OverflowInt(int64(f))
+		}
+	case target.CanUint():
+		switch {
+		case v.CanInt():
+			ok = v.Int() >= 0 && !target.OverflowUint(uint64(v.Int()))
+		case v.CanUint():
+			ok = !target.OverflowUint(v.Uint())
+		default:
+			f := v.Float()
+			ok = f == math.Trunc(f) && f >= 0 && f < math.MaxUint64 && !target.OverflowUint(uint64(f))
+		}
+	default:
+		ok = !v.CanFloat() || !target.OverflowFloat(v.Float())
+	}
+	if !ok {
+		return fmt.Errorf("value %v cannot be represented as %s", v, to)
+	}
+	return nil
 }
 
-func (a NullJSONArray) GormDBDataType(_ *gorm.DB, _ *schema.Field) string {
-	return "ARRAY<JSON>"
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
 }
@@ -40,6 +40,9 @@ import (
 var benchmarkProjectId, benchmarkInstanceId, benchmarkDatabaseId string
 var allIds []string
 
+// albumSingerIds contains the singers that have albums.
+var albumSingerIds []string
+
 // BaseModel is embedded in all other models to add common database fields.
 type BaseModel struct {
 	ID string `gorm:"primaryKey;autoIncrement:false"`
@@ -113,6 +116,24 @@ func BenchmarkSelectSingleRecordGORM(b *testing.B) {
 	}
 }
 
+func BenchmarkReadSingleRecordGORM(b *testing.B) {
+	db, err := gorm.Open(spannergorm.New(spannergorm.Config{
+		DriverName: "spanner",
+		DSN:        fmt.Sprintf("projects/%s/instances/%s/databases/%s", benchmarkProjectId, benchmarkInstanceId, benchmarkDatabaseId),
+	}), &gorm.Config{PrepareStmt: true})
+	if err != nil {
+		b.Fatalf("failed to open database connection: %v\n", err)
+	}
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := readRandomSinger(db, allIds, rnd); err != nil {
+			b.Fatalf("failed to read singer: %v", err)
+		}
+	}
+}
+
 func BenchmarkSelectAndUpdateUsingMutationGORM(b *testing.B) {
 	db, err := gorm.Open(spannergorm.New(spannergorm.Config{
 		DriverName: "spanner",
@@ -188,6 +209,32 @@ func BenchmarkSelect100SingersGORM(b *testing.B) {
 	}
 }
 
+func BenchmarkPreloadAlbumsGORM(b *testing.B) {
+	benchmarkPreloadAlbums(b)
+}
+
+func BenchmarkPreloadAlbumsArraySubqueryGORM(b *testing.B) {
+	benchmarkPreloadAlbums(b, spannergorm.ArraySubqueryPreload)
+}
+
+func benchmarkPreloadAlbums(b *testing.B, args ...interface{}) {
+	db, err := gorm.Open(spannergorm.New(spannergorm.Config{
+		DriverName: "spanner",
+		DSN:        fmt.Sprintf("projects/%s/instances/%s/databases/%s", benchmarkProjectId, benchmarkInstanceId, benchmarkDatabaseId),
+	}), &gorm.Config{PrepareStmt: true})
+	if err != nil {
+		b.Fatalf("failed to open database connection: %v\n", err)
+	}
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := preloadRandomSingers(db, albumSingerIds, rnd, 10, args...); err != nil {
+			b.Fatalf("failed to preload albums: %v", err)
+		}
+	}
+}
+
 func setup() error {
 	benchmarkProjectId, benchmarkInstanceId, benchmarkDatabaseId = os.Getenv("BENCHMARK_PROJECT_ID"), os.Getenv("BENCHMARK_INSTANCE_ID"), os.Getenv("BENCHMARK_DATABASE_ID")
 	if benchmarkProjectId == "" {
@@ -227,7 +274,10 @@ func setup() error {
 		return err
 	}
 	if c == int64(total) {
-		return selectAllSingerIds(db, total)
+		if err := selectAllSingerIds(db, total); err != nil {
+			return err
+		}
+		return createRandomAlbums(db)
 	}
 
 	fmt.Print("Deleting existing singers\n")
@@ -245,7 +295,7 @@ func setup() error {
 		allIds = append(allIds, ids...)
 		fmt.Printf("Inserted %v singers\n", (batch+1)*count)
 	}
-	return nil
+	return createRandomAlbums(db)
 }
 
 func createDb(projectId, instanceId, databaseId string) error {
@@ -302,6 +352,24 @@ func createRandomSingers(db *gorm.DB, count int) ([]string, error) {
 	return ids, db.CreateInBatches(singers, 100).Error
 }
 
+// createRandomAlbums creates 10 albums for each of the first 100 singers.
+func createRandomAlbums(db *gorm.DB) error {
+	albumSingerIds = allIds[:100]
+	fmt.Printf("Inserting albums for %v singers\n", len(albumSingerIds))
+	var albums []*Album
+	for _, singerId := range albumSingerIds {
+		for i := 0; i < 10; i++ {
+			albums = append(albums, &Album{
+				BaseModel:   BaseModel{ID: uuid.NewString()},
+				Title:       fmt.Sprintf("Album %v", i+1),
+				ReleaseDate: datatypes.Date(time.Now()),
+				SingerId:    singerId,
+			})
+		}
+	}
+	return db.Omit("Singer").CreateInBatches(albums, 100).Error
+}
+
 func createRandomSingerMutations(count int) ([]string, []*Singer) {
 	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
 	firstNames := []string{"Pete", "Alice", "John", "Ethel", "Trudy", "Naomi", "Wendy", "Ruben", "Thomas", "Elly", "Cora", "Elise", "April", "Libby", "Alexandra", "Shania"}
@@ -326,6 +394,12 @@ func selectRandomSinger(db *gorm.DB, ids []string, rnd *rand.Rand) (*Singer, err
 	return &s, err
 }
 
+func readRandomSinger(db *gorm.DB, ids []string, rnd *rand.Rand) (*Singer, error) {
+	var s Singer
+	err := spannergorm.ReadByKeys(db, &s, spanner.Key{ids[rnd.Intn(len(ids))]})
+	return &s, err
+}
+
 func selectRandomSingersWithClient(db *gorm.DB, count int) error {
 	var singers []Singer
 	if err := db.Raw(fmt.Sprintf("SELECT * FROM Singers TABLESAMPLE RESERVOIR (%v ROWS)", count)).Scan(&singers).Error; err != nil {
@@ -337,6 +411,23 @@ func selectRandomSingersWithClient(db *gorm.DB, count int) error {
 	return nil
 }
 
+func preloadRandomSingers(db *gorm.DB, ids []string, rnd *rand.Rand, count int, args ...interface{}) error {
+	selected := make([]string, count)
+	for i := range selected {
+		selected[i] = ids[rnd.Intn(len(ids))]
+	}
+	var singers []Singer
+	if err := db.Preload("Albums", args...).Find(&singers, "id IN ?", selected).Error; err != nil {
+		return err
+	}
+	for _, singer := range singers {
+		if len(singer.Albums) == 0 {
+			return errors.New("missing albums")
+		}
+	}
+	return nil
+}
+
 type queryerSql interface {
 	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
 
@@ -0,0 +1,168 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command spannerdata exports tables and queries from a Spanner database to
+// CSV, JSON Lines and Avro files, and imports those files into tables.
+//
+// Usage:
+//
+//	spannerdata export -dsn <dsn> -table singers -out singers.csv
+//	spannerdata export -dsn <dsn> -query "SELECT * FROM singers WHERE active" -out active.avro
+//	spannerdata import -dsn <dsn> singers.csv albums.avro
+//
+// The format of a file is determined by its extension (.csv, .jsonl or
+// .avro), and the table of an imported file is the name of the file without
+// the extension, unless the -table flag is set. Use a DSN with
+// autoConfigEmulator=true to use the emulator at SPANNER_EMULATOR_HOST.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	spannergorm "github.com/googleapis/go-gorm-spanner"
+	"github.com/googleapis/go-gorm-spanner/dataio"
+	spannerpg "github.com/googleapis/go-gorm-spanner/postgresql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: spannerdata export|import [flags]")
+	}
+	flags := flag.NewFlagSet("spannerdata "+args[0], flag.ContinueOnError)
+	dsn := flags.String("dsn", "", "the data source name of the database")
+	dialect := flags.String("dialect", "googlesql", "the dialect of the database: googlesql or postgresql")
+	table := flags.String("table", "", "the table to export or import")
+	csvNull := flags.String("csv-null", dataio.DefaultCSVNull, "the text for NULL values in CSV files")
+	switch args[0] {
+	case "export":
+		query := flags.String("query", "", "the query to export")
+		out := flags.String("out", "", "the file to export to")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *out == "" || (*table == "") == (*query == "") {
+			return fmt.Errorf("export requires -out and either -table or -query")
+		}
+		db, err := open(*dsn, *dialect)
+		if err != nil {
+			return err
+		}
+		return export(ctx, db, *table, *query, *out, *csvNull, stdout)
+	case "import":
+		batchSize := flags.Int("batch-size", 0, "the number of rows per transaction")
+		maxMutations := flags.Int("max-mutations", dataio.MaxMutationsPerCommit, "the maximum number of mutations per transaction")
+		dml := flags.Bool("dml", false, "import with INSERT statements instead of mutations")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if flags.NArg() == 0 || *table != "" && flags.NArg() > 1 {
+			return fmt.Errorf("import requires one or more files, and -table can only be used with one file")
+		}
+		db, err := open(*dsn, *dialect)
+		if err != nil {
+			return err
+		}
+		opts := dataio.ImportOptions{BatchSize: *batchSize, MaxMutations: *maxMutations, CSVNull: *csvNull}
+		if *dml {
+			opts.Mode = dataio.ImportWithDML
+		}
+		return importFiles(ctx, db, opts, *table, flags.Args(), stdout)
+	}
+	return fmt.Errorf("unknown command: %s", args[0])
+}
+
+func open(dsn, dialect string) (*gorm.DB, error) {
+	if dsn == "" {
+		return nil, fmt.Errorf("missing -dsn")
+	}
+	config := &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)}
+	switch strings.ToLower(dialect) {
+	case "googlesql":
+		return gorm.Open(spannergorm.New(spannergorm.Config{DriverName: "spanner", DSN: dsn}), config)
+	case "postgresql":
+		return gorm.Open(spannerpg.New(postgres.Config{DSN: dsn}), config)
+	}
+	return nil, fmt.Errorf("unknown dialect: %s", dialect)
+}
+
+func export(ctx context.Context, db *gorm.DB, table, query, out, csvNull string, stdout io.Writer) (err error) {
+	format, err := dataio.FormatFromPath(out)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	opts := dataio.ExportOptions{Format: format, CSVNull: csvNull}
+	var n int64
+	if table != "" {
+		n, err = dataio.ExportTable(ctx, db, f, opts, table)
+	} else {
+		n, err = dataio.Export(db.WithContext(ctx), f, opts, func(tx *gorm.DB) *gorm.DB {
+			return tx.Raw(query).Find(&[]map[string]interface{}{})
+		})
+	}
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(stdout, "exported %d rows to %s\n", n, out)
+	return nil
+}
+
+func importFiles(ctx context.Context, db *gorm.DB, opts dataio.ImportOptions, table string, paths []string, stdout io.Writer) error {
+	sources := make([]dataio.Source, len(paths))
+	for i, path := range paths {
+		format, err := dataio.FormatFromPath(path)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		sources[i] = dataio.Source{Table: table, Format: format, Reader: f}
+		if table == "" {
+			sources[i].Table = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+	}
+	n, err := dataio.Import(ctx, db, opts, sources...)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(stdout, "imported %d rows\n", n)
+	return nil
+}
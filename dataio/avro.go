@@ -0,0 +1,554 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataio
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// This file implements the subset of the Avro object container file format
+// (https://avro.apache.org/docs/1.11.1/specification/) that is needed for
+// exporting and importing rows: a record with nullable fields of primitive
+// types and arrays of those. The writer writes uncompressed files, and the
+// reader supports the null and deflate codecs.
+
+var avroMagic = []byte{'O', 'b', 'j', 1}
+
+// avroBlockSize is the number of bytes after which the writer starts a new
+// block.
+const avroBlockSize = 1 << 20
+
+// avroSchema returns the Avro type of the non-null values of a column of
+// the given type.
+func avroSchema(t *spannerpb.Type) interface{} {
+	switch t.GetCode() {
+	case spannerpb.TypeCode_BOOL:
+		return "boolean"
+	case spannerpb.TypeCode_INT64:
+		return "long"
+	case spannerpb.TypeCode_FLOAT64:
+		return "double"
+	case spannerpb.TypeCode_FLOAT32:
+		return "float"
+	case spannerpb.TypeCode_STRING:
+		return "string"
+	case spannerpb.TypeCode_BYTES:
+		return "bytes"
+	case spannerpb.TypeCode_ENUM:
+		return map[string]interface{}{"type": "long", "sqlType": sqlType(t)}
+	case spannerpb.TypeCode_PROTO:
+		return map[string]interface{}{"type": "bytes", "sqlType": sqlType(t)}
+	case spannerpb.TypeCode_ARRAY:
+		return map[string]interface{}{"type": "array", "items": []interface{}{"null", avroSchema(t.GetArrayElementType())}}
+	}
+	return map[string]interface{}{"type": "string", "sqlType": sqlType(t)}
+}
+
+// sqlType returns the name of the given Spanner type.
+func sqlType(t *spannerpb.Type) string {
+	switch t.GetCode() {
+	case spannerpb.TypeCode_ARRAY:
+		return "ARRAY<" + sqlType(t.GetArrayElementType()) + ">"
+	case spannerpb.TypeCode_PROTO, spannerpb.TypeCode_ENUM:
+		return t.GetProtoTypeFqn()
+	}
+	if t.GetTypeAnnotation() == spannerpb.TypeAnnotationCode_PG_NUMERIC || t.GetTypeAnnotation() == spannerpb.TypeAnnotationCode_PG_JSONB {
+		return t.GetTypeAnnotation().String()
+	}
+	return t.GetCode().String()
+}
+
+// avroName returns a valid Avro name for the given name. Invalid characters
+// are replaced with underscores.
+func avroName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || i > 0 && r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	if b.Len() == 0 {
+		return "_"
+	}
+	return b.String()
+}
+
+type avroWriter struct {
+	w      io.Writer
+	fields []*spannerpb.StructType_Field
+	sync   [16]byte
+	block  []byte
+	count  int64
+}
+
+func newAvroWriter(w io.Writer, name string, fields []*spannerpb.StructType_Field) (*avroWriter, error) {
+	writer := &avroWriter{w: w, fields: fields}
+	if _, err := rand.Read(writer.sync[:]); err != nil {
+		return nil, err
+	}
+	schemaFields := make([]interface{}, len(fields))
+	for i, field := range fields {
+		f := map[string]interface{}{
+			"name": avroName(field.Name),
+			"type": []interface{}{"null", avroSchema(field.Type)},
+		}
+		if avroName(field.Name) != field.Name {
+			f["sqlName"] = field.Name
+		}
+		schemaFields[i] = f
+	}
+	schema, err := json.Marshal(map[string]interface{}{
+		"type":   "record",
+		"name":   avroName(name),
+		"fields": schemaFields,
+	})
+	if err != nil {
+		return nil, err
+	}
+	header := append([]byte{}, avroMagic...)
+	header = binary.AppendVarint(header, 2)
+	header = appendAvroBytes(header, []byte("avro.schema"))
+	header = appendAvroBytes(header, schema)
+	header = appendAvroBytes(header, []byte("avro.codec"))
+	header = appendAvroBytes(header, []byte("null"))
+	header = binary.AppendVarint(header, 0)
+	header = append(header, writer.sync[:]...)
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (w *avroWriter) write(row []*structpb.Value) (err error) {
+	for i, v := range row {
+		if w.block, err = appendAvroValue(w.block, w.fields[i].Type, v); err != nil {
+			return fmt.Errorf("%s: %w", w.fields[i].Name, err)
+		}
+	}
+	w.count++
+	if len(w.block) >= avroBlockSize {
+		return w.flush()
+	}
+	return nil
+}
+
+func (w *avroWriter) flush() error {
+	if w.count == 0 {
+		return nil
+	}
+	header := binary.AppendVarint(nil, w.count)
+	header = binary.AppendVarint(header, int64(len(w.block)))
+	for _, b := range [][]byte{header, w.block, w.sync[:]} {
+		if _, err := w.w.Write(b); err != nil {
+			return err
+		}
+	}
+	w.block = w.block[:0]
+	w.count = 0
+	return nil
+}
+
+func (w *avroWriter) close() error {
+	return w.flush()
+}
+
+func appendAvroBytes(buf, b []byte) []byte {
+	return append(binary.AppendVarint(buf, int64(len(b))), b...)
+}
+
+// appendAvroValue appends a nullable value of the given type to buf.
+func appendAvroValue(buf []byte, t *spannerpb.Type, v *structpb.Value) ([]byte, error) {
+	if isNull(v) {
+		return binary.AppendVarint(buf, 0), nil
+	}
+	buf = binary.AppendVarint(buf, 1)
+	switch t.GetCode() {
+	case spannerpb.TypeCode_BOOL:
+		if v.GetBoolValue() {
+			return append(buf, 1), nil
+		}
+		return append(buf, 0), nil
+	case spannerpb.TypeCode_INT64, spannerpb.TypeCode_ENUM:
+		n, err := strconv.ParseInt(v.GetStringValue(), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return binary.AppendVarint(buf, n), nil
+	case spannerpb.TypeCode_FLOAT64:
+		f, err := floatValue(v)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(f)), nil
+	case spannerpb.TypeCode_FLOAT32:
+		f, err := floatValue(v)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(f))), nil
+	case spannerpb.TypeCode_BYTES, spannerpb.TypeCode_PROTO:
+		b, err := base64.StdEncoding.DecodeString(v.GetStringValue())
+		if err != nil {
+			return nil, err
+		}
+		return appendAvroBytes(buf, b), nil
+	case spannerpb.TypeCode_ARRAY:
+		values := v.GetListValue().GetValues()
+		if len(values) > 0 {
+			buf = binary.AppendVarint(buf, int64(len(values)))
+			for _, e := range values {
+				var err error
+				if buf, err = appendAvroValue(buf, t.GetArrayElementType(), e); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return binary.AppendVarint(buf, 0), nil
+	case spannerpb.TypeCode_STRUCT:
+		b, err := listJSON(v)
+		if err != nil {
+			return nil, err
+		}
+		return appendAvroBytes(buf, b), nil
+	}
+	return appendAvroBytes(buf, []byte(v.GetStringValue())), nil
+}
+
+// avroType is a parsed Avro schema.
+type avroType struct {
+	kind        string
+	logicalType string
+	scale       int
+	items       *avroType
+	branches    []*avroType
+}
+
+// parseAvroType parses the Avro schema of a field.
+func parseAvroType(raw json.RawMessage) (*avroType, error) {
+	var name string
+	if err := json.Unmarshal(raw, &name); err == nil {
+		switch name {
+		case "null", "boolean", "int", "long", "float", "double", "bytes", "string":
+			return &avroType{kind: name}, nil
+		}
+		return nil, fmt.Errorf("unsupported Avro type: %s", name)
+	}
+	var union []json.RawMessage
+	if err := json.Unmarshal(raw, &union); err == nil {
+		t := &avroType{kind: "union", branches: make([]*avroType, len(union))}
+		for i, branch := range union {
+			if t.branches[i], err = parseAvroType(branch); err != nil {
+				return nil, err
+			}
+		}
+		return t, nil
+	}
+	var complexType struct {
+		Type        json.RawMessage `json:"type"`
+		LogicalType string          `json:"logicalType"`
+		Scale       int             `json:"scale"`
+		Items       json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &complexType); err != nil {
+		return nil, err
+	}
+	if string(complexType.Type) == `"array"` {
+		items, err := parseAvroType(complexType.Items)
+		if err != nil {
+			return nil, err
+		}
+		return &avroType{kind: "array", items: items}, nil
+	}
+	t, err := parseAvroType(complexType.Type)
+	if err != nil {
+		return nil, err
+	}
+	t.logicalType = complexType.LogicalType
+	t.scale = complexType.Scale
+	return t, nil
+}
+
+type avroReader struct {
+	r       *bufio.Reader
+	codec   string
+	sync    [16]byte
+	names   []string
+	types   []*avroType
+	block   *bytes.Reader
+	pending int64
+}
+
+func newAvroReader(r io.Reader, columns *tableColumns) (*avroReader, error) {
+	reader := &avroReader{r: bufio.NewReader(r)}
+	magic := make([]byte, len(avroMagic))
+	if _, err := io.ReadFull(reader.r, magic); err != nil || !bytes.Equal(magic, avroMagic) {
+		return nil, fmt.Errorf("not an Avro object container file")
+	}
+	metadata := make(map[string][]byte)
+	for {
+		count, err := binary.ReadVarint(reader.r)
+		if err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			break
+		}
+		if count < 0 {
+			// A negative count is followed by the size of the block.
+			count = -count
+			if _, err := binary.ReadVarint(reader.r); err != nil {
+				return nil, err
+			}
+		}
+		for i := int64(0); i < count; i++ {
+			key, err := readAvroBytes(reader.r)
+			if err != nil {
+				return nil, err
+			}
+			if metadata[string(key)], err = readAvroBytes(reader.r); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if _, err := io.ReadFull(reader.r, reader.sync[:]); err != nil {
+		return nil, err
+	}
+	reader.codec = string(metadata["avro.codec"])
+	if reader.codec != "" && reader.codec != "null" && reader.codec != "deflate" {
+		return nil, fmt.Errorf("unsupported Avro codec: %s", reader.codec)
+	}
+	var schema struct {
+		Type   string `json:"type"`
+		Fields []struct {
+			Name    string          `json:"name"`
+			SQLName string          `json:"sqlName"`
+			Type    json.RawMessage `json:"type"`
+		} `json:"fields"`
+	}
+	if err := json.Unmarshal(metadata["avro.schema"], &schema); err != nil {
+		return nil, fmt.Errorf("invalid Avro schema: %w", err)
+	}
+	if schema.Type != "record" {
+		return nil, fmt.Errorf("unsupported Avro schema type: %s", schema.Type)
+	}
+	for _, field := range schema.Fields {
+		name := field.Name
+		if field.SQLName != "" {
+			name = field.SQLName
+		}
+		column, _, err := columns.lookup(name)
+		if err != nil {
+			return nil, err
+		}
+		t, err := parseAvroType(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		reader.names = append(reader.names, column)
+		reader.types = append(reader.types, t)
+	}
+	return reader, nil
+}
+
+func (r *avroReader) read() ([]string, []*structpb.Value, error) {
+	if r.pending == 0 {
+		if err := r.nextBlock(); err != nil {
+			return nil, nil, err
+		}
+	}
+	values := make([]*structpb.Value, len(r.types))
+	for i, t := range r.types {
+		var err error
+		if values[i], err = readAvroValue(r.block, t); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", r.names[i], err)
+		}
+	}
+	r.pending--
+	return r.names, values, nil
+}
+
+// nextBlock reads the next block with one or more objects.
+func (r *avroReader) nextBlock() error {
+	for r.pending == 0 {
+		count, err := binary.ReadVarint(r.r)
+		if err == io.EOF {
+			return io.EOF
+		}
+		if err != nil {
+			return err
+		}
+		size, err := binary.ReadVarint(r.r)
+		if err != nil {
+			return err
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(r.r, data); err != nil {
+			return err
+		}
+		var sync [16]byte
+		if _, err := io.ReadFull(r.r, sync[:]); err != nil {
+			return err
+		}
+		if sync != r.sync {
+			return fmt.Errorf("invalid sync marker in Avro file")
+		}
+		if r.codec == "deflate" {
+			if data, err = io.ReadAll(flate.NewReader(bytes.NewReader(data))); err != nil {
+				return err
+			}
+		}
+		r.block = bytes.NewReader(data)
+		r.pending = count
+	}
+	return nil
+}
+
+func readAvroBytes(r io.ByteReader) ([]byte, error) {
+	n, err := binary.ReadVarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("invalid length: %d", n)
+	}
+	b := make([]byte, n)
+	for i := range b {
+		if b[i], err = r.ReadByte(); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// readAvroValue reads a value of the given Avro type, and returns it in the
+// encoding that Spanner uses for the corresponding Spanner type.
+func readAvroValue(r *bytes.Reader, t *avroType) (*structpb.Value, error) {
+	switch t.kind {
+	case "null":
+		return structpb.NewNullValue(), nil
+	case "boolean":
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		return structpb.NewBoolValue(b != 0), nil
+	case "int", "long":
+		n, err := binary.ReadVarint(r)
+		if err != nil {
+			return nil, err
+		}
+		switch t.logicalType {
+		case "date":
+			return structpb.NewStringValue(time.Unix(n*24*60*60, 0).UTC().Format(time.DateOnly)), nil
+		case "timestamp-millis":
+			return structpb.NewStringValue(time.UnixMilli(n).UTC().Format(time.RFC3339Nano)), nil
+		case "timestamp-micros":
+			return structpb.NewStringValue(time.UnixMicro(n).UTC().Format(time.RFC3339Nano)), nil
+		}
+		return structpb.NewStringValue(strconv.FormatInt(n, 10)), nil
+	case "float":
+		var b [4]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return nil, err
+		}
+		return structpb.NewNumberValue(float64(math.Float32frombits(binary.LittleEndian.Uint32(b[:])))), nil
+	case "double":
+		var b [8]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return nil, err
+		}
+		return structpb.NewNumberValue(math.Float64frombits(binary.LittleEndian.Uint64(b[:]))), nil
+	case "bytes":
+		b, err := readAvroBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		if t.logicalType == "decimal" {
+			return structpb.NewStringValue(decimalString(b, t.scale)), nil
+		}
+		return structpb.NewStringValue(base64.StdEncoding.EncodeToString(b)), nil
+	case "string":
+		b, err := readAvroBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		return structpb.NewStringValue(string(b)), nil
+	case "array":
+		var values []*structpb.Value
+		for {
+			count, err := binary.ReadVarint(r)
+			if err != nil {
+				return nil, err
+			}
+			if count == 0 {
+				break
+			}
+			if count < 0 {
+				count = -count
+				if _, err := binary.ReadVarint(r); err != nil {
+					return nil, err
+				}
+			}
+			for i := int64(0); i < count; i++ {
+				v, err := readAvroValue(r, t.items)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, v)
+			}
+		}
+		return structpb.NewListValue(&structpb.ListValue{Values: values}), nil
+	case "union":
+		index, err := binary.ReadVarint(r)
+		if err != nil {
+			return nil, err
+		}
+		if index < 0 || index >= int64(len(t.branches)) {
+			return nil, fmt.Errorf("invalid union index: %d", index)
+		}
+		return readAvroValue(r, t.branches[index])
+	}
+	return nil, errors.New("unsupported Avro type: " + t.kind)
+}
+
+// decimalString returns the text of an Avro decimal, which is a big-endian
+// two's-complement integer with the given scale.
+func decimalString(b []byte, scale int) string {
+	n := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+	}
+	r := new(big.Rat).SetFrac(n, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil))
+	return r.FloatString(scale)
+}
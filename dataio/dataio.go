@@ -0,0 +1,507 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dataio exports the rows of tables and gorm queries to CSV, JSON
+// Lines and Avro files, and imports those files into Spanner tables.
+//
+// The values are exported with the Spanner types of the columns in the
+// result of the query, which means that the exported files also contain the
+// correct types of ARRAY, JSON, PROTO, NUMERIC and TIMESTAMP columns. Files
+// are imported with mutations or DML in batches that stay below the limit
+// for the number of mutations in a single commit. Tables are imported in
+// interleaving order, which means that parent tables are imported before
+// the tables that are interleaved in them.
+//
+// The functions in this package can be used for both GoogleSQL-dialect and
+// PostgreSQL-dialect databases.
+package dataio
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/googleapis/go-gorm-spanner/internal/gormutil"
+	spannerdriver "github.com/googleapis/go-sql-spanner"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gorm.io/gorm"
+)
+
+// Format is the file format of exported and imported data.
+type Format int
+
+const (
+	// CSV files contain a header with the column names and one line per
+	// row. ARRAY and STRUCT values are encoded as JSON arrays.
+	CSV Format = iota
+	// JSONLines files contain one JSON object per row. INT64 values are
+	// encoded as JSON numbers, and JSON values are embedded in the object.
+	JSONLines
+	// Avro files are Avro object container files with one record per row.
+	// Columns that have no corresponding Avro type are encoded as strings,
+	// and the Spanner type is added to the schema as the sqlType property.
+	Avro
+)
+
+func (f Format) String() string {
+	switch f {
+	case CSV:
+		return "CSV"
+	case JSONLines:
+		return "JSON Lines"
+	case Avro:
+		return "Avro"
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// FormatFromPath returns the format for the extension of the given file
+// name: .csv, .jsonl, .ndjson or .avro.
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return CSV, nil
+	case ".jsonl", ".ndjson":
+		return JSONLines, nil
+	case ".avro":
+		return Avro, nil
+	}
+	return 0, fmt.Errorf("unknown file format: %s", path)
+}
+
+// DefaultCSVNull is the text that represents NULL values in CSV files.
+const DefaultCSVNull = `\N`
+
+// MaxMutationsPerCommit is the maximum number of mutations in a single
+// commit in Spanner.
+const MaxMutationsPerCommit = 80_000
+
+// ExportOptions contains the options for exporting data.
+type ExportOptions struct {
+	// Format is the format of the exported data.
+	Format Format
+	// CSVNull is the text for NULL values in CSV files. The default is
+	// DefaultCSVNull.
+	CSVNull string
+}
+
+// Export writes the rows that are returned by the query in fc to w. The
+// query is built by gorm in dry-run mode, which means that fc does not
+// return any records. The destination of the query is only used to build
+// the query, and the exported columns are the columns in the result of the
+// query. Export returns the number of exported rows.
+//
+// Example:
+//
+//	n, err := dataio.Export(db, w, dataio.ExportOptions{Format: dataio.CSV}, func(tx *gorm.DB) *gorm.DB {
+//		return tx.Where("last_name = ?", "Allison").Find(&[]Singer{})
+//	})
+func Export(db *gorm.DB, w io.Writer, opts ExportOptions, fc func(tx *gorm.DB) *gorm.DB) (int64, error) {
+	return export(db, w, opts, "Row", fc)
+}
+
+// ExportTable writes all rows in the given table to w, and returns the number
+// of exported rows.
+func ExportTable(ctx context.Context, db *gorm.DB, w io.Writer, opts ExportOptions, table string) (int64, error) {
+	return export(db.WithContext(ctx), w, opts, table, func(tx *gorm.DB) *gorm.DB {
+		return tx.Table(table).Find(&[]map[string]interface{}{})
+	})
+}
+
+func export(db *gorm.DB, w io.Writer, opts ExportOptions, name string, fc func(tx *gorm.DB) *gorm.DB) (int64, error) {
+	tx := fc(db.Session(&gorm.Session{DryRun: true}))
+	if tx.Error != nil {
+		return 0, tx.Error
+	}
+	stmt := tx.Statement
+	args := withExecOptions(stmt.Vars, func(options *spannerdriver.ExecOptions) {
+		options.DecodeOption = spannerdriver.DecodeOptionProto
+		options.ReturnResultSetMetadata = true
+	})
+	rows, err := gormutil.UnpreparedConnPool(stmt.ConnPool).QueryContext(stmt.Context, stmt.SQL.String(), args...)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = rows.Close() }()
+	fields, err := readMetadata(rows)
+	if err != nil {
+		return 0, err
+	}
+	writer, err := newRowWriter(w, opts, name, fields)
+	if err != nil {
+		return 0, err
+	}
+	values := make([]spanner.GenericColumnValue, len(fields))
+	dest := make([]interface{}, len(fields))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	row := make([]*structpb.Value, len(fields))
+	var count int64
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return count, err
+		}
+		for i, v := range values {
+			row[i] = v.Value
+		}
+		if err := writer.write(row); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, err
+	}
+	return count, writer.close()
+}
+
+// readMetadata reads the metadata of a query that was executed with
+// ReturnResultSetMetadata, and moves to the result set with the rows.
+func readMetadata(rows *sql.Rows) ([]*spannerpb.StructType_Field, error) {
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("no result set metadata returned")
+	}
+	var metadata *spannerpb.ResultSetMetadata
+	if err := rows.Scan(&metadata); err != nil {
+		return nil, err
+	}
+	if !rows.NextResultSet() {
+		return nil, fmt.Errorf("no result set returned")
+	}
+	return metadata.GetRowType().GetFields(), nil
+}
+
+// withExecOptions returns the given query arguments with ExecOptions that
+// are modified by fn. ExecOptions that are already in the arguments, for
+// example with the request tag of the query, are kept.
+func withExecOptions(vars []interface{}, fn func(options *spannerdriver.ExecOptions)) []interface{} {
+	var options spannerdriver.ExecOptions
+	args := make([]interface{}, 0, len(vars)+1)
+	for _, v := range vars {
+		switch o := v.(type) {
+		case spannerdriver.ExecOptions:
+			options = o
+		case *spannerdriver.ExecOptions:
+			options = *o
+		default:
+			args = append(args, v)
+		}
+	}
+	fn(&options)
+	return append(args, options)
+}
+
+// ImportMode determines how rows are written to the database.
+type ImportMode int
+
+const (
+	// ImportWithMutations writes the rows with insert mutations. This is the
+	// default and the most efficient way to import data.
+	ImportWithMutations ImportMode = iota
+	// ImportWithDML writes the rows with gorm in batches of INSERT
+	// statements. Use this to import data in a table that has a default
+	// value or a check that needs DML.
+	ImportWithDML
+)
+
+// ImportOptions contains the options for importing data.
+type ImportOptions struct {
+	// Mode determines how rows are written to the database.
+	Mode ImportMode
+	// BatchSize is the maximum number of rows that are written in one
+	// transaction. A transaction also never exceeds MaxMutations. The
+	// default is the largest number of rows that stays below MaxMutations.
+	BatchSize int
+	// MaxMutations is the maximum number of mutations in one transaction.
+	// The default is MaxMutationsPerCommit. Each value in a row counts as
+	// one mutation. Reduce this value for tables with secondary indexes, as
+	// the changes to an index also count as mutations.
+	MaxMutations int
+	// CSVNull is the text for NULL values in CSV files. The default is
+	// DefaultCSVNull.
+	CSVNull string
+}
+
+// Source is a file with data that is imported in a table.
+type Source struct {
+	// Table is the name of the table.
+	Table string
+	// Format is the format of the file.
+	Format Format
+	// Reader reads the contents of the file.
+	Reader io.Reader
+}
+
+// Import imports the given sources, and returns the number of imported rows.
+// Parent tables are imported before the tables that are interleaved in
+// them, and the other sources are imported in the given order. Each source
+// is imported in one or more transactions, which means that a failed import
+// can leave the rows that were already imported in the database.
+func Import(ctx context.Context, db *gorm.DB, opts ImportOptions, sources ...Source) (int64, error) {
+	if opts.MaxMutations <= 0 {
+		opts.MaxMutations = MaxMutationsPerCommit
+	}
+	tables := make([]string, len(sources))
+	for i, source := range sources {
+		tables[i] = source.Table
+	}
+	depths, err := interleaveDepths(ctx, db, tables)
+	if err != nil {
+		return 0, err
+	}
+	sorted := append([]Source{}, sources...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return depths[sorted[i].Table] < depths[sorted[j].Table]
+	})
+	var count int64
+	for _, source := range sorted {
+		n, err := importSource(ctx, db, opts, source)
+		count += n
+		if err != nil {
+			return count, fmt.Errorf("%s: %w", source.Table, err)
+		}
+	}
+	return count, nil
+}
+
+// interleaveDepths returns the number of interleaved parents of each of the
+// given tables.
+func interleaveDepths(ctx context.Context, db *gorm.DB, tables []string) (map[string]int, error) {
+	depths := make(map[string]int, len(tables))
+	if len(tables) < 2 {
+		return depths, nil
+	}
+	defaultSchema := ""
+	if db.Dialector.Name() == "postgres-spanner" {
+		defaultSchema = "public"
+	}
+	rows, err := db.WithContext(ctx).Raw(
+		"SELECT table_name, parent_table_name FROM information_schema.tables "+
+			"WHERE table_schema = ? AND parent_table_name IS NOT NULL", defaultSchema).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	parents := make(map[string]string)
+	for rows.Next() {
+		var table, parent string
+		if err := rows.Scan(&table, &parent); err != nil {
+			return nil, err
+		}
+		parents[strings.ToLower(table)] = strings.ToLower(parent)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, table := range tables {
+		depth := 0
+		for parent, ok := parents[strings.ToLower(table)]; ok && depth <= len(parents); parent, ok = parents[parent] {
+			depth++
+		}
+		depths[table] = depth
+	}
+	return depths, nil
+}
+
+// tableColumns contains the columns of a table.
+type tableColumns struct {
+	table string
+	types map[string]*spannerpb.Type
+	names map[string]string
+}
+
+// lookup returns the name and type of the column with the given name. The
+// name is case-insensitive.
+func (c *tableColumns) lookup(name string) (string, *spannerpb.Type, error) {
+	if t, ok := c.types[name]; ok {
+		return name, t, nil
+	}
+	if column, ok := c.names[strings.ToLower(name)]; ok {
+		return column, c.types[column], nil
+	}
+	return "", nil, fmt.Errorf("table %s has no column %s", c.table, name)
+}
+
+// queryColumns returns the columns of the given table.
+func queryColumns(ctx context.Context, db *gorm.DB, table string) (*tableColumns, error) {
+	tx := db.WithContext(ctx).Session(&gorm.Session{DryRun: true}).Table(table).Limit(0).Find(&[]map[string]interface{}{})
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	args := withExecOptions(tx.Statement.Vars, func(options *spannerdriver.ExecOptions) {
+		options.DecodeOption = spannerdriver.DecodeOptionProto
+		options.ReturnResultSetMetadata = true
+	})
+	rows, err := gormutil.UnpreparedConnPool(tx.Statement.ConnPool).QueryContext(ctx, tx.Statement.SQL.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	fields, err := readMetadata(rows)
+	if err != nil {
+		return nil, err
+	}
+	columns := &tableColumns{
+		table: table,
+		types: make(map[string]*spannerpb.Type, len(fields)),
+		names: make(map[string]string, len(fields)),
+	}
+	for _, field := range fields {
+		columns.types[field.Name] = field.Type
+		columns.names[strings.ToLower(field.Name)] = field.Name
+	}
+	return columns, rows.Err()
+}
+
+// importSource imports the rows in the given source in batches.
+func importSource(ctx context.Context, db *gorm.DB, opts ImportOptions, source Source) (int64, error) {
+	columns, err := queryColumns(ctx, db, source.Table)
+	if err != nil {
+		return 0, err
+	}
+	reader, err := newRowReader(source.Reader, source.Format, opts, columns)
+	if err != nil {
+		return 0, err
+	}
+	var batch importBatch
+	if opts.Mode == ImportWithDML {
+		batch = &dmlBatch{db: db.WithContext(ctx), table: source.Table}
+	} else {
+		batch = &mutationBatch{db: db, table: source.Table}
+	}
+	var count int64
+	var mutations int
+	for {
+		names, values, err := reader.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return count, err
+		}
+		// A batch is written when the next row would exceed MaxMutations, or
+		// when it contains BatchSize rows.
+		if batch.len() > 0 && (mutations+len(names) > opts.MaxMutations ||
+			opts.BatchSize > 0 && batch.len() >= opts.BatchSize) {
+			if err := batch.flush(ctx); err != nil {
+				return count, err
+			}
+			count += int64(batch.len())
+			batch.reset()
+			mutations = 0
+		}
+		row := make([]spanner.GenericColumnValue, len(names))
+		for i, name := range names {
+			_, t, _ := columns.lookup(name)
+			row[i] = spanner.GenericColumnValue{Type: t, Value: values[i]}
+		}
+		batch.add(names, row)
+		mutations += len(names)
+	}
+	if batch.len() > 0 {
+		if err := batch.flush(ctx); err != nil {
+			return count, err
+		}
+		count += int64(batch.len())
+	}
+	return count, nil
+}
+
+// importBatch collects rows and writes them in one transaction.
+type importBatch interface {
+	add(columns []string, values []spanner.GenericColumnValue)
+	len() int
+	flush(ctx context.Context) error
+	reset()
+}
+
+// mutationBatch writes rows with insert mutations.
+type mutationBatch struct {
+	db        *gorm.DB
+	table     string
+	mutations []*spanner.Mutation
+}
+
+func (b *mutationBatch) add(columns []string, values []spanner.GenericColumnValue) {
+	row := make([]interface{}, len(values))
+	for i, v := range values {
+		row[i] = v
+	}
+	b.mutations = append(b.mutations, spanner.Insert(b.table, columns, row))
+}
+
+func (b *mutationBatch) len() int {
+	return len(b.mutations)
+}
+
+func (b *mutationBatch) flush(ctx context.Context) error {
+	sqlDB, err := b.db.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+	return conn.Raw(func(driverConn any) error {
+		spannerConn, ok := driverConn.(spannerdriver.SpannerConn)
+		if !ok {
+			return fmt.Errorf("import with mutations is only supported for Spanner")
+		}
+		_, err := spannerConn.Apply(ctx, b.mutations)
+		return err
+	})
+}
+
+func (b *mutationBatch) reset() {
+	b.mutations = b.mutations[:0]
+}
+
+// dmlBatch writes rows with gorm.
+type dmlBatch struct {
+	db    *gorm.DB
+	table string
+	rows  []map[string]interface{}
+}
+
+func (b *dmlBatch) add(columns []string, values []spanner.GenericColumnValue) {
+	row := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		row[column] = values[i]
+	}
+	b.rows = append(b.rows, row)
+}
+
+func (b *dmlBatch) len() int {
+	return len(b.rows)
+}
+
+func (b *dmlBatch) flush(context.Context) error {
+	return b.db.Table(b.table).CreateInBatches(b.rows, len(b.rows)).Error
+}
+
+func (b *dmlBatch) reset() {
+	b.rows = b.rows[:0]
+}
@@ -0,0 +1,354 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataio_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	spannergorm "github.com/googleapis/go-gorm-spanner"
+	"github.com/googleapis/go-gorm-spanner/dataio"
+	"github.com/googleapis/go-gorm-spanner/emulatortest"
+	"github.com/googleapis/go-gorm-spanner/spannergormtest"
+	"github.com/googleapis/go-sql-spanner/testutil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gorm.io/gorm"
+)
+
+func singersResultSet() *spannerpb.ResultSet {
+	typeOf := func(code spannerpb.TypeCode) *spannerpb.Type {
+		return &spannerpb.Type{Code: code}
+	}
+	fields := []*spannerpb.StructType_Field{
+		{Name: "id", Type: typeOf(spannerpb.TypeCode_INT64)},
+		{Name: "name", Type: typeOf(spannerpb.TypeCode_STRING)},
+		{Name: "active", Type: typeOf(spannerpb.TypeCode_BOOL)},
+		{Name: "rating", Type: typeOf(spannerpb.TypeCode_FLOAT64)},
+		{Name: "budget", Type: typeOf(spannerpb.TypeCode_NUMERIC)},
+		{Name: "picture", Type: typeOf(spannerpb.TypeCode_BYTES)},
+		{Name: "details", Type: typeOf(spannerpb.TypeCode_JSON)},
+		{Name: "updated_at", Type: typeOf(spannerpb.TypeCode_TIMESTAMP)},
+		{Name: "scores", Type: &spannerpb.Type{Code: spannerpb.TypeCode_ARRAY, ArrayElementType: typeOf(spannerpb.TypeCode_INT64)}},
+	}
+	null := structpb.NewNullValue()
+	list := func(values ...*structpb.Value) *structpb.Value {
+		return structpb.NewListValue(&structpb.ListValue{Values: values})
+	}
+	return &spannerpb.ResultSet{
+		Metadata: &spannerpb.ResultSetMetadata{RowType: &spannerpb.StructType{Fields: fields}},
+		Rows: []*structpb.ListValue{
+			{Values: []*structpb.Value{
+				structpb.NewStringValue("9007199254740993"),
+				structpb.NewStringValue("Alice, \"Al\""),
+				structpb.NewBoolValue(true),
+				structpb.NewNumberValue(4.5),
+				structpb.NewStringValue("123.456"),
+				structpb.NewStringValue("cGlj"),
+				structpb.NewStringValue(`{"genre":"pop"}`),
+				structpb.NewStringValue("2024-01-02T03:04:05.123456Z"),
+				list(structpb.NewStringValue("1"), null, structpb.NewStringValue("3")),
+			}},
+			{Values: []*structpb.Value{
+				structpb.NewStringValue("2"),
+				structpb.NewStringValue(""),
+				null,
+				structpb.NewStringValue("NaN"),
+				null,
+				null,
+				null,
+				null,
+				list(),
+			}},
+		},
+	}
+}
+
+func putResultSet(server *spannergormtest.Server, query string, resultSet *spannerpb.ResultSet) {
+	_ = server.TestSpanner.PutStatementResult(query, &testutil.StatementResult{
+		Type:      testutil.StatementResultResultSet,
+		ResultSet: resultSet,
+	})
+}
+
+func TestExportTable(t *testing.T) {
+	t.Parallel()
+
+	db, server := spannergormtest.Open(t, spannergormtest.Config{})
+	putResultSet(server, "SELECT * FROM `singers`", singersResultSet())
+	for _, test := range []struct {
+		format dataio.Format
+		want   string
+	}{
+		{
+			format: dataio.CSV,
+			want: "id,name,active,rating,budget,picture,details,updated_at,scores\n" +
+				"9007199254740993,\"Alice, \"\"Al\"\"\",true,4.5,123.456,cGlj,\"{\"\"genre\"\":\"\"pop\"\"}\",2024-01-02T03:04:05.123456Z,\"[\"\"1\"\",null,\"\"3\"\"]\"\n" +
+				"2,,\\N,NaN,\\N,\\N,\\N,\\N,[]\n",
+		},
+		{
+			format: dataio.JSONLines,
+			want: `{"id":9007199254740993,"name":"Alice, \"Al\"","active":true,"rating":4.5,"budget":"123.456","picture":"cGlj","details":{"genre":"pop"},"updated_at":"2024-01-02T03:04:05.123456Z","scores":[1,null,3]}` + "\n" +
+				`{"id":2,"name":"","active":null,"rating":"NaN","budget":null,"picture":null,"details":null,"updated_at":null,"scores":[]}` + "\n",
+		},
+	} {
+		var buf bytes.Buffer
+		n, err := dataio.ExportTable(context.Background(), db, &buf, dataio.ExportOptions{Format: test.format}, "singers")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g, w := n, int64(2); g != w {
+			t.Fatalf("%v: row count mismatch\n Got: %v\nWant: %v", test.format, g, w)
+		}
+		if g, w := buf.String(), test.want; g != w {
+			t.Fatalf("%v: output mismatch\n Got: %v\nWant: %v", test.format, g, w)
+		}
+	}
+}
+
+func TestExportQuery(t *testing.T) {
+	t.Parallel()
+
+	db, server := spannergormtest.Open(t, spannergormtest.Config{Dialect: spannergormtest.PostgreSQL})
+	type Singer struct {
+		ID   int64
+		Name string
+	}
+	const query = `SELECT * FROM "singers" WHERE name = $1`
+	server.PutQueryResult(query, []Singer{{ID: 1, Name: "Alice"}})
+	var buf bytes.Buffer
+	if _, err := dataio.Export(db, &buf, dataio.ExportOptions{Format: dataio.JSONLines}, func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("name = ?", "Alice").Find(&[]Singer{})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if g, w := buf.String(), `{"id":1,"name":"Alice"}`+"\n"; g != w {
+		t.Fatalf("output mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	server.AssertExecuted(query, map[string]interface{}{"p1": "Alice"})
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []dataio.Format{dataio.CSV, dataio.JSONLines, dataio.Avro} {
+		for _, mode := range []dataio.ImportMode{dataio.ImportWithMutations, dataio.ImportWithDML} {
+			db, server := spannergormtest.Open(t, spannergormtest.Config{})
+			resultSet := singersResultSet()
+			putResultSet(server, "SELECT * FROM `singers`", resultSet)
+			putResultSet(server, "SELECT * FROM `singers` LIMIT @p1", &spannerpb.ResultSet{Metadata: resultSet.Metadata})
+			server.PutUpdateCount("INSERT INTO `singers` (`active`,`budget`,`details`,`id`,`name`,`picture`,`rating`,`scores`,`updated_at`) VALUES (@p1,@p2,@p3,@p4,@p5,@p6,@p7,@p8,@p9),(@p10,@p11,@p12,@p13,@p14,@p15,@p16,@p17,@p18)", 2)
+
+			var buf bytes.Buffer
+			if _, err := dataio.ExportTable(context.Background(), db, &buf, dataio.ExportOptions{Format: format}, "singers"); err != nil {
+				t.Fatal(err)
+			}
+			server.ClearRequests()
+			n, err := dataio.Import(context.Background(), db, dataio.ImportOptions{Mode: mode}, dataio.Source{Table: "singers", Format: format, Reader: &buf})
+			if err != nil {
+				t.Fatalf("%v: %v", format, err)
+			}
+			if g, w := n, int64(2); g != w {
+				t.Fatalf("%v: row count mismatch\n Got: %v\nWant: %v", format, g, w)
+			}
+			var got [][]*structpb.Value
+			if mode == dataio.ImportWithMutations {
+				mutations := server.Mutations()
+				if g, w := len(mutations), 2; g != w {
+					t.Fatalf("%v: mutation count mismatch\n Got: %v\nWant: %v", format, g, w)
+				}
+				for _, m := range mutations {
+					got = append(got, m.GetInsert().GetValues()[0].GetValues())
+				}
+			} else {
+				requests := server.ExecuteSqlRequests()
+				params := requests[len(requests)-1].GetParams().GetFields()
+				columns := []string{"active", "budget", "details", "id", "name", "picture", "rating", "scores", "updated_at"}
+				order := []int{3, 4, 0, 6, 1, 5, 2, 8, 7}
+				for row := 0; row < 2; row++ {
+					var values []*structpb.Value
+					for _, i := range order {
+						values = append(values, params[fmt.Sprintf("p%d", row*len(columns)+i+1)])
+					}
+					got = append(got, values)
+				}
+			}
+			for i, row := range resultSet.Rows {
+				want := row.Values
+				if i == 1 {
+					// NaN is imported as a number value.
+					want = append([]*structpb.Value{}, want...)
+					want[3] = structpb.NewNumberValue(math.NaN())
+				}
+				for j := range want {
+					if !equalValues(got[i][j], want[j]) {
+						t.Fatalf("%v/%v: value mismatch for row %d column %d\n Got: %v\nWant: %v", format, mode, i, j, got[i][j], want[j])
+					}
+				}
+			}
+		}
+	}
+}
+
+func equalValues(a, b *structpb.Value) bool {
+	if math.IsNaN(a.GetNumberValue()) && math.IsNaN(b.GetNumberValue()) {
+		return true
+	}
+	return proto.Equal(a, b)
+}
+
+func TestImportInterleaveOrder(t *testing.T) {
+	t.Parallel()
+
+	db, server := spannergormtest.Open(t, spannergormtest.Config{})
+	type Table struct {
+		TableName       string
+		ParentTableName string
+	}
+	server.PutQueryResult("SELECT table_name, parent_table_name FROM information_schema.tables WHERE table_schema = @p1 AND parent_table_name IS NOT NULL",
+		[]Table{{TableName: "tracks", ParentTableName: "albums"}, {TableName: "albums", ParentTableName: "singers"}})
+	type Row struct {
+		ID int64
+	}
+	for _, table := range []string{"singers", "albums", "tracks"} {
+		server.PutQueryResult("SELECT * FROM `"+table+"` LIMIT @p1", []Row{})
+	}
+	var sources []dataio.Source
+	for _, table := range []string{"tracks", "singers", "albums"} {
+		sources = append(sources, dataio.Source{Table: table, Format: dataio.CSV, Reader: bytes.NewBufferString("id\n1\n2\n3\n")})
+	}
+	n, err := dataio.Import(context.Background(), db, dataio.ImportOptions{MaxMutations: 2}, sources...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := n, int64(9); g != w {
+		t.Fatalf("row count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	var tables []string
+	for _, commit := range server.CommitRequests() {
+		tables = append(tables, commit.GetMutations()[0].GetInsert().GetTable())
+	}
+	// Each table is imported in two transactions of at most two mutations.
+	if g, w := tables, []string{"singers", "singers", "albums", "albums", "tracks", "tracks"}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("table order mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestImportBatchSize(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		opts dataio.ImportOptions
+		want []int
+	}{
+		{opts: dataio.ImportOptions{BatchSize: 2}, want: []int{2, 1}},
+		// MaxMutations also applies if BatchSize has been set.
+		{opts: dataio.ImportOptions{BatchSize: 2, MaxMutations: 3}, want: []int{1, 1, 1}},
+		{opts: dataio.ImportOptions{MaxMutations: 4}, want: []int{2, 1}},
+	} {
+		db, server := spannergormtest.Open(t, spannergormtest.Config{})
+		server.PutQueryResult("SELECT table_name, parent_table_name FROM information_schema.tables WHERE table_schema = @p1 AND parent_table_name IS NOT NULL", []struct{ TableName string }{})
+		server.PutQueryResult("SELECT * FROM `singers` LIMIT @p1", []struct {
+			ID   int64
+			Name string
+		}{})
+		source := dataio.Source{Table: "singers", Format: dataio.CSV, Reader: bytes.NewBufferString("id,name\n1,a\n2,b\n3,c\n")}
+		if _, err := dataio.Import(context.Background(), db, test.opts, source); err != nil {
+			t.Fatal(err)
+		}
+		var rows []int
+		for _, commit := range server.CommitRequests() {
+			rows = append(rows, len(commit.GetMutations()))
+		}
+		if g, w := rows, test.want; !reflect.DeepEqual(g, w) {
+			t.Fatalf("%+v: rows per commit mismatch\n Got: %v\nWant: %v", test.opts, g, w)
+		}
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	t.Parallel()
+
+	for path, want := range map[string]dataio.Format{"a.csv": dataio.CSV, "b.JSONL": dataio.JSONLines, "c.ndjson": dataio.JSONLines, "d.avro": dataio.Avro} {
+		if g, err := dataio.FormatFromPath(path); err != nil || g != want {
+			t.Fatalf("%s: format mismatch\n Got: %v, %v\nWant: %v", path, g, err, want)
+		}
+	}
+	if _, err := dataio.FormatFromPath("e.txt"); err == nil {
+		t.Fatal("missing error for unknown extension")
+	}
+}
+
+func TestEmulatorRoundTrip(t *testing.T) {
+	if _, ok := os.LookupEnv("SPANNER_EMULATOR_HOST"); !ok {
+		t.Skip("SPANNER_EMULATOR_HOST has not been set")
+	}
+	ctx := context.Background()
+	emulator, err := emulatortest.Start(ctx, emulatortest.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = emulator.Stop(ctx) }()
+
+	type Singer struct {
+		ID      int64 `gorm:"primaryKey;autoIncrement:false"`
+		Name    string
+		Budget  spanner.NullNumeric
+		Details spanner.NullJSON
+		Scores  spannergorm.Int64Array
+	}
+	for _, format := range []dataio.Format{dataio.CSV, dataio.JSONLines, dataio.Avro} {
+		db := emulator.OpenDatabase(t, emulatortest.DatabaseConfig{Models: []interface{}{&Singer{}}})
+		want := []Singer{
+			{ID: 1, Name: "Alice", Budget: spanner.NullNumeric{Numeric: *big.NewRat(1, 4), Valid: true},
+				Details: spanner.NullJSON{Value: map[string]interface{}{"genre": "pop"}, Valid: true}, Scores: []int64{1, 2}},
+			{ID: 2, Name: "Bob"},
+		}
+		if err := db.Create(&want).Error; err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if _, err := dataio.ExportTable(ctx, db, &buf, dataio.ExportOptions{Format: format}, "singers"); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Exec("DELETE FROM singers WHERE TRUE").Error; err != nil {
+			t.Fatal(err)
+		}
+		if _, err := dataio.Import(ctx, db, dataio.ImportOptions{}, dataio.Source{Table: "singers", Format: format, Reader: &buf}); err != nil {
+			t.Fatalf("%v: %v", format, err)
+		}
+		var got []Singer
+		if err := db.Order("id").Find(&got).Error; err != nil {
+			t.Fatal(err)
+		}
+		if g, w := len(got), len(want); g != w {
+			t.Fatalf("%v: row count mismatch\n Got: %v\nWant: %v", format, g, w)
+		}
+		if g, w := got[0].Budget.String(), want[0].Budget.String(); g != w {
+			t.Fatalf("%v: budget mismatch\n Got: %v\nWant: %v", format, g, w)
+		}
+		if g, w := got[0].Scores, want[0].Scores; !reflect.DeepEqual(g, w) {
+			t.Fatalf("%v: scores mismatch\n Got: %v\nWant: %v", format, g, w)
+		}
+	}
+}
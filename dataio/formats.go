@@ -0,0 +1,461 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataio
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// rowWriter writes exported rows in a file format.
+type rowWriter interface {
+	write(row []*structpb.Value) error
+	close() error
+}
+
+// rowReader reads the rows of a file that is imported. read returns the
+// column names and values of the next row, or io.EOF if there are no more
+// rows.
+type rowReader interface {
+	read() ([]string, []*structpb.Value, error)
+}
+
+func newRowWriter(w io.Writer, opts ExportOptions, name string, fields []*spannerpb.StructType_Field) (rowWriter, error) {
+	switch opts.Format {
+	case CSV:
+		return newCSVWriter(w, opts.CSVNull, fields)
+	case JSONLines:
+		return &jsonWriter{w: bufio.NewWriter(w), fields: fields}, nil
+	case Avro:
+		return newAvroWriter(w, name, fields)
+	}
+	return nil, fmt.Errorf("unsupported format: %v", opts.Format)
+}
+
+func newRowReader(r io.Reader, format Format, opts ImportOptions, columns *tableColumns) (rowReader, error) {
+	switch format {
+	case CSV:
+		return newCSVReader(r, opts.CSVNull, columns)
+	case JSONLines:
+		return newJSONReader(r, columns), nil
+	case Avro:
+		return newAvroReader(r, columns)
+	}
+	return nil, fmt.Errorf("unsupported format: %v", format)
+}
+
+// isNull returns true if the given value is NULL.
+func isNull(v *structpb.Value) bool {
+	if v == nil {
+		return true
+	}
+	_, ok := v.GetKind().(*structpb.Value_NullValue)
+	return ok
+}
+
+// isFloat returns true if the type is FLOAT32 or FLOAT64.
+func isFloat(t *spannerpb.Type) bool {
+	return t.GetCode() == spannerpb.TypeCode_FLOAT64 || t.GetCode() == spannerpb.TypeCode_FLOAT32
+}
+
+// floatValue returns the float value of a FLOAT32 or FLOAT64 value. Spanner
+// encodes NaN and infinity as strings.
+func floatValue(v *structpb.Value) (float64, error) {
+	if s, ok := v.GetKind().(*structpb.Value_StringValue); ok {
+		return strconv.ParseFloat(s.StringValue, 64)
+	}
+	return v.GetNumberValue(), nil
+}
+
+// formatFloat formats a float value of the given type as text.
+func formatFloat(t *spannerpb.Type, f float64) string {
+	bitSize := 64
+	if t.GetCode() == spannerpb.TypeCode_FLOAT32 {
+		bitSize = 32
+	}
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+	return strconv.FormatFloat(f, 'g', -1, bitSize)
+}
+
+// textValue returns the text representation of a value of the given type.
+// Values of most types are encoded as strings by Spanner, and use the same
+// text. ARRAY and STRUCT values are encoded as JSON.
+func textValue(t *spannerpb.Type, v *structpb.Value) (string, error) {
+	switch {
+	case t.GetCode() == spannerpb.TypeCode_BOOL:
+		return strconv.FormatBool(v.GetBoolValue()), nil
+	case isFloat(t):
+		f, err := floatValue(v)
+		if err != nil {
+			return "", err
+		}
+		return formatFloat(t, f), nil
+	case t.GetCode() == spannerpb.TypeCode_ARRAY || t.GetCode() == spannerpb.TypeCode_STRUCT:
+		b, err := listJSON(v)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+	return v.GetStringValue(), nil
+}
+
+// listJSON returns the JSON encoding of an ARRAY or STRUCT value. The
+// encoding is the same as the protobuf JSON encoding of the value, without
+// the random whitespace that protojson adds to its output.
+func listJSON(v *structpb.Value) ([]byte, error) {
+	return json.Marshal(v.AsInterface())
+}
+
+// parseText parses the text representation of a value of the given type.
+func parseText(t *spannerpb.Type, s string) (*structpb.Value, error) {
+	switch {
+	case t.GetCode() == spannerpb.TypeCode_BOOL:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, err
+		}
+		return structpb.NewBoolValue(b), nil
+	case isFloat(t):
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+		return structpb.NewNumberValue(f), nil
+	case t.GetCode() == spannerpb.TypeCode_ARRAY:
+		v := &structpb.Value{}
+		if err := protojson.Unmarshal([]byte(s), v); err != nil {
+			return nil, err
+		}
+		if _, ok := v.GetKind().(*structpb.Value_ListValue); !ok {
+			return nil, fmt.Errorf("invalid array value: %s", s)
+		}
+		return v, nil
+	case t.GetCode() == spannerpb.TypeCode_STRUCT:
+		return nil, fmt.Errorf("STRUCT values are not supported")
+	}
+	return structpb.NewStringValue(s), nil
+}
+
+type csvWriter struct {
+	w      *csv.Writer
+	null   string
+	fields []*spannerpb.StructType_Field
+	record []string
+}
+
+func newCSVWriter(w io.Writer, null string, fields []*spannerpb.StructType_Field) (*csvWriter, error) {
+	if null == "" {
+		null = DefaultCSVNull
+	}
+	writer := &csvWriter{w: csv.NewWriter(w), null: null, fields: fields, record: make([]string, len(fields))}
+	for i, field := range fields {
+		writer.record[i] = field.Name
+	}
+	if err := writer.w.Write(writer.record); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (w *csvWriter) write(row []*structpb.Value) (err error) {
+	for i, v := range row {
+		if isNull(v) {
+			w.record[i] = w.null
+		} else if w.record[i], err = textValue(w.fields[i].Type, v); err != nil {
+			return fmt.Errorf("%s: %w", w.fields[i].Name, err)
+		}
+	}
+	return w.w.Write(w.record)
+}
+
+func (w *csvWriter) close() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+type csvReader struct {
+	r     *csv.Reader
+	null  string
+	names []string
+	types []*spannerpb.Type
+}
+
+func newCSVReader(r io.Reader, null string, columns *tableColumns) (*csvReader, error) {
+	if null == "" {
+		null = DefaultCSVNull
+	}
+	reader := &csvReader{r: csv.NewReader(r), null: null}
+	header, err := reader.r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	reader.names = make([]string, len(header))
+	reader.types = make([]*spannerpb.Type, len(header))
+	for i, name := range header {
+		if reader.names[i], reader.types[i], err = columns.lookup(name); err != nil {
+			return nil, err
+		}
+	}
+	return reader, nil
+}
+
+func (r *csvReader) read() ([]string, []*structpb.Value, error) {
+	record, err := r.r.Read()
+	if err != nil {
+		return nil, nil, err
+	}
+	values := make([]*structpb.Value, len(record))
+	for i, s := range record {
+		if s == r.null {
+			values[i] = structpb.NewNullValue()
+		} else if values[i], err = parseText(r.types[i], s); err != nil {
+			line, _ := r.r.FieldPos(i)
+			return nil, nil, fmt.Errorf("line %d: %s: %w", line, r.names[i], err)
+		}
+	}
+	return r.names, values, nil
+}
+
+type jsonWriter struct {
+	w      *bufio.Writer
+	fields []*spannerpb.StructType_Field
+	buf    []byte
+}
+
+func (w *jsonWriter) write(row []*structpb.Value) error {
+	w.buf = append(w.buf[:0], '{')
+	for i, v := range row {
+		if i > 0 {
+			w.buf = append(w.buf, ',')
+		}
+		name, err := json.Marshal(w.fields[i].Name)
+		if err != nil {
+			return err
+		}
+		w.buf = append(append(w.buf, name...), ':')
+		if w.buf, err = appendJSON(w.buf, w.fields[i].Type, v); err != nil {
+			return fmt.Errorf("%s: %w", w.fields[i].Name, err)
+		}
+	}
+	w.buf = append(w.buf, '}', '\n')
+	_, err := w.w.Write(w.buf)
+	return err
+}
+
+func (w *jsonWriter) close() error {
+	return w.w.Flush()
+}
+
+// appendJSON appends the JSON representation of a value of the given type to
+// buf. INT64 values are written as numbers, and JSON values are embedded.
+func appendJSON(buf []byte, t *spannerpb.Type, v *structpb.Value) ([]byte, error) {
+	if isNull(v) {
+		return append(buf, "null"...), nil
+	}
+	switch t.GetCode() {
+	case spannerpb.TypeCode_BOOL:
+		return strconv.AppendBool(buf, v.GetBoolValue()), nil
+	case spannerpb.TypeCode_INT64, spannerpb.TypeCode_ENUM:
+		if _, err := strconv.ParseInt(v.GetStringValue(), 10, 64); err != nil {
+			return nil, err
+		}
+		return append(buf, v.GetStringValue()...), nil
+	case spannerpb.TypeCode_FLOAT64, spannerpb.TypeCode_FLOAT32:
+		f, err := floatValue(v)
+		if err != nil {
+			return nil, err
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return strconv.AppendQuote(buf, formatFloat(t, f)), nil
+		}
+		return append(buf, formatFloat(t, f)...), nil
+	case spannerpb.TypeCode_JSON:
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, []byte(v.GetStringValue())); err != nil {
+			return nil, err
+		}
+		return append(buf, compact.Bytes()...), nil
+	case spannerpb.TypeCode_ARRAY:
+		buf = append(buf, '[')
+		for i, e := range v.GetListValue().GetValues() {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			var err error
+			if buf, err = appendJSON(buf, t.GetArrayElementType(), e); err != nil {
+				return nil, err
+			}
+		}
+		return append(buf, ']'), nil
+	case spannerpb.TypeCode_STRUCT:
+		buf = append(buf, '{')
+		fields := t.GetStructType().GetFields()
+		for i, e := range v.GetListValue().GetValues() {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			name, err := json.Marshal(fields[i].Name)
+			if err != nil {
+				return nil, err
+			}
+			buf = append(append(buf, name...), ':')
+			if buf, err = appendJSON(buf, fields[i].Type, e); err != nil {
+				return nil, err
+			}
+		}
+		return append(buf, '}'), nil
+	}
+	b, err := json.Marshal(v.GetStringValue())
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, b...), nil
+}
+
+// parseJSON parses the JSON representation of a value of the given type.
+func parseJSON(t *spannerpb.Type, raw json.RawMessage) (*structpb.Value, error) {
+	if string(raw) == "null" {
+		return structpb.NewNullValue(), nil
+	}
+	switch t.GetCode() {
+	case spannerpb.TypeCode_BOOL:
+		var b bool
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return nil, err
+		}
+		return structpb.NewBoolValue(b), nil
+	case spannerpb.TypeCode_INT64, spannerpb.TypeCode_ENUM:
+		// Both numbers and strings are accepted for INT64 values.
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			var n json.Number
+			if err := json.Unmarshal(raw, &n); err != nil {
+				return nil, err
+			}
+			s = n.String()
+		}
+		if _, err := strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, err
+		}
+		return structpb.NewStringValue(s), nil
+	case spannerpb.TypeCode_FLOAT64, spannerpb.TypeCode_FLOAT32:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			s = string(raw)
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+		return structpb.NewNumberValue(f), nil
+	case spannerpb.TypeCode_JSON:
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, raw); err != nil {
+			return nil, err
+		}
+		return structpb.NewStringValue(compact.String()), nil
+	case spannerpb.TypeCode_ARRAY:
+		var elements []json.RawMessage
+		if err := json.Unmarshal(raw, &elements); err != nil {
+			return nil, err
+		}
+		values := make([]*structpb.Value, len(elements))
+		for i, e := range elements {
+			var err error
+			if values[i], err = parseJSON(t.GetArrayElementType(), e); err != nil {
+				return nil, err
+			}
+		}
+		return structpb.NewListValue(&structpb.ListValue{Values: values}), nil
+	case spannerpb.TypeCode_STRUCT:
+		return nil, fmt.Errorf("STRUCT values are not supported")
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, err
+	}
+	return structpb.NewStringValue(s), nil
+}
+
+type jsonReader struct {
+	r       *bufio.Reader
+	columns *tableColumns
+	line    int
+}
+
+func newJSONReader(r io.Reader, columns *tableColumns) *jsonReader {
+	return &jsonReader{r: bufio.NewReader(r), columns: columns}
+}
+
+func (r *jsonReader) read() ([]string, []*structpb.Value, error) {
+	var line []byte
+	for len(bytes.TrimSpace(line)) == 0 {
+		var err error
+		line, err = r.r.ReadBytes('\n')
+		r.line++
+		if err == io.EOF && len(bytes.TrimSpace(line)) == 0 {
+			return nil, nil, io.EOF
+		}
+		if err != nil && err != io.EOF {
+			return nil, nil, err
+		}
+	}
+	// Decode the object with the columns in the order of the line.
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	if t, err := decoder.Token(); err != nil || t != json.Delim('{') {
+		return nil, nil, fmt.Errorf("line %d: expected a JSON object", r.line)
+	}
+	var names []string
+	var values []*structpb.Value
+	for decoder.More() {
+		t, err := decoder.Token()
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", r.line, err)
+		}
+		name, columnType, err := r.columns.lookup(t.(string))
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", r.line, err)
+		}
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", r.line, err)
+		}
+		value, err := parseJSON(columnType, raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %s: %w", r.line, name, err)
+		}
+		names = append(names, name)
+		values = append(values, value)
+	}
+	return names, values, nil
+}
@@ -0,0 +1,336 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package emulatortest manages a Spanner emulator for integration tests of
+// applications that use gorm with Spanner. The emulator is started once, for
+// example in TestMain, and each test creates its own database on the
+// emulator. This allows tests to run in parallel without interfering with
+// each other.
+//
+// Example:
+//
+//	var emulator *emulatortest.Emulator
+//
+//	func TestMain(m *testing.M) {
+//		var err error
+//		emulator, err = emulatortest.Start(context.Background(), emulatortest.Options{})
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		code := m.Run()
+//		_ = emulator.Stop(context.Background())
+//		os.Exit(code)
+//	}
+//
+//	func TestSingers(t *testing.T) {
+//		t.Parallel()
+//		db := emulator.OpenDatabase(t, emulatortest.DatabaseConfig{Models: []interface{}{&Singer{}}})
+//		...
+//	}
+package emulatortest
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	database "cloud.google.com/go/spanner/admin/database/apiv1"
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	instance "cloud.google.com/go/spanner/admin/instance/apiv1"
+	"cloud.google.com/go/spanner/admin/instance/apiv1/instancepb"
+	spannergorm "github.com/googleapis/go-gorm-spanner"
+	spannerpg "github.com/googleapis/go-gorm-spanner/postgresql"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Options contains the options for starting an emulator.
+type Options struct {
+	// Runner starts and stops the emulator. The default is DefaultRunner().
+	Runner Runner
+	// ProjectID is the project of the test instance. The default is
+	// "emulator-project".
+	ProjectID string
+	// InstanceID is the test instance that is created on the emulator. The
+	// default is "test-instance".
+	InstanceID string
+	// StartupTimeout is the maximum time to wait for the emulator to be
+	// ready. The default is 30 seconds.
+	StartupTimeout time.Duration
+}
+
+// Emulator is a running Spanner emulator with a test instance.
+type Emulator struct {
+	runner     Runner
+	host       string
+	projectID  string
+	instanceID string
+
+	instanceAdmin *instance.InstanceAdminClient
+	databaseAdmin *database.DatabaseAdminClient
+	// prefix and counter are used to generate unique database IDs.
+	prefix  string
+	counter atomic.Int64
+}
+
+// Start starts an emulator with the runner in the options, waits until the
+// emulator is ready, and creates the test instance on the emulator.
+func Start(ctx context.Context, opts Options) (*Emulator, error) {
+	if opts.Runner == nil {
+		opts.Runner = DefaultRunner()
+	}
+	if opts.ProjectID == "" {
+		opts.ProjectID = "emulator-project"
+	}
+	if opts.InstanceID == "" {
+		opts.InstanceID = "test-instance"
+	}
+	if opts.StartupTimeout == 0 {
+		opts.StartupTimeout = 30 * time.Second
+	}
+	host, err := opts.Runner.Start(ctx)
+	if err != nil {
+		return nil, err
+	}
+	e := &Emulator{
+		runner:     opts.Runner,
+		host:       host,
+		projectID:  opts.ProjectID,
+		instanceID: opts.InstanceID,
+		prefix:     strconv.FormatInt(time.Now().Unix()%(1<<32), 36),
+	}
+	if err := e.init(ctx, opts.StartupTimeout); err != nil {
+		_ = e.Stop(ctx)
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *Emulator) init(ctx context.Context, timeout time.Duration) (err error) {
+	clientOptions := []option.ClientOption{
+		option.WithEndpoint(e.host),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	}
+	if e.instanceAdmin, err = instance.NewInstanceAdminClient(ctx, clientOptions...); err != nil {
+		return err
+	}
+	if e.databaseAdmin, err = database.NewDatabaseAdminClient(ctx, clientOptions...); err != nil {
+		return err
+	}
+	// Wait until the emulator responds to requests.
+	deadline := time.Now().Add(timeout)
+	for {
+		attemptCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		_, err = e.instanceAdmin.GetInstance(attemptCtx, &instancepb.GetInstanceRequest{Name: e.InstanceName()})
+		cancel()
+		if err == nil || spanner.ErrCode(err) == codes.NotFound {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("emulator at %s did not become ready within %v: %w", e.host, timeout, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+	if err == nil {
+		// The instance already exists on an emulator that was already running.
+		return nil
+	}
+	op, err := e.instanceAdmin.CreateInstance(ctx, &instancepb.CreateInstanceRequest{
+		Parent:     "projects/" + e.projectID,
+		InstanceId: e.instanceID,
+		Instance: &instancepb.Instance{
+			Config:      fmt.Sprintf("projects/%s/instanceConfigs/emulator-config", e.projectID),
+			DisplayName: e.instanceID,
+			NodeCount:   1,
+		},
+	})
+	if err != nil {
+		if spanner.ErrCode(err) == codes.AlreadyExists {
+			return nil
+		}
+		return err
+	}
+	_, err = op.Wait(ctx)
+	return err
+}
+
+// Stop closes the admin clients of the emulator and stops the emulator.
+// Databases that have not been dropped are removed together with the
+// emulator, unless the emulator was already running when Start was called.
+func (e *Emulator) Stop(ctx context.Context) error {
+	if e.instanceAdmin != nil {
+		_ = e.instanceAdmin.Close()
+	}
+	if e.databaseAdmin != nil {
+		_ = e.databaseAdmin.Close()
+	}
+	return e.runner.Stop(ctx)
+}
+
+// Host returns the host:port address of the gRPC endpoint of the emulator.
+func (e *Emulator) Host() string {
+	return e.host
+}
+
+// InstanceName returns the fully qualified name of the test instance.
+func (e *Emulator) InstanceName() string {
+	return fmt.Sprintf("projects/%s/instances/%s", e.projectID, e.instanceID)
+}
+
+// DSN returns the data source name for connecting to the given database on
+// the emulator.
+func (e *Emulator) DSN(databaseID string) string {
+	return fmt.Sprintf("%s/%s/databases/%s?usePlainText=true", e.host, e.InstanceName(), databaseID)
+}
+
+// CreateDatabase creates a new database with a unique ID and the given
+// dialect on the emulator, and executes the given DDL statements. It returns
+// the ID of the database.
+func (e *Emulator) CreateDatabase(ctx context.Context, dialect databasepb.DatabaseDialect, statements ...string) (string, error) {
+	databaseID := fmt.Sprintf("db-%s-%d", e.prefix, e.counter.Add(1))
+	createStatement := "CREATE DATABASE `" + databaseID + "`"
+	extraStatements := statements
+	if dialect == databasepb.DatabaseDialect_POSTGRESQL {
+		// PostgreSQL databases do not support extra statements in the
+		// CreateDatabase request.
+		createStatement = `CREATE DATABASE "` + databaseID + `"`
+		extraStatements = nil
+	}
+	op, err := e.databaseAdmin.CreateDatabase(ctx, &databasepb.CreateDatabaseRequest{
+		Parent:          e.InstanceName(),
+		CreateStatement: createStatement,
+		DatabaseDialect: dialect,
+		ExtraStatements: extraStatements,
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, err := op.Wait(ctx); err != nil {
+		return "", err
+	}
+	if dialect == databasepb.DatabaseDialect_POSTGRESQL && len(statements) > 0 {
+		if err := e.UpdateDDL(ctx, databaseID, statements...); err != nil {
+			_ = e.DropDatabase(ctx, databaseID)
+			return "", err
+		}
+	}
+	return databaseID, nil
+}
+
+// UpdateDDL executes the given DDL statements on the given database.
+func (e *Emulator) UpdateDDL(ctx context.Context, databaseID string, statements ...string) error {
+	op, err := e.databaseAdmin.UpdateDatabaseDdl(ctx, &databasepb.UpdateDatabaseDdlRequest{
+		Database:   e.InstanceName() + "/databases/" + databaseID,
+		Statements: statements,
+	})
+	if err != nil {
+		return err
+	}
+	return op.Wait(ctx)
+}
+
+// DropDatabase drops the given database.
+func (e *Emulator) DropDatabase(ctx context.Context, databaseID string) error {
+	return e.databaseAdmin.DropDatabase(ctx, &databasepb.DropDatabaseRequest{
+		Database: e.InstanceName() + "/databases/" + databaseID,
+	})
+}
+
+// DatabaseConfig contains the configuration of a database that is created
+// by OpenDatabase.
+type DatabaseConfig struct {
+	// Dialect is the dialect of the database. The default is GoogleSQL.
+	Dialect databasepb.DatabaseDialect
+	// DDL contains the DDL statements that are executed when the database
+	// is created.
+	DDL []string
+	// Models contains the models that are migrated with AutoMigrate after
+	// the DDL statements have been executed.
+	Models []interface{}
+
+	// GoogleSQL is the configuration of the GoogleSQL dialector. The
+	// connection fields DriverName, DSN, Connector and Conn are set by
+	// OpenDatabase.
+	GoogleSQL spannergorm.Config
+	// PostgreSQL is the configuration of the PostgreSQL dialector.
+	PostgreSQL spannerpg.SpannerConfig
+	// Gorm is the gorm configuration of the database. The default is an
+	// empty configuration.
+	Gorm *gorm.Config
+}
+
+// OpenDatabase creates a new database on the emulator, applies the DDL
+// statements and migrates the models in the configuration, and returns a
+// gorm database for it. The database is closed and dropped when the test and
+// all its subtests have completed. Each call creates a separate database,
+// which allows parallel tests to each use their own database.
+func (e *Emulator) OpenDatabase(t testing.TB, config DatabaseConfig) *gorm.DB {
+	t.Helper()
+	ctx := context.Background()
+	if config.Dialect == databasepb.DatabaseDialect_DATABASE_DIALECT_UNSPECIFIED {
+		config.Dialect = databasepb.DatabaseDialect_GOOGLE_STANDARD_SQL
+	}
+	databaseID, err := e.CreateDatabase(ctx, config.Dialect, config.DDL...)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := e.DropDatabase(ctx, databaseID); err != nil {
+			t.Logf("failed to drop database %s: %v", databaseID, err)
+		}
+	})
+
+	var dialector gorm.Dialector
+	if config.Dialect == databasepb.DatabaseDialect_POSTGRESQL {
+		dialector = spannerpg.NewWithSpannerConfig(postgres.Config{DSN: e.DSN(databaseID)}, config.PostgreSQL)
+	} else {
+		c := config.GoogleSQL
+		c.DriverName, c.DSN, c.Connector, c.Conn = "spanner", e.DSN(databaseID), nil, nil
+		dialector = spannergorm.New(c)
+	}
+	gormConfig := config.Gorm
+	if gormConfig == nil {
+		gormConfig = &gorm.Config{}
+	}
+	db, err := gorm.Open(dialector, gormConfig)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	// Cleanup functions are called in last added, first called order, which
+	// means that the database is closed before it is dropped.
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	if len(config.Models) > 0 {
+		if err := db.AutoMigrate(config.Models...); err != nil {
+			t.Fatalf("failed to migrate models: %v", err)
+		}
+	}
+	return db
+}
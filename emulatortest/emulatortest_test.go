@@ -0,0 +1,115 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emulatortest
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+)
+
+func TestPublishedAddress(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		output  string
+		want    string
+		wantErr bool
+	}{
+		{output: "127.0.0.1:32768", want: "127.0.0.1:32768"},
+		{output: "0.0.0.0:32768\n[::]:32768\n", want: "localhost:32768"},
+		{output: "[::]:32769", want: "localhost:32769"},
+		{output: "", wantErr: true},
+		{output: "32768", wantErr: true},
+	} {
+		got, err := publishedAddress(test.output)
+		if (err != nil) != test.wantErr {
+			t.Fatalf("%q: error mismatch\n Got: %v\nWant error: %v", test.output, err, test.wantErr)
+		}
+		if g, w := got, test.want; g != w {
+			t.Fatalf("%q: address mismatch\n Got: %v\nWant: %v", test.output, g, w)
+		}
+	}
+}
+
+func TestRunningEmulator(t *testing.T) {
+	t.Parallel()
+
+	r := RunningEmulator("localhost:9010")
+	host, err := r.Start(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := host, "localhost:9010"; g != w {
+		t.Fatalf("host mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if err := r.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	e := &Emulator{host: host, projectID: "p", instanceID: "i"}
+	if g, w := e.DSN("d"), "localhost:9010/projects/p/instances/i/databases/d?usePlainText=true"; g != w {
+		t.Fatalf("dsn mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+type singer struct {
+	ID   int64 `gorm:"primaryKey;autoIncrement:false"`
+	Name string
+}
+
+func TestOpenDatabase(t *testing.T) {
+	if _, ok := os.LookupEnv("SPANNER_EMULATOR_HOST"); !ok {
+		t.Skip("SPANNER_EMULATOR_HOST has not been set")
+	}
+	ctx := context.Background()
+	emulator, err := Start(ctx, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = emulator.Stop(ctx) }()
+
+	for _, test := range []struct {
+		dialect databasepb.DatabaseDialect
+		ddl     string
+	}{
+		{databasepb.DatabaseDialect_GOOGLE_STANDARD_SQL, "CREATE TABLE albums (id INT64, title STRING(MAX)) PRIMARY KEY (id)"},
+		{databasepb.DatabaseDialect_POSTGRESQL, "CREATE TABLE albums (id bigint primary key, title varchar)"},
+	} {
+		test := test
+		t.Run(test.dialect.String(), func(t *testing.T) {
+			t.Parallel()
+			db := emulator.OpenDatabase(t, DatabaseConfig{
+				Dialect: test.dialect,
+				DDL:     []string{test.ddl},
+				Models:  []interface{}{&singer{}},
+			})
+			if err := db.Create(&singer{ID: 1, Name: "Alice"}).Error; err != nil {
+				t.Fatal(err)
+			}
+			var count int64
+			if err := db.Model(&singer{}).Count(&count).Error; err != nil {
+				t.Fatal(err)
+			}
+			if g, w := count, int64(1); g != w {
+				t.Fatalf("count mismatch\n Got: %v\nWant: %v", g, w)
+			}
+			if err := db.Exec("DELETE FROM albums WHERE true").Error; err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
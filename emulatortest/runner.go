@@ -0,0 +1,195 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emulatortest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// DefaultImage is the Docker image of the Spanner emulator.
+const DefaultImage = "gcr.io/cloud-spanner-emulator/emulator"
+
+// Runner starts and stops a Spanner emulator.
+type Runner interface {
+	// Start starts the emulator and returns the host:port address of the
+	// gRPC endpoint of the emulator. The emulator does not need to be ready
+	// to serve requests when Start returns.
+	Start(ctx context.Context) (string, error)
+	// Stop stops the emulator.
+	Stop(ctx context.Context) error
+}
+
+// DefaultRunner returns a runner for the emulator at SPANNER_EMULATOR_HOST
+// if that environment variable has been set, and a DockerRunner otherwise.
+func DefaultRunner() Runner {
+	if host, ok := os.LookupEnv("SPANNER_EMULATOR_HOST"); ok && host != "" {
+		return RunningEmulator(host)
+	}
+	return &DockerRunner{}
+}
+
+// RunningEmulator returns a runner for an emulator that is already running
+// at the given host:port address. The runner does not stop the emulator.
+func RunningEmulator(host string) Runner {
+	return runningEmulator(host)
+}
+
+type runningEmulator string
+
+func (r runningEmulator) Start(context.Context) (string, error) {
+	return string(r), nil
+}
+
+func (r runningEmulator) Stop(context.Context) error {
+	return nil
+}
+
+// DockerRunner runs the emulator in a Docker container. The runner uses the
+// docker command line tool, which must be installed on the local system.
+type DockerRunner struct {
+	// Image is the Docker image of the emulator. The default is
+	// DefaultImage.
+	Image string
+	// Command is the docker command. The default is "docker".
+	Command string
+
+	containerID string
+}
+
+func (r *DockerRunner) Start(ctx context.Context) (string, error) {
+	image := r.Image
+	if image == "" {
+		image = DefaultImage
+	}
+	// Publish the gRPC port of the emulator on a random port of the host.
+	id, err := r.docker(ctx, "run", "-d", "--rm", "-p", "127.0.0.1::9010", image)
+	if err != nil {
+		return "", fmt.Errorf("failed to start emulator container: %w", err)
+	}
+	r.containerID = id
+	port, err := r.docker(ctx, "port", id, "9010/tcp")
+	if err != nil {
+		_ = r.Stop(ctx)
+		return "", fmt.Errorf("failed to get port of emulator container: %w", err)
+	}
+	return publishedAddress(port)
+}
+
+func (r *DockerRunner) Stop(ctx context.Context) error {
+	if r.containerID == "" {
+		return nil
+	}
+	_, err := r.docker(ctx, "stop", r.containerID)
+	r.containerID = ""
+	return err
+}
+
+func (r *DockerRunner) docker(ctx context.Context, args ...string) (string, error) {
+	command := r.Command
+	if command == "" {
+		command = "docker"
+	}
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%s %s: %w: %s", command, args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// publishedAddress returns the address in the output of docker port, for
+// example 127.0.0.1:32768. docker port returns one line per address.
+func publishedAddress(output string) (string, error) {
+	line, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	host, port, err := net.SplitHostPort(strings.TrimSpace(line))
+	if err != nil {
+		return "", fmt.Errorf("invalid published port %q: %w", output, err)
+	}
+	if host == "0.0.0.0" || host == "::" || host == "" {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// BinaryRunner runs the emulator as a local process, using the gateway_main
+// binary from the emulator release.
+type BinaryRunner struct {
+	// Path is the path of the gateway_main binary.
+	Path string
+	// Port is the gRPC port of the emulator. A free port is used if no port
+	// has been set.
+	Port int
+	// Args contains additional command line arguments for the emulator.
+	Args []string
+
+	cmd *exec.Cmd
+}
+
+func (r *BinaryRunner) Start(context.Context) (string, error) {
+	port := r.Port
+	if port == 0 {
+		var err error
+		if port, err = freePort(); err != nil {
+			return "", err
+		}
+	}
+	httpPort, err := freePort()
+	if err != nil {
+		return "", err
+	}
+	args := append([]string{
+		"--hostname", "localhost",
+		"--grpc_port", strconv.Itoa(port),
+		"--http_port", strconv.Itoa(httpPort),
+	}, r.Args...)
+	// The process must outlive the context of Start, and is stopped by Stop.
+	r.cmd = exec.Command(r.Path, args...)
+	if err := r.cmd.Start(); err != nil {
+		r.cmd = nil
+		return "", fmt.Errorf("failed to start emulator: %w", err)
+	}
+	return net.JoinHostPort("localhost", strconv.Itoa(port)), nil
+}
+
+func (r *BinaryRunner) Stop(context.Context) error {
+	if r.cmd == nil {
+		return nil
+	}
+	cmd := r.cmd
+	r.cmd = nil
+	if err := cmd.Process.Kill(); err != nil {
+		return err
+	}
+	_ = cmd.Wait()
+	return nil
+}
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = l.Close() }()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,413 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fixtures loads test data into a Spanner database that is used with
+// gorm, and resets the tables of the database between tests.
+//
+// Fixtures are YAML or JSON files that contain the rows of one or more
+// tables. The top-level keys are table names, and each row is a map from
+// column (or field) name to value:
+//
+//	singers:
+//	  - id: 1
+//	    first_name: Alice
+//	albums:
+//	  - id: 1
+//	    singer_id: 1
+//	    title: Blue
+//
+// The rows are decoded into the registered models and written with
+// mutations in dependency order. Parent tables are written before the tables
+// that reference them, and Reset deletes the rows of child tables before the
+// rows of their parents. The dependencies are derived from the relationships
+// of the models. Interleaved tables should therefore be mapped with a
+// relationship to their parent, for example as a 'has many' relationship.
+//
+// Example:
+//
+//	f, err := fixtures.New(db, &Singer{}, &Album{})
+//	...
+//	if err := f.Reset(ctx); err != nil {
+//		t.Fatal(err)
+//	}
+//	if err := f.LoadFiles(ctx, "testdata/singers.yaml"); err != nil {
+//		t.Fatal(err)
+//	}
+package fixtures
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	spannergorm "github.com/googleapis/go-gorm-spanner"
+	spannerdriver "github.com/googleapis/go-sql-spanner"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// ResetMode determines how Reset deletes the rows in the tables.
+type ResetMode int
+
+const (
+	// ResetWithDML deletes all rows of each table with a DELETE statement
+	// in an autocommit transaction. This is the default.
+	ResetWithDML ResetMode = iota
+	// ResetWithPartitionedDML deletes all rows of each table with
+	// Partitioned DML. Use this for tables with more rows than the mutation
+	// limit of a single transaction.
+	ResetWithPartitionedDML
+)
+
+// Fixtures loads fixtures into the tables of a set of models, and resets
+// those tables.
+type Fixtures struct {
+	db *gorm.DB
+	// schemas contains the schemas of the models in dependency order.
+	schemas []*schema.Schema
+	tables  map[string]*schema.Schema
+
+	// ResetMode determines how Reset deletes the rows in the tables.
+	ResetMode ResetMode
+}
+
+// New parses the given models and returns a Fixtures for the tables of
+// the models.
+func New(db *gorm.DB, models ...interface{}) (*Fixtures, error) {
+	f := &Fixtures{db: db, tables: make(map[string]*schema.Schema, len(models))}
+	var schemas []*schema.Schema
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse model %T: %w", model, err)
+		}
+		if _, ok := f.tables[stmt.Schema.Table]; ok {
+			continue
+		}
+		f.tables[stmt.Schema.Table] = stmt.Schema
+		schemas = append(schemas, stmt.Schema)
+	}
+	f.schemas = dependencyOrder(schemas)
+	return f, nil
+}
+
+// Tables returns the names of the tables of the models in dependency order.
+// Tables that are referenced by other tables come before the tables that
+// reference them.
+func (f *Fixtures) Tables() []string {
+	tables := make([]string, len(f.schemas))
+	for i, s := range f.schemas {
+		tables[i] = s.Table
+	}
+	return tables
+}
+
+// dependencyOrder sorts the given schemas so that each schema comes after
+// the schemas that it references. References to schemas that are not in the
+// list are ignored, and the original order is kept for schemas that do not
+// depend on each other.
+func dependencyOrder(schemas []*schema.Schema) []*schema.Schema {
+	included := make(map[*schema.Schema]bool, len(schemas))
+	for _, s := range schemas {
+		included[s] = true
+	}
+	visited := make(map[*schema.Schema]bool, len(schemas))
+	result := make([]*schema.Schema, 0, len(schemas))
+	var visit func(s *schema.Schema)
+	visit = func(s *schema.Schema) {
+		if visited[s] {
+			return
+		}
+		visited[s] = true
+		for _, rel := range s.Relationships.Relations {
+			if c := rel.ParseConstraint(); c != nil && c.Schema == s && c.ReferenceSchema != s && included[c.ReferenceSchema] {
+				visit(c.ReferenceSchema)
+			}
+		}
+		result = append(result, s)
+	}
+	for _, s := range schemas {
+		visit(s)
+	}
+	return result
+}
+
+// LoadFiles loads the fixtures in the given YAML or JSON files. All rows in
+// the files are written in one transaction.
+func (f *Fixtures) LoadFiles(ctx context.Context, paths ...string) error {
+	var rows []tableRows
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fileRows, err := f.parse(ctx, data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		rows = append(rows, fileRows...)
+	}
+	return f.insert(ctx, rows)
+}
+
+// Load loads the fixtures in the given YAML or JSON document. All rows in
+// the document are written in one transaction.
+func (f *Fixtures) Load(ctx context.Context, data []byte) error {
+	rows, err := f.parse(ctx, data)
+	if err != nil {
+		return err
+	}
+	return f.insert(ctx, rows)
+}
+
+// Insert writes the given models with mutations in one transaction. The
+// models can be structs, pointers to structs, or slices of those, and must
+// belong to the models of the Fixtures.
+//
+// Insert does not call the hooks of the models. Fields that are zero and
+// have a default value are not written, and zero auto create and update
+// time fields are set to the current time.
+func (f *Fixtures) Insert(ctx context.Context, models ...interface{}) error {
+	var rows []tableRows
+	for _, model := range models {
+		value := reflect.Indirect(reflect.ValueOf(model))
+		if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+			if value.Len() == 0 {
+				continue
+			}
+		}
+		stmt := &gorm.Statement{DB: f.db}
+		if err := stmt.Parse(model); err != nil {
+			return fmt.Errorf("failed to parse model %T: %w", model, err)
+		}
+		s, ok := f.tables[stmt.Schema.Table]
+		if !ok {
+			return fmt.Errorf("table %s has not been registered", stmt.Schema.Table)
+		}
+		table := tableRows{schema: s}
+		if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+			for i := 0; i < value.Len(); i++ {
+				table.rows = append(table.rows, addressable(reflect.Indirect(value.Index(i))))
+			}
+		} else {
+			table.rows = append(table.rows, addressable(value))
+		}
+		rows = append(rows, table)
+	}
+	return f.insert(ctx, rows)
+}
+
+// addressable returns a copy of the given value if the value is not
+// addressable, so the auto time fields of the value can be set.
+func addressable(value reflect.Value) reflect.Value {
+	if value.CanAddr() {
+		return value
+	}
+	c := reflect.New(value.Type()).Elem()
+	c.Set(value)
+	return c
+}
+
+// Reset deletes all rows in the tables of the models. The tables are
+// cleared in reverse dependency order, so tables that reference other
+// tables are cleared first.
+func (f *Fixtures) Reset(ctx context.Context) error {
+	var args []interface{}
+	if f.ResetMode == ResetWithPartitionedDML {
+		args = append(args, spannerdriver.ExecOptions{AutocommitDMLMode: spannerdriver.PartitionedNonAtomic})
+	}
+	db := f.db.WithContext(ctx)
+	for i := len(f.schemas) - 1; i >= 0; i-- {
+		table := f.schemas[i].Table
+		if err := db.Exec(fmt.Sprintf("DELETE FROM %s WHERE TRUE", db.Statement.Quote(table)), args...).Error; err != nil {
+			return fmt.Errorf("failed to delete rows from %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// tableRows contains the struct values of the rows of one table.
+type tableRows struct {
+	schema *schema.Schema
+	rows   []reflect.Value
+}
+
+// parse decodes a YAML or JSON document into the models of the tables in
+// the document. Any valid JSON document is also a valid YAML document.
+func (f *Fixtures) parse(ctx context.Context, data []byte) ([]tableRows, error) {
+	var document map[string][]map[string]interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	var result []tableRows
+	for table, rows := range document {
+		s, ok := f.tables[table]
+		if !ok {
+			return nil, fmt.Errorf("table %s has not been registered", table)
+		}
+		values := tableRows{schema: s, rows: make([]reflect.Value, 0, len(rows))}
+		for i, row := range rows {
+			value := reflect.New(s.ModelType).Elem()
+			for column, v := range row {
+				field := s.LookUpField(column)
+				if field == nil {
+					return nil, fmt.Errorf("%s[%d]: unknown column %s", table, i, column)
+				}
+				if err := field.Set(ctx, value, v); err != nil {
+					return nil, fmt.Errorf("%s[%d]: invalid value for %s: %w", table, i, column, err)
+				}
+			}
+			values.rows = append(values.rows, value)
+		}
+		result = append(result, values)
+	}
+	return result, nil
+}
+
+// insert writes the given rows with mutations in dependency order.
+func (f *Fixtures) insert(ctx context.Context, rows []tableRows) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	byTable := make(map[*schema.Schema][]reflect.Value, len(rows))
+	for _, table := range rows {
+		byTable[table.schema] = append(byTable[table.schema], table.rows...)
+	}
+	sqlDB, err := f.db.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+	return conn.Raw(func(driverConn any) error {
+		spannerConn, ok := driverConn.(spannerdriver.SpannerConn)
+		if !ok {
+			return fmt.Errorf("fixtures are only supported for Spanner")
+		}
+		checker, ok := driverConn.(driver.NamedValueChecker)
+		if !ok {
+			return fmt.Errorf("fixtures are only supported for Spanner")
+		}
+		now := f.db.NowFunc()
+		var mutations []*spanner.Mutation
+		for _, s := range f.schemas {
+			for _, row := range byTable[s] {
+				m, err := mutation(ctx, checker, s, row, now)
+				if err != nil {
+					return fmt.Errorf("%s: %w", s.Table, err)
+				}
+				mutations = append(mutations, m)
+			}
+		}
+		_, err := spannerConn.Apply(ctx, mutations)
+		return err
+	})
+}
+
+// mutation returns an insert mutation for the given row.
+func mutation(ctx context.Context, checker driver.NamedValueChecker, s *schema.Schema, row reflect.Value, now time.Time) (*spanner.Mutation, error) {
+	columns := make([]string, 0, len(s.DBNames))
+	values := make([]interface{}, 0, len(s.DBNames))
+	for _, name := range s.DBNames {
+		field := s.FieldsByDBName[name]
+		if !field.Creatable {
+			continue
+		}
+		v, zero := field.ValueOf(ctx, row)
+		if zero && (field.AutoCreateTime > 0 || field.AutoUpdateTime > 0) {
+			if err := field.Set(ctx, row, autoTime(field, now)); err != nil {
+				return nil, err
+			}
+			v, zero = field.ValueOf(ctx, row)
+		}
+		if zero && field.HasDefaultValue {
+			if field.DefaultValueInterface == nil {
+				continue
+			}
+			v = field.DefaultValueInterface
+		}
+		value, err := mutationValue(checker, v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", name, err)
+		}
+		columns = append(columns, name)
+		values = append(values, value)
+	}
+	return spanner.Insert(s.Table, columns, values), nil
+}
+
+// autoTime returns the value for an auto create or update time field.
+func autoTime(field *schema.Field, now time.Time) interface{} {
+	switch field.AutoCreateTime | field.AutoUpdateTime {
+	case schema.UnixNanosecond:
+		return now.UnixNano()
+	case schema.UnixMillisecond:
+		return now.UnixMilli()
+	case schema.UnixSecond:
+		return now.Unix()
+	}
+	return now
+}
+
+// mutationValue converts a field value to a value that can be used in a
+// mutation. The value is converted in the same way as the Spanner database
+// driver converts query parameters.
+func mutationValue(checker driver.NamedValueChecker, v interface{}) (interface{}, error) {
+	switch v.(type) {
+	case spannergorm.CommitTimestamp:
+		return spanner.CommitTimestamp, nil
+	case gorm.Valuer:
+		return nil, errors.New("values that implement gorm.Valuer are not supported")
+	}
+	value := &driver.NamedValue{Value: v}
+	if err := checker.CheckNamedValue(value); err != nil && !errors.Is(err, driver.ErrSkip) {
+		return nil, err
+	}
+	switch v := value.Value.(type) {
+	case uint:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case sql.NullString:
+		return nullable(v.String, v.Valid), nil
+	case sql.NullInt64:
+		return nullable(v.Int64, v.Valid), nil
+	case sql.NullInt32:
+		return nullable(int64(v.Int32), v.Valid), nil
+	case sql.NullFloat64:
+		return nullable(v.Float64, v.Valid), nil
+	case sql.NullBool:
+		return nullable(v.Bool, v.Valid), nil
+	case sql.NullTime:
+		return nullable(v.Time, v.Valid), nil
+	}
+	return value.Value, nil
+}
+
+func nullable(v interface{}, valid bool) interface{} {
+	if !valid {
+		return nil
+	}
+	return v
+}
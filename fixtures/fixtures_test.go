@@ -0,0 +1,216 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fixtures_test
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/googleapis/go-gorm-spanner/fixtures"
+	"github.com/googleapis/go-gorm-spanner/spannergormtest"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type Singer struct {
+	ID        int64 `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	Nickname  sql.NullString
+	CreatedAt time.Time
+}
+
+type Album struct {
+	ID          int64 `gorm:"primaryKey;autoIncrement:false"`
+	SingerID    int64
+	Singer      Singer
+	Title       string
+	ReleaseDate *time.Time
+	Tracks      []Track
+}
+
+// Track is interleaved in Album.
+type Track struct {
+	AlbumID     int64 `gorm:"primaryKey;autoIncrement:false"`
+	TrackNumber int64 `gorm:"primaryKey;autoIncrement:false"`
+	Title       string
+}
+
+func TestTables(t *testing.T) {
+	t.Parallel()
+
+	db, _ := spannergormtest.Open(t, spannergormtest.Config{})
+	f, err := fixtures.New(db, &Track{}, &Album{}, &Singer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := f.Tables(), []string{"singers", "albums", "tracks"}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("tables mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestLoadFiles(t *testing.T) {
+	t.Parallel()
+
+	for _, dialect := range []spannergormtest.Dialect{spannergormtest.GoogleSQL, spannergormtest.PostgreSQL} {
+		db, server := spannergormtest.Open(t, spannergormtest.Config{Dialect: dialect})
+		f, err := fixtures.New(db, &Singer{}, &Album{}, &Track{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := f.LoadFiles(context.Background(), "testdata/singers.yaml"); err != nil {
+			t.Fatal(err)
+		}
+		if g, w := len(server.CommitRequests()), 1; g != w {
+			t.Fatalf("%v: commit count mismatch\n Got: %v\nWant: %v", dialect, g, w)
+		}
+		mutations := server.Mutations()
+		var tables []string
+		for _, m := range mutations {
+			tables = append(tables, m.GetInsert().GetTable())
+		}
+		if g, w := tables, []string{"singers", "singers", "albums", "tracks"}; !reflect.DeepEqual(g, w) {
+			t.Fatalf("%v: tables mismatch\n Got: %v\nWant: %v", dialect, g, w)
+		}
+
+		alice := mutations[0].GetInsert()
+		if g, w := alice.GetColumns(), []string{"id", "name", "nickname", "created_at"}; !reflect.DeepEqual(g, w) {
+			t.Fatalf("%v: columns mismatch\n Got: %v\nWant: %v", dialect, g, w)
+		}
+		values := alice.GetValues()[0].GetValues()
+		if g, w := values[0].GetStringValue(), "1"; g != w {
+			t.Fatalf("%v: id mismatch\n Got: %v\nWant: %v", dialect, g, w)
+		}
+		if g, w := values[2].GetStringValue(), "Al"; g != w {
+			t.Fatalf("%v: nickname mismatch\n Got: %v\nWant: %v", dialect, g, w)
+		}
+		if values[3].GetStringValue() == "" {
+			t.Fatalf("%v: missing created_at value", dialect)
+		}
+		bob := mutations[1].GetInsert().GetValues()[0].GetValues()
+		if _, ok := bob[2].GetKind().(*structpb.Value_NullValue); !ok {
+			t.Fatalf("%v: nickname should be null, got %v", dialect, bob[2])
+		}
+		album := mutations[2].GetInsert().GetValues()[0].GetValues()
+		if g, w := album[3].GetStringValue(), "2020-01-02T00:00:00Z"; g != w {
+			t.Fatalf("%v: release date mismatch\n Got: %v\nWant: %v", dialect, g, w)
+		}
+	}
+}
+
+func TestLoadJSON(t *testing.T) {
+	t.Parallel()
+
+	db, server := spannergormtest.Open(t, spannergormtest.Config{})
+	f, err := fixtures.New(db, &Singer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Load(context.Background(), []byte(`{"singers": [{"id": 9007199254740993, "Name": "Alice"}]}`)); err != nil {
+		t.Fatal(err)
+	}
+	values := server.Mutations()[0].GetInsert().GetValues()[0].GetValues()
+	if g, w := values[0].GetStringValue(), "9007199254740993"; g != w {
+		t.Fatalf("id mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := values[1].GetStringValue(), "Alice"; g != w {
+		t.Fatalf("name mismatch\n Got: %v\nWant: %v", g, w)
+	}
+
+	if err := f.Load(context.Background(), []byte(`{"albums": [{"id": 1}]}`)); err == nil {
+		t.Fatal("missing error for unregistered table")
+	}
+	if err := f.Load(context.Background(), []byte(`{"singers": [{"unknown": 1}]}`)); err == nil {
+		t.Fatal("missing error for unknown column")
+	}
+}
+
+func TestInsert(t *testing.T) {
+	t.Parallel()
+
+	db, server := spannergormtest.Open(t, spannergormtest.Config{})
+	f, err := fixtures.New(db, &Singer{}, &Album{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Insert(context.Background(), []Album{{ID: 1, SingerID: 1, Title: "Blue"}}, Singer{ID: 1, Name: "Alice"}); err != nil {
+		t.Fatal(err)
+	}
+	mutations := server.Mutations()
+	if g, w := len(mutations), 2; g != w {
+		t.Fatalf("mutation count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := mutations[0].GetInsert().GetTable(), "singers"; g != w {
+		t.Fatalf("table mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestReset(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		dialect     spannergormtest.Dialect
+		mode        fixtures.ResetMode
+		statements  []string
+		partitioned bool
+	}{
+		{
+			dialect:    spannergormtest.GoogleSQL,
+			statements: []string{"DELETE FROM `tracks` WHERE TRUE", "DELETE FROM `albums` WHERE TRUE", "DELETE FROM `singers` WHERE TRUE"},
+		},
+		{
+			dialect:     spannergormtest.GoogleSQL,
+			mode:        fixtures.ResetWithPartitionedDML,
+			statements:  []string{"DELETE FROM `tracks` WHERE TRUE", "DELETE FROM `albums` WHERE TRUE", "DELETE FROM `singers` WHERE TRUE"},
+			partitioned: true,
+		},
+		{
+			dialect:    spannergormtest.PostgreSQL,
+			statements: []string{`DELETE FROM "tracks" WHERE TRUE`, `DELETE FROM "albums" WHERE TRUE`, `DELETE FROM "singers" WHERE TRUE`},
+		},
+	} {
+		db, server := spannergormtest.Open(t, spannergormtest.Config{Dialect: test.dialect})
+		for _, statement := range test.statements {
+			server.PutUpdateCount(statement, 1)
+		}
+		f, err := fixtures.New(db, &Singer{}, &Track{}, &Album{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.ResetMode = test.mode
+		server.ClearRequests()
+		if err := f.Reset(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		var statements []string
+		for _, req := range server.ExecuteSqlRequests() {
+			statements = append(statements, req.Sql)
+		}
+		if g, w := statements, test.statements; !reflect.DeepEqual(g, w) {
+			t.Fatalf("%v: statements mismatch\n Got: %v\nWant: %v", test.dialect, g, w)
+		}
+		partitioned := 0
+		for _, req := range server.Requests() {
+			if begin, ok := req.(*spannerpb.BeginTransactionRequest); ok && begin.GetOptions().GetPartitionedDml() != nil {
+				partitioned++
+			}
+		}
+		if g, w := partitioned > 0, test.partitioned; g != w {
+			t.Fatalf("%v: partitioned mismatch\n Got: %v\nWant: %v", test.dialect, g, w)
+		}
+	}
+}
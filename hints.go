@@ -49,6 +49,14 @@ func (indexHint IndexHint) ModifyStatement(stmt *gorm.Statement) {
 
 func (indexHint IndexHint) Build(builder clause.Builder) {
 	if indexHint.Key != "" {
+		// PostgreSQL-dialect databases use a comment for statement and table hints.
+		if stmt, ok := builder.(*gorm.Statement); ok && isPostgreSQL(stmt.DB) {
+			builder.WriteString("/*@ ")
+			builder.WriteString(indexHint.Type)
+			builder.WriteString(indexHint.Key)
+			builder.WriteString(" */")
+			return
+		}
 		builder.WriteString("@{")
 		builder.WriteString(indexHint.Type)
 		builder.WriteQuoted(indexHint.Key)
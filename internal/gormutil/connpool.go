@@ -0,0 +1,33 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gormutil contains helpers that are shared by the packages of the
+// Spanner gorm dialects, but that are not part of their public API.
+package gormutil
+
+import "gorm.io/gorm"
+
+// UnpreparedConnPool returns the given connection pool without prepared
+// statement caching. The Spanner driver keeps the ExecOptions that are passed
+// to a prepared statement for all later executions of the statement, which
+// means that statements with ExecOptions must not be cached.
+func UnpreparedConnPool(pool gorm.ConnPool) gorm.ConnPool {
+	switch p := pool.(type) {
+	case *gorm.PreparedStmtDB:
+		return p.ConnPool
+	case *gorm.PreparedStmtTX:
+		return p.Tx
+	}
+	return pool
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gormutil
+
+import (
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// SchemaNamer adds the default schema of the dialector to all table names
+// that do not already include a schema.
+type SchemaNamer struct {
+	schema.Namer
+	DefaultSchema string
+}
+
+func (n SchemaNamer) TableName(table string) string {
+	return n.qualify(n.Namer.TableName(table))
+}
+
+func (n SchemaNamer) JoinTableName(joinTable string) string {
+	return n.qualify(n.Namer.JoinTableName(joinTable))
+}
+
+func (n SchemaNamer) qualify(table string) string {
+	if strings.Contains(table, ".") {
+		return table
+	}
+	return n.DefaultSchema + "." + table
+}
+
+// TableSchema returns the schema and the unqualified name of the given table.
+// gorm strips the schema from stmt.Table, so the schema of stmt.Table is taken
+// from the table name of the model of the statement. The schema is empty for
+// tables in the default schema.
+func TableSchema(stmt *gorm.Statement, table string) (string, string) {
+	if idx := strings.LastIndex(table, "."); idx > -1 {
+		return table[:idx], table[idx+1:]
+	}
+	if stmt != nil && stmt.Schema != nil && table == stmt.Table {
+		if idx := strings.LastIndex(stmt.Schema.Table, "."); idx > -1 && stmt.Schema.Table[idx+1:] == table {
+			return stmt.Schema.Table[:idx], table
+		}
+	}
+	return "", table
+}
+
+const createdSchemasKey = "gorm:spanner:created_schemas"
+
+// WithCreatedSchemas returns a session of db that keeps track of the schemas
+// that are created by a migration. HasSchema does not return true for a
+// schema that has been created in a DDL batch or a dry-run until the batch
+// has been executed.
+func WithCreatedSchemas(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{}).Set(createdSchemasKey, &sync.Map{}).Session(&gorm.Session{})
+}
+
+// SchemaCreated marks the given schema as created in the migration of db, and
+// returns true if it had already been created in the migration before.
+func SchemaCreated(db *gorm.DB, name string) bool {
+	created, ok := db.Get(createdSchemasKey)
+	if !ok {
+		return false
+	}
+	_, loaded := created.(*sync.Map).LoadOrStore(name, true)
+	return loaded
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gormutil
+
+import (
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+func TestTableSchema(t *testing.T) {
+	t.Parallel()
+
+	stmt := &gorm.Statement{Table: "singers", Schema: &schema.Schema{Table: "sales.singers"}}
+	for i, test := range []struct {
+		stmt              *gorm.Statement
+		table             string
+		schema, wantTable string
+	}{
+		{nil, "singers", "", "singers"},
+		{nil, "sales.singers", "sales", "singers"},
+		{stmt, "singers", "sales", "singers"},
+		{stmt, "albums", "", "albums"},
+		{stmt, "marketing.albums", "marketing", "albums"},
+		{&gorm.Statement{Table: "singers", Schema: &schema.Schema{Table: "singers"}}, "singers", "", "singers"},
+	} {
+		s, table := TableSchema(test.stmt, test.table)
+		if g, w := s, test.schema; g != w {
+			t.Errorf("%d: schema mismatch\n Got: %v\nWant: %v", i, g, w)
+		}
+		if g, w := table, test.wantTable; g != w {
+			t.Errorf("%d: table mismatch\n Got: %v\nWant: %v", i, g, w)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Interval is a Spanner INTERVAL value. An interval consists of a number of
+// months, a number of days and a number of nanoseconds. The components are
+// independent of each other, as the length of a month and a day depends on
+// the date that the interval is added to.
+//
+// Interval values are sent to Spanner as typed INTERVAL parameters. The
+// Spanner database/sql driver does not decode INTERVAL values in query
+// results. Queries that are built by gorm therefore select the INTERVAL
+// columns of a model as strings, which Interval scans as ISO 8601 durations.
+// Raw queries must cast INTERVAL columns to strings themselves, for example
+// with CAST(duration AS STRING).
+//
+// NULL values are scanned as the zero Interval. Use *Interval for values that
+// can be NULL.
+type Interval struct {
+	Months int32
+	Days   int32
+	Nanos  int64
+}
+
+// NewInterval returns an interval with the given number of months and days,
+// and the given duration as the nanoseconds component.
+func NewInterval(months, days int32, d time.Duration) Interval {
+	return Interval{Months: months, Days: days, Nanos: int64(d)}
+}
+
+// IntervalFromDuration returns an interval that only consists of the given
+// duration.
+func IntervalFromDuration(d time.Duration) Interval {
+	return Interval{Nanos: int64(d)}
+}
+
+// ParseInterval parses an interval in the ISO 8601 duration format, for
+// example "P1Y2M3DT4H5M6.5S".
+func ParseInterval(s string) (Interval, error) {
+	interval, err := spanner.ParseInterval(s)
+	if err != nil {
+		return Interval{}, err
+	}
+	return intervalFromSpanner(interval)
+}
+
+// Duration returns the interval as a time.Duration. The conversion is only
+// lossless if the interval has no months and no days. The second return
+// value is false if that is not the case.
+func (i Interval) Duration() (time.Duration, bool) {
+	return time.Duration(i.Nanos), i.Months == 0 && i.Days == 0
+}
+
+// String returns the interval in the ISO 8601 duration format.
+func (i Interval) String() string {
+	return spanner.Interval{Months: i.Months, Days: i.Days, Nanos: big.NewInt(i.Nanos)}.String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (i Interval) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (i *Interval) UnmarshalText(b []byte) error {
+	interval, err := ParseInterval(string(b))
+	if err != nil {
+		return err
+	}
+	*i = interval
+	return nil
+}
+
+// Value implements driver.Valuer. The interval is sent to Spanner as an
+// INTERVAL value.
+func (i Interval) Value() (driver.Value, error) {
+	return spanner.GenericColumnValue{
+		Type:  &spannerpb.Type{Code: spannerpb.TypeCode_INTERVAL},
+		Value: structpb.NewStringValue(i.String()),
+	}, nil
+}
+
+// Scan implements sql.Scanner. NULL values are scanned as the zero Interval.
+func (i *Interval) Scan(v any) error {
+	switch val := v.(type) {
+	case nil:
+		*i = Interval{}
+	case string:
+		interval, err := ParseInterval(val)
+		if err != nil {
+			return err
+		}
+		*i = interval
+	case []byte:
+		return i.Scan(string(val))
+	case spanner.NullString:
+		if !val.Valid {
+			*i = Interval{}
+			return nil
+		}
+		return i.Scan(val.StringVal)
+	case spanner.Interval:
+		interval, err := intervalFromSpanner(val)
+		if err != nil {
+			return err
+		}
+		*i = interval
+	case spanner.NullInterval:
+		if !val.Valid {
+			*i = Interval{}
+			return nil
+		}
+		return i.Scan(val.Interval)
+	case spanner.GenericColumnValue:
+		if _, ok := val.Value.GetKind().(*structpb.Value_StringValue); !ok {
+			*i = Interval{}
+			return nil
+		}
+		return i.Scan(val.Value.GetStringValue())
+	default:
+		return fmt.Errorf("cannot scan %T into %T", v, i)
+	}
+	return nil
+}
+
+func (i Interval) GormDataType() string {
+	return "INTERVAL"
+}
+
+func (i Interval) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	if isPostgreSQL(db) {
+		return "interval"
+	}
+	return "INTERVAL"
+}
+
+// IntervalArray is an Array of intervals. This type cannot contain any NULL
+// elements.
+type IntervalArray = Array[Interval]
+
+// NullIntervalArray is an Array of intervals that can contain NULL elements.
+type NullIntervalArray = NullArray[Interval]
+
+// intervalFromSpanner converts a spanner.Interval to an Interval. The
+// nanoseconds component of a Spanner interval can exceed the range of an
+// int64.
+func intervalFromSpanner(interval spanner.Interval) (Interval, error) {
+	var nanos int64
+	if interval.Nanos != nil {
+		if !interval.Nanos.IsInt64() {
+			return Interval{}, fmt.Errorf("nanoseconds of interval %v overflow int64", interval)
+		}
+		nanos = interval.Nanos.Int64()
+	}
+	return Interval{Months: interval.Months, Days: interval.Days, Nanos: nanos}, nil
+}
+
+// isIntervalField returns true if the given field is an INTERVAL column,
+// either because the type of the field is Interval, or because the field has
+// a `gorm:"type:interval"` tag.
+func isIntervalField(field *schema.Field) bool {
+	return strings.EqualFold(string(field.DataType), "interval")
+}
+
+// isIntervalArrayField returns true if the given field is an ARRAY<INTERVAL>
+// column.
+func isIntervalArrayField(field *schema.Field) bool {
+	dataType := strings.ToLower(string(field.DataType))
+	return dataType == "array<interval>" || dataType == "interval[]"
+}
+
+// RegisterIntervalReads registers a SELECT clause builder that selects the
+// INTERVAL and ARRAY<INTERVAL> columns of the model of a query as strings.
+// The Spanner database/sql driver does not decode INTERVAL values in query
+// results, but Interval, IntervalArray and NullIntervalArray can scan the
+// ISO 8601 strings that are returned for them. This builder is registered
+// by the GoogleSQL and PostgreSQL dialectors.
+func RegisterIntervalReads(db *gorm.DB) {
+	db.ClauseBuilders[clause.Select{}.Name()] = buildIntervalSelect
+}
+
+func buildIntervalSelect(c clause.Clause, builder clause.Builder) {
+	selectClause, ok := c.Expression.(clause.Select)
+	if !ok {
+		c.Build(builder)
+		return
+	}
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok || stmt.Schema == nil {
+		c.Build(builder)
+		return
+	}
+	stringType, arrayType := "STRING", "ARRAY<STRING>"
+	if isPostgreSQL(stmt.DB) {
+		stringType, arrayType = "varchar", "varchar[]"
+	}
+	casts := make(map[string]string)
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		if isIntervalField(field) {
+			casts[field.DBName] = stringType
+		} else if isIntervalArrayField(field) {
+			casts[field.DBName] = arrayType
+		}
+	}
+	if len(casts) == 0 {
+		c.Build(builder)
+		return
+	}
+	// SELECT * cannot cast individual columns, so list all columns of the
+	// model instead.
+	if len(selectClause.Columns) == 0 {
+		selectClause.Columns = make([]clause.Column, len(stmt.Schema.DBNames))
+		for i, name := range stmt.Schema.DBNames {
+			selectClause.Columns[i] = clause.Column{Name: name}
+		}
+	}
+	c.Expression = intervalSelect{Select: selectClause, table: stmt.Table, casts: casts}
+	c.Build(builder)
+}
+
+// intervalSelect is a SELECT clause that casts the given columns of the
+// table of the statement to strings.
+type intervalSelect struct {
+	clause.Select
+	table string
+	casts map[string]string
+}
+
+func (s intervalSelect) Build(builder clause.Builder) {
+	if s.Distinct {
+		builder.WriteString("DISTINCT ")
+	}
+	for idx, column := range s.Columns {
+		if idx > 0 {
+			builder.WriteByte(',')
+		}
+		castType, ok := s.casts[column.Name]
+		if !ok || column.Raw || (column.Table != "" && column.Table != clause.CurrentTable && column.Table != s.table) {
+			builder.WriteQuoted(column)
+			continue
+		}
+		alias := column.Alias
+		if alias == "" {
+			alias = column.Name
+		}
+		builder.WriteString("CAST(")
+		builder.WriteQuoted(clause.Column{Table: column.Table, Name: column.Name})
+		builder.WriteString(" AS " + castType + ") AS ")
+		builder.WriteQuoted(alias)
+	}
+}
+
+// TimestampAdd returns the timestamp in the given column plus the given
+// interval. The expression can be used for both GoogleSQL and PostgreSQL
+// databases.
+//
+// Example:
+//
+//	db.Where(spannergorm.TimestampAdd("created_at", spannergorm.NewInterval(0, 30, 0)).Lt(time.Now())).Find(&orders)
+func TimestampAdd(column string, interval Interval) ComparableExpr {
+	return ComparableExpr{Expr: clause.Expr{SQL: "(? + ?)", Vars: []interface{}{clause.Column{Name: column}, interval}}}
+}
+
+// TimestampSub returns the timestamp in the given column minus the given
+// interval. The expression can be used for both GoogleSQL and PostgreSQL
+// databases.
+func TimestampSub(column string, interval Interval) ComparableExpr {
+	return ComparableExpr{Expr: clause.Expr{SQL: "(? - ?)", Vars: []interface{}{clause.Column{Name: column}, interval}}}
+}
+
+// ErrIntervalWithNanos is returned by statements that use DateAdd or
+// DateSub with an interval that has a nanoseconds component.
+var ErrIntervalWithNanos = errors.New("DATE arithmetic only supports intervals of months and days")
+
+// DateAdd returns the date in the given column plus the months and days of
+// the given interval. The expression uses the GoogleSQL DATE_ADD function.
+// Statements that use the expression fail with ErrIntervalWithNanos if the
+// interval has a nanoseconds component.
+//
+// Example:
+//
+//	db.Where(spannergorm.DateAdd("release_date", spannergorm.NewInterval(1, 0, 0)).Gt(civil.DateOf(time.Now()))).Find(&albums)
+func DateAdd(column string, interval Interval) ComparableExpr {
+	return dateArithmetic("DATE_ADD", column, interval)
+}
+
+// DateSub returns the date in the given column minus the months and days of
+// the given interval. The expression uses the GoogleSQL DATE_SUB function.
+// Statements that use the expression fail with ErrIntervalWithNanos if the
+// interval has a nanoseconds component.
+func DateSub(column string, interval Interval) ComparableExpr {
+	return dateArithmetic("DATE_SUB", column, interval)
+}
+
+func dateArithmetic(function, column string, interval Interval) ComparableExpr {
+	if interval.Nanos != 0 {
+		return ComparableExpr{Expr: clause.Expr{SQL: "?", Vars: []interface{}{errorExpr{
+			err: fmt.Errorf("%w: %s has an interval with nanoseconds: %v", ErrIntervalWithNanos, function, interval),
+		}}}}
+	}
+	return ComparableExpr{Expr: clause.Expr{
+		SQL:  function + "(" + function + "(?, INTERVAL ? MONTH), INTERVAL ? DAY)",
+		Vars: []interface{}{clause.Column{Name: column}, int64(interval.Months), int64(interval.Days)},
+	}}
+}
+
+// errorExpr is an expression that adds an error to the statement that it is
+// used in.
+type errorExpr struct {
+	err error
+}
+
+func (e errorExpr) Build(builder clause.Builder) {
+	_ = builder.AddError(e.err)
+}
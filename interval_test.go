@@ -0,0 +1,288 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/googleapis/go-sql-spanner/testutil"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+type intervalSubscription struct {
+	ID        int64 `gorm:"primaryKey;autoIncrement:false"`
+	Period    Interval
+	Grace     *Interval
+	Reminders NullIntervalArray
+	CreatedAt time.Time
+}
+
+// selectIntervalSubscriptionsSql selects the INTERVAL columns as strings, as
+// the Spanner database/sql driver does not decode INTERVAL values.
+const selectIntervalSubscriptionsSql = "SELECT `id`,CAST(`period` AS STRING) AS `period`,CAST(`grace` AS STRING) AS `grace`," +
+	"CAST(`reminders` AS ARRAY<STRING>) AS `reminders`,`created_at` FROM `interval_subscriptions`"
+
+func TestIntervalDataType(t *testing.T) {
+	field := &schema.Field{}
+	googleSQL := &gorm.DB{Config: &gorm.Config{Dialector: New(Config{})}}
+	postgreSQL := &gorm.DB{Config: &gorm.Config{Dialector: postgreSQLDialector{}}}
+	for _, test := range []struct {
+		db   *gorm.DB
+		got  string
+		want string
+	}{
+		{db: googleSQL, got: (Interval{}).GormDBDataType(googleSQL, field), want: "INTERVAL"},
+		{db: googleSQL, got: (IntervalArray{}).GormDBDataType(googleSQL, field), want: "ARRAY<INTERVAL>"},
+		{db: googleSQL, got: (NullIntervalArray{}).GormDBDataType(googleSQL, field), want: "ARRAY<INTERVAL>"},
+		{db: postgreSQL, got: (Interval{}).GormDBDataType(postgreSQL, field), want: "interval"},
+		{db: postgreSQL, got: (IntervalArray{}).GormDBDataType(postgreSQL, field), want: "interval[]"},
+	} {
+		if test.got != test.want {
+			t.Errorf("%s: data type mismatch\n Got: %v\nWant: %v", test.db.Dialector.Name(), test.got, test.want)
+		}
+	}
+	if g, w := New(Config{}).DataTypeOf(&schema.Field{DataType: "interval"}), "INTERVAL"; g != w {
+		t.Errorf("tagged data type mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestIntervalDryRun(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	statements, err := db.Migrator().(SpannerMigrator).AutoMigrateDryRun(&intervalSubscription{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := statements[0].SQL,
+		"CREATE TABLE `interval_subscriptions` (`id` INT64,`period` INTERVAL,`grace` INTERVAL,`reminders` ARRAY<INTERVAL>,`created_at` TIMESTAMP) PRIMARY KEY (`id`)"; g != w {
+		t.Fatalf("create table statement mismatch\n Got: %s\nWant: %s", g, w)
+	}
+}
+
+func TestIntervalConversion(t *testing.T) {
+	for _, test := range []struct {
+		interval Interval
+		want     string
+	}{
+		{interval: Interval{}, want: "P0Y"},
+		{interval: IntervalFromDuration(90 * time.Minute), want: "PT1H30M"},
+		{interval: NewInterval(14, 3, 4*time.Hour+500*time.Millisecond), want: "P1Y2M3DT4H0.500S"},
+		{interval: NewInterval(0, -1, 0), want: "P-1D"},
+	} {
+		if g, w := test.interval.String(), test.want; g != w {
+			t.Errorf("string mismatch\n Got: %v\nWant: %v", g, w)
+		}
+		var scanned Interval
+		if err := scanned.Scan(test.want); err != nil {
+			t.Fatalf("failed to scan %q: %v", test.want, err)
+		}
+		if g, w := scanned, test.interval; g != w {
+			t.Errorf("scanned interval mismatch\n Got: %v\nWant: %v", g, w)
+		}
+	}
+
+	if d, ok := IntervalFromDuration(time.Hour).Duration(); !ok || d != time.Hour {
+		t.Errorf("duration mismatch\n Got: %v, %v\nWant: %v, true", d, ok, time.Hour)
+	}
+	if _, ok := NewInterval(0, 1, 0).Duration(); ok {
+		t.Error("interval with days should not be convertible to a duration")
+	}
+
+	var interval Interval
+	if err := interval.Scan(spanner.Interval{Nanos: new(big.Int).Lsh(big.NewInt(1), 64)}); err == nil {
+		t.Error("missing overflow error")
+	}
+	if err := interval.Scan(spanner.NullInterval{Interval: spanner.Interval{Days: 2, Nanos: big.NewInt(0)}, Valid: true}); err != nil {
+		t.Fatal(err)
+	}
+	if g, w := interval, NewInterval(0, 2, 0); g != w {
+		t.Errorf("scanned interval mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if err := interval.Scan(nil); err != nil {
+		t.Fatal(err)
+	}
+	if g, w := interval, (Interval{}); g != w {
+		t.Errorf("scanned interval mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestIntervalRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	const insertSql = "INSERT INTO `interval_subscriptions` (`id`,`period`,`grace`,`reminders`,`created_at`) VALUES (@p1,@p2,@p3,@p4,@p5)"
+	_ = server.TestSpanner.PutStatementResult(insertSql, &testutil.StatementResult{
+		Type:        testutil.StatementResultUpdateCount,
+		UpdateCount: 1,
+	})
+	week := NewInterval(0, 7, 0)
+	subscription := intervalSubscription{
+		ID:        1,
+		Period:    NewInterval(1, 0, 0),
+		Reminders: NullIntervalArray{&week, nil},
+	}
+	if err := db.Create(&subscription).Error; err != nil {
+		t.Fatal(err)
+	}
+	req := getLastSqlRequest(server)
+	if g, w := req.Sql, insertSql; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.ParamTypes["p2"].GetCode(), spannerpb.TypeCode_INTERVAL; g != w {
+		t.Fatalf("param type mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.Params.Fields["p2"].GetStringValue(), "P1M"; g != w {
+		t.Fatalf("param value mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if _, ok := req.Params.Fields["p3"].GetKind().(*structpb.Value_NullValue); !ok {
+		t.Fatalf("param value mismatch\n Got: %v\nWant: NULL", req.Params.Fields["p3"])
+	}
+	if g, w := req.ParamTypes["p4"].GetArrayElementType().GetCode(), spannerpb.TypeCode_INTERVAL; g != w {
+		t.Fatalf("array param type mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	reminders := req.Params.Fields["p4"].GetListValue().GetValues()
+	if g, w := len(reminders), 2; g != w {
+		t.Fatalf("array length mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := reminders[0].GetStringValue(), "P7D"; g != w {
+		t.Fatalf("array element mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if _, ok := reminders[1].GetKind().(*structpb.Value_NullValue); !ok {
+		t.Fatalf("array element mismatch\n Got: %v\nWant: NULL", reminders[1])
+	}
+
+	const selectSql = selectIntervalSubscriptionsSql + " WHERE `interval_subscriptions`.`id` = @p1 ORDER BY `interval_subscriptions`.`id` LIMIT @p2"
+	_ = server.TestSpanner.PutStatementResult(selectSql, &testutil.StatementResult{
+		Type: testutil.StatementResultResultSet,
+		ResultSet: &spannerpb.ResultSet{
+			Metadata: &spannerpb.ResultSetMetadata{
+				RowType: &spannerpb.StructType{
+					Fields: []*spannerpb.StructType_Field{
+						{Name: "id", Type: &spannerpb.Type{Code: spannerpb.TypeCode_INT64}},
+						{Name: "period", Type: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}},
+						{Name: "grace", Type: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}},
+						{Name: "reminders", Type: &spannerpb.Type{Code: spannerpb.TypeCode_ARRAY, ArrayElementType: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}}},
+						{Name: "created_at", Type: &spannerpb.Type{Code: spannerpb.TypeCode_TIMESTAMP}},
+					},
+				},
+			},
+			Rows: []*structpb.ListValue{
+				{Values: []*structpb.Value{
+					structpb.NewStringValue("1"),
+					structpb.NewStringValue("P1M"),
+					structpb.NewNullValue(),
+					structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{structpb.NewStringValue("P7D"), structpb.NewNullValue()}}),
+					structpb.NewNullValue(),
+				}},
+			},
+		},
+	})
+	var found intervalSubscription
+	if err := db.First(&found, 1).Error; err != nil {
+		t.Fatalf("failed to read subscription: %v", err)
+	}
+	if g, w := getLastSqlRequest(server).Sql, selectSql; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := found.Period, subscription.Period; g != w {
+		t.Fatalf("period mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if found.Grace != nil {
+		t.Fatalf("grace mismatch\n Got: %v\nWant: nil", found.Grace)
+	}
+	if g, w := len(found.Reminders), 2; g != w {
+		t.Fatalf("reminders length mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if found.Reminders[0] == nil || *found.Reminders[0] != week || found.Reminders[1] != nil {
+		t.Fatalf("reminders mismatch\n Got: %v\nWant: [%v <nil>]", found.Reminders, week)
+	}
+}
+
+func TestIntervalExpressions(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	now := time.Now()
+	for _, test := range []struct {
+		expr     interface{}
+		wantSql  string
+		wantVars []interface{}
+	}{
+		{
+			expr:     TimestampAdd("created_at", NewInterval(1, 0, 0)).Lt(now),
+			wantSql:  selectIntervalSubscriptionsSql + " WHERE (`created_at` + ?) < ?",
+			wantVars: []interface{}{NewInterval(1, 0, 0), now},
+		},
+		{
+			expr:     TimestampSub("created_at", IntervalFromDuration(time.Hour)).Gte(now),
+			wantSql:  selectIntervalSubscriptionsSql + " WHERE (`created_at` - ?) >= ?",
+			wantVars: []interface{}{IntervalFromDuration(time.Hour), now},
+		},
+		{
+			expr:     DateAdd("created_at", NewInterval(2, 3, 0)).Gt(now),
+			wantSql:  selectIntervalSubscriptionsSql + " WHERE DATE_ADD(DATE_ADD(`created_at`, INTERVAL ? MONTH), INTERVAL ? DAY) > ?",
+			wantVars: []interface{}{int64(2), int64(3), now},
+		},
+		{
+			expr:     DateSub("created_at", NewInterval(0, 1, 0)).Eq(now),
+			wantSql:  selectIntervalSubscriptionsSql + " WHERE DATE_SUB(DATE_SUB(`created_at`, INTERVAL ? MONTH), INTERVAL ? DAY) = ?",
+			wantVars: []interface{}{int64(0), int64(1), now},
+		},
+	} {
+		stmt := db.Session(&gorm.Session{DryRun: true}).Where(test.expr).Find(&[]intervalSubscription{}).Statement
+		if g, w := stmt.SQL.String(), test.wantSql; g != w {
+			t.Errorf("sql mismatch\n Got: %v\nWant: %v", g, w)
+		}
+		if g, w := len(stmt.Vars), len(test.wantVars); g != w {
+			t.Fatalf("vars length mismatch\n Got: %v\nWant: %v", g, w)
+		}
+		for i := range stmt.Vars {
+			if g, w := stmt.Vars[i], test.wantVars[i]; g != w {
+				t.Errorf("var %d mismatch\n Got: %v\nWant: %v", i, g, w)
+			}
+		}
+	}
+}
+
+func TestDateArithmeticWithNanos(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	for _, expr := range []ComparableExpr{
+		DateAdd("created_at", NewInterval(1, 0, time.Hour)),
+		DateSub("created_at", IntervalFromDuration(time.Second)),
+	} {
+		err := db.Session(&gorm.Session{DryRun: true}).Where(expr.Gt(time.Now())).Find(&[]intervalSubscription{}).Error
+		if !errors.Is(err, ErrIntervalWithNanos) {
+			t.Errorf("error mismatch\n Got: %v\nWant: %v", err, ErrIntervalWithNanos)
+		}
+	}
+}
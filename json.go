@@ -0,0 +1,201 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"cloud.google.com/go/spanner"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// JSON is a JSON column that is marshalled from and unmarshalled to a value
+// of type T. The column type is JSON for GoogleSQL and jsonb for PostgreSQL.
+//
+// The column is NULL if T is a pointer, map or slice and Data is nil. NULL
+// values are scanned as the zero value of T. Use a pointer type for T, for
+// example JSON[*VenueDetails], to distinguish NULL from an empty value.
+//
+// Example:
+//
+//	type Venue struct {
+//	  ID      int64
+//	  Details spannergorm.JSON[VenueDetails]
+//	}
+type JSON[T any] struct {
+	Data T
+}
+
+// NewJSON returns a JSON value containing the given data.
+func NewJSON[T any](data T) JSON[T] {
+	return JSON[T]{Data: data}
+}
+
+func (j JSON[T]) isNull() bool {
+	v := reflect.ValueOf(&j.Data).Elem()
+	return canBeNil(v.Kind()) && v.IsNil()
+}
+
+// Value implements driver.Valuer. The value is sent to Spanner as a GoogleSQL
+// JSON value. gorm uses GormValue, which also supports PostgreSQL.
+func (j JSON[T]) Value() (driver.Value, error) {
+	return spanner.NullJSON{Value: j.Data, Valid: !j.isNull()}, nil
+}
+
+// GormValue returns the value of the column as a JSON value for GoogleSQL
+// and as a jsonb value for PostgreSQL.
+func (j JSON[T]) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	if isPostgreSQL(db) {
+		return clause.Expr{SQL: "?", Vars: []interface{}{spanner.PGJsonB{Value: j.Data, Valid: !j.isNull()}}}
+	}
+	return clause.Expr{SQL: "?", Vars: []interface{}{spanner.NullJSON{Value: j.Data, Valid: !j.isNull()}}}
+}
+
+// Scan implements sql.Scanner. NULL values are scanned as the zero value of T.
+func (j *JSON[T]) Scan(v any) error {
+	var (
+		b   []byte
+		err error
+	)
+	switch val := v.(type) {
+	case nil:
+		j.Data = *new(T)
+		return nil
+	case spanner.NullJSON:
+		if !val.Valid {
+			j.Data = *new(T)
+			return nil
+		}
+		b, err = json.Marshal(val.Value)
+	case spanner.PGJsonB:
+		if !val.Valid {
+			j.Data = *new(T)
+			return nil
+		}
+		b, err = json.Marshal(val.Value)
+	case string:
+		b = []byte(val)
+	case []byte:
+		b = val
+	default:
+		return fmt.Errorf("cannot scan %T into %T", v, j)
+	}
+	if err != nil {
+		return err
+	}
+	var data T
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+	j.Data = data
+	return nil
+}
+
+// MarshalJSON marshals the data of the column.
+func (j JSON[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Data)
+}
+
+// UnmarshalJSON unmarshals the data of the column.
+func (j *JSON[T]) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &j.Data)
+}
+
+func (j JSON[T]) GormDataType() string {
+	return "JSON"
+}
+
+func (j JSON[T]) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	if isPostgreSQL(db) {
+		return "jsonb"
+	}
+	return "JSON"
+}
+
+// JSONValue returns the scalar value at the given JSONPath in the given JSON
+// column. The value is returned as a STRING by JSON_VALUE, unless the
+// expression is compared with a number or a bool. In that case, the value is
+// converted with LAX_FLOAT64 or LAX_BOOL, which return NULL if the value
+// cannot be converted.
+//
+// Example:
+//
+//	db.Where(spannergorm.JSONValue("venue_details", "$.rating").Gt(4)).Find(&venues)
+func JSONValue(column, path string) ComparableExpr {
+	vars := []interface{}{clause.Column{Name: column}, jsonPath(path)}
+	return ComparableExpr{
+		Expr: clause.Expr{SQL: "JSON_VALUE(?, ?)", Vars: vars},
+		TypedSQL: func(value interface{}) string {
+			switch jsonKind(value) {
+			case reflect.Float64:
+				return "LAX_FLOAT64(JSON_QUERY(?, ?))"
+			case reflect.Bool:
+				return "LAX_BOOL(JSON_QUERY(?, ?))"
+			}
+			return "JSON_VALUE(?, ?)"
+		},
+	}
+}
+
+// JSONQuery returns the JSON value at the given JSONPath in the given JSON
+// column.
+func JSONQuery(column, path string) clause.Expr {
+	return clause.Expr{SQL: "JSON_QUERY(?, ?)", Vars: []interface{}{clause.Column{Name: column}, jsonPath(path)}}
+}
+
+// JSONValueArray returns the array of scalar values at the given JSONPath in
+// the given JSON column as an ARRAY<STRING>.
+//
+// Example:
+//
+//	db.Where("? IN UNNEST(?)", "rock", spannergorm.JSONValueArray("venue_details", "$.genres")).Find(&venues)
+func JSONValueArray(column, path string) clause.Expr {
+	return clause.Expr{SQL: "JSON_VALUE_ARRAY(?, ?)", Vars: []interface{}{clause.Column{Name: column}, jsonPath(path)}}
+}
+
+// jsonPath returns the given JSONPath as a string literal.
+func jsonPath(path string) clause.Expr {
+	return clause.Expr{SQL: strconv.Quote(path)}
+}
+
+// jsonKind returns reflect.Float64 for numbers, reflect.Bool for bools and
+// reflect.String for all other values.
+func jsonKind(value interface{}) reflect.Kind {
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			return reflect.String
+		}
+		value = v
+	}
+	if value == nil {
+		return reflect.String
+	}
+	kind := reflect.TypeOf(value).Kind()
+	switch {
+	case isNumberKind(kind):
+		return reflect.Float64
+	case kind == reflect.Bool:
+		return reflect.Bool
+	}
+	return reflect.String
+}
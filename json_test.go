@@ -0,0 +1,162 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/googleapis/go-sql-spanner/testutil"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+type venueDetails struct {
+	Rating   float64  `json:"rating"`
+	Genres   []string `json:"genres"`
+	Capacity int64    `json:"capacity"`
+}
+
+type jsonVenue struct {
+	ID      int64 `gorm:"primaryKey;autoIncrement:false"`
+	Details JSON[venueDetails]
+	Extra   JSON[*venueDetails]
+}
+
+const insertJsonVenueSql = "INSERT INTO `json_venues` (`id`,`details`,`extra`) VALUES (@p1,@p2,@p3)"
+
+func TestJSONDataType(t *testing.T) {
+	field := &schema.Field{}
+	googleSQL := &gorm.DB{Config: &gorm.Config{Dialector: New(Config{})}}
+	if g, w := (JSON[venueDetails]{}).GormDBDataType(googleSQL, field), "JSON"; g != w {
+		t.Errorf("GoogleSQL data type mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	postgreSQL := &gorm.DB{Config: &gorm.Config{Dialector: postgreSQLDialector{}}}
+	if g, w := (JSON[venueDetails]{}).GormDBDataType(postgreSQL, field), "jsonb"; g != w {
+		t.Errorf("PostgreSQL data type mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	_ = server.TestSpanner.PutStatementResult(insertJsonVenueSql, &testutil.StatementResult{
+		Type:        testutil.StatementResultUpdateCount,
+		UpdateCount: 1,
+	})
+	venue := jsonVenue{
+		ID:      1,
+		Details: NewJSON(venueDetails{Rating: 4.5, Genres: []string{"rock", "jazz"}, Capacity: 2000}),
+	}
+	if err := db.Create(&venue).Error; err != nil {
+		t.Fatalf("failed to create venue: %v", err)
+	}
+	req := getLastSqlRequest(server)
+	if g, w := req.Sql, insertJsonVenueSql; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.ParamTypes["p2"].GetCode(), spannerpb.TypeCode_JSON; g != w {
+		t.Fatalf("param type mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.Params.Fields["p2"].GetStringValue(), `{"rating":4.5,"genres":["rock","jazz"],"capacity":2000}`; g != w {
+		t.Fatalf("param value mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if _, ok := req.Params.Fields["p3"].GetKind().(*structpb.Value_NullValue); !ok {
+		t.Fatalf("param value mismatch\n Got: %v\nWant: NULL", req.Params.Fields["p3"])
+	}
+
+	_ = server.TestSpanner.PutStatementResult("SELECT * FROM `json_venues` ORDER BY `json_venues`.`id` LIMIT @p1",
+		&testutil.StatementResult{
+			Type: testutil.StatementResultResultSet,
+			ResultSet: &spannerpb.ResultSet{
+				Metadata: &spannerpb.ResultSetMetadata{
+					RowType: &spannerpb.StructType{
+						Fields: []*spannerpb.StructType_Field{
+							{Name: "id", Type: &spannerpb.Type{Code: spannerpb.TypeCode_INT64}},
+							{Name: "details", Type: &spannerpb.Type{Code: spannerpb.TypeCode_JSON}},
+							{Name: "extra", Type: &spannerpb.Type{Code: spannerpb.TypeCode_JSON}},
+						},
+					},
+				},
+				Rows: []*structpb.ListValue{
+					{Values: []*structpb.Value{
+						{Kind: &structpb.Value_StringValue{StringValue: "1"}},
+						req.Params.Fields["p2"],
+						req.Params.Fields["p3"],
+					}},
+				},
+			},
+		})
+	var result jsonVenue
+	if err := db.First(&result).Error; err != nil {
+		t.Fatalf("failed to fetch venue: %v", err)
+	}
+	if g, w := result, venue; !reflect.DeepEqual(g, w) {
+		t.Fatalf("venue mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestJSONExpressions(t *testing.T) {
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+	dryRun := db.Session(&gorm.Session{DryRun: true})
+
+	for _, test := range []struct {
+		query func(db *gorm.DB) *gorm.DB
+		sql   string
+		vars  []interface{}
+	}{
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(JSONValue("details", "$.rating").Gt(4)) },
+			sql:   "SELECT * FROM `json_venues` WHERE LAX_FLOAT64(JSON_QUERY(`details`, \"$.rating\")) > ?",
+			vars:  []interface{}{4},
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(JSONValue("details", "$.open").Eq(true)) },
+			sql:   "SELECT * FROM `json_venues` WHERE LAX_BOOL(JSON_QUERY(`details`, \"$.open\")) = ?",
+			vars:  []interface{}{true},
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(JSONValue("details", "$.name").Neq("Hall")) },
+			sql:   "SELECT * FROM `json_venues` WHERE JSON_VALUE(`details`, \"$.name\") <> ?",
+			vars:  []interface{}{"Hall"},
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where("? IN UNNEST(?)", "rock", JSONValueArray("details", "$.genres"))
+			},
+			sql:  "SELECT * FROM `json_venues` WHERE ? IN UNNEST(JSON_VALUE_ARRAY(`details`, \"$.genres\"))",
+			vars: []interface{}{"rock"},
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Select("id, ?", JSONQuery("details", `$."it's"`)).Where("id = ?", 1)
+			},
+			sql:  "SELECT id, JSON_QUERY(`details`, \"$.\\\"it's\\\"\") FROM `json_venues` WHERE id = ?",
+			vars: []interface{}{1},
+		},
+	} {
+		stmt := test.query(dryRun).Find(&[]jsonVenue{}).Statement
+		if g, w := stmt.SQL.String(), test.sql; g != w {
+			t.Errorf("sql mismatch\n Got: %v\nWant: %v", g, w)
+		}
+		if g, w := stmt.Vars, test.vars; !reflect.DeepEqual(g, w) {
+			t.Errorf("%s: vars mismatch\n Got: %v\nWant: %v", test.sql, g, w)
+		}
+	}
+}
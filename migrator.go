@@ -20,10 +20,14 @@ import (
 	"fmt"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	"github.com/googleapis/go-gorm-spanner/internal/gormutil"
 	spannerdriver "github.com/googleapis/go-sql-spanner"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"gorm.io/gorm/migrator"
@@ -50,6 +54,68 @@ type SpannerMigrator interface {
 	StartBatchDDL() error
 	RunBatch() error
 	AbortBatch() error
+
+	// RenameTableWithSynonym renames a table and adds a synonym with the old
+	// name of the table. This allows applications that still use the old name
+	// to continue to work while the rename is being rolled out.
+	RenameTableWithSynonym(oldName, newName interface{}) error
+	// AddSynonym adds a synonym for the given table.
+	AddSynonym(value interface{}, synonym string) error
+	// DropSynonym drops a synonym from the given table.
+	DropSynonym(value interface{}, synonym string) error
+
+	// CreateSchema creates a named schema if it does not already exist.
+	CreateSchema(name string) error
+	// DropSchema drops a named schema. The schema must be empty.
+	DropSchema(name string) error
+	// HasSchema returns true if the database contains a named schema with
+	// the given name.
+	HasSchema(name string) bool
+
+	// HasView returns true if the database contains a view with the given name.
+	HasView(name string) bool
+
+	// CreateRole creates a database role for fine-grained access control.
+	CreateRole(name string) error
+	// DropRole drops a database role.
+	DropRole(name string) error
+	// HasRole returns true if the database contains a role with the given name.
+	HasRole(name string) bool
+	// GrantPrivilege grants a privilege on the table or view of the given model
+	// or table name to a database role. The privilege is limited to the given
+	// columns if one or more columns are specified.
+	GrantPrivilege(value interface{}, privilege, role string, columns ...string) error
+	// RevokePrivilege revokes a privilege on the table or view of the given
+	// model or table name from a database role.
+	RevokePrivilege(value interface{}, privilege, role string, columns ...string) error
+	// HasPrivilege returns true if the database role has been granted the
+	// privilege on the table or view of the given model or table name. All
+	// the given columns must have been granted if one or more columns are
+	// specified.
+	HasPrivilege(value interface{}, privilege, role string, columns ...string) bool
+
+	// CreateSequence creates a sequence if it does not already exist.
+	CreateSequence(sequence Sequence) error
+	// AlterSequence changes the options of an existing sequence.
+	AlterSequence(sequence Sequence) error
+	// DropSequence drops the sequence with the given name.
+	DropSequence(name string) error
+	// HasSequence returns true if the database contains a sequence with the
+	// given name.
+	HasSequence(name string) bool
+	// GetSequences returns all sequences in the database.
+	GetSequences() ([]Sequence, error)
+
+	// CreateProtoBundle creates the proto bundle of the database with the
+	// given proto message and enum types and all types that they reference.
+	// This requires Config.DatabaseAdminClient to be set.
+	CreateProtoBundle(types ...protoreflect.Descriptor) error
+	// AlterProtoBundle inserts, updates and deletes types in the proto bundle
+	// of the database. This requires Config.DatabaseAdminClient to be set.
+	AlterProtoBundle(insert, update []protoreflect.Descriptor, delete []string) error
+	// GetProtoBundle returns the names of the types in the proto bundle of the
+	// database. This requires Config.DatabaseAdminClient to be set.
+	GetProtoBundle() ([]string, error)
 }
 
 type spannerMigrator struct {
@@ -71,8 +137,73 @@ type Index struct {
 	IsPrimaryKey sql.NullBool
 }
 
+// CurrentDatabase returns the ID of the database that the migrator is
+// connected to.
 func (m spannerMigrator) CurrentDatabase() (name string) {
-	return ""
+	databaseName := m.databaseName()
+	return databaseName[strings.LastIndex(databaseName, "/")+1:]
+}
+
+// databaseName returns the fully qualified name of the database that the
+// migrator is connected to.
+func (m spannerMigrator) databaseName() (name string) {
+	conn, ok := m.DB.Statement.ConnPool.(*sql.Conn)
+	if !ok {
+		return ""
+	}
+	_ = conn.Raw(func(driverConn any) error {
+		spannerConn, ok := driverConn.(spannerdriver.SpannerConn)
+		if !ok {
+			return nil
+		}
+		client, err := spannerConn.UnderlyingClient()
+		if err != nil {
+			return err
+		}
+		name = client.DatabaseName()
+		return nil
+	})
+	return name
+}
+
+// CurrentSchema returns the schema and the unqualified name of the given
+// table. Tables in the default schema have an empty schema name.
+func (m spannerMigrator) CurrentSchema(stmt *gorm.Statement, table string) (string, string) {
+	return gormutil.TableSchema(stmt, table)
+}
+
+func (m spannerMigrator) CreateSchema(name string) error {
+	return m.DB.Exec("CREATE SCHEMA IF NOT EXISTS ?", clause.Table{Name: name}).Error
+}
+
+func (m spannerMigrator) DropSchema(name string) error {
+	return m.DB.Exec("DROP SCHEMA ?", clause.Table{Name: name}).Error
+}
+
+func (m spannerMigrator) HasSchema(name string) bool {
+	var count int64
+	m.DB.Raw("SELECT count(*) FROM information_schema.schemata WHERE schema_name = ?", name).Row().Scan(&count)
+	return count > 0
+}
+
+// qualifiedIndexName returns the name of an index on the given table. Indexes
+// on tables in a named schema must be created in the same schema as the table.
+func (m spannerMigrator) qualifiedIndexName(stmt *gorm.Statement, name string) string {
+	if currentSchema, _ := m.CurrentSchema(stmt, stmt.Table); currentSchema != "" && !strings.Contains(name, ".") {
+		return currentSchema + "." + name
+	}
+	return name
+}
+
+func (m spannerMigrator) tableOf(value interface{}) (interface{}, error) {
+	if v, ok := value.(string); ok {
+		return clause.Table{Name: v}, nil
+	}
+	stmt := &gorm.Statement{DB: m.DB}
+	if err := stmt.Parse(value); err != nil {
+		return nil, err
+	}
+	return m.CurrentTable(stmt), nil
 }
 
 func (m spannerMigrator) AutoMigrateDryRun(values ...interface{}) ([]spanner.Statement, error) {
@@ -85,12 +216,21 @@ func (m spannerMigrator) AutoMigrate(values ...interface{}) error {
 }
 
 func (m spannerMigrator) autoMigrate(dryRun bool, values ...interface{}) ([]spanner.Statement, error) {
+	// The proto bundle is changed with the database admin client, and must
+	// contain the proto types before the columns that use them are created.
+	protoBundle, err := m.migrateProtoBundle(dryRun, values...)
+	if err != nil {
+		return nil, err
+	}
 	if dryRun || !m.Dialector.Config.DisableAutoMigrateBatching {
 		if err := m.StartBatchDDL(); err != nil {
 			return nil, err
 		}
 	}
-	err := m.Migrator.AutoMigrate(values...)
+	// Schemas that are created in a batch do not exist until the batch has
+	// been executed, and must only be created once.
+	m.DB = gormutil.WithCreatedSchemas(m.DB)
+	err = m.autoMigrateModels(values...)
 	if err == nil {
 		if !dryRun && m.Dialector.Config.DisableAutoMigrateBatching {
 			return nil, nil
@@ -111,7 +251,7 @@ func (m spannerMigrator) autoMigrate(dryRun bool, values ...interface{}) ([]span
 			}); err != nil {
 				return nil, err
 			}
-			return statements, m.AbortBatch()
+			return append(protoBundle, statements...), m.AbortBatch()
 		} else {
 			return nil, m.RunBatch()
 		}
@@ -119,6 +259,191 @@ func (m spannerMigrator) autoMigrate(dryRun bool, values ...interface{}) ([]span
 	return nil, err
 }
 
+// autoMigrateModels migrates all tables before creating or replacing the
+// views that are defined by models that implement View. Roles and privileges
+// of models that implement TableGrants are migrated last.
+func (m spannerMigrator) autoMigrateModels(values ...interface{}) error {
+	var tables, views []interface{}
+	for _, value := range values {
+		if _, ok := value.(View); ok {
+			views = append(views, value)
+		} else {
+			tables = append(tables, value)
+		}
+	}
+	// Sequences of new tables are created by CreateTable.
+	var existingTables []interface{}
+	for _, value := range tables {
+		if m.HasTable(value) {
+			existingTables = append(existingTables, value)
+		}
+	}
+	if err := m.Migrator.AutoMigrate(tables...); err != nil {
+		return err
+	}
+	for _, value := range existingTables {
+		if err := m.migrateSequences(value); err != nil {
+			return err
+		}
+	}
+	for _, value := range views {
+		if err := m.views().MigrateView(value); err != nil {
+			return err
+		}
+	}
+	return m.migrateGrants(values...)
+}
+
+// migrateGrants creates the roles and grants the privileges that are declared
+// by models that implement TableGrants and that do not yet exist.
+func (m spannerMigrator) migrateGrants(values ...interface{}) error {
+	roles := make(map[string]bool)
+	for _, value := range values {
+		grants, ok := value.(TableGrants)
+		if !ok {
+			continue
+		}
+		for _, grant := range grants.TableGrants() {
+			if !roles[grant.Role] && !m.HasRole(grant.Role) {
+				if err := m.CreateRole(grant.Role); err != nil {
+					return err
+				}
+			}
+			roles[grant.Role] = true
+			if !m.HasPrivilege(value, grant.Privilege, grant.Role, grant.Columns...) {
+				if err := m.GrantPrivilege(value, grant.Privilege, grant.Role, grant.Columns...); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// views returns the migrator for the views of the models.
+func (m spannerMigrator) views() ViewMigrator {
+	return ViewMigrator{
+		Migrator: m.Migrator,
+		CurrentSchema: func(stmt *gorm.Statement, table string) (interface{}, interface{}) {
+			return m.CurrentSchema(stmt, table)
+		},
+	}
+}
+
+func (m spannerMigrator) CreateView(name string, option gorm.ViewOption) error {
+	return m.views().CreateView(name, option)
+}
+
+func (m spannerMigrator) DropView(name string) error {
+	return m.views().DropView(name)
+}
+
+func (m spannerMigrator) HasView(name string) bool {
+	return m.views().HasView(name)
+}
+
+func (m spannerMigrator) CreateRole(name string) error {
+	return m.DB.Exec("CREATE ROLE ?", clause.Column{Name: name}).Error
+}
+
+func (m spannerMigrator) DropRole(name string) error {
+	return m.DB.Exec("DROP ROLE ?", clause.Column{Name: name}).Error
+}
+
+func (m spannerMigrator) HasRole(name string) bool {
+	var count int64
+	m.DB.Raw("SELECT count(*) FROM information_schema.roles WHERE role_name = ?", name).Row().Scan(&count)
+	return count > 0
+}
+
+func (m spannerMigrator) GrantPrivilege(value interface{}, privilege, role string, columns ...string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		sql, values, err := m.buildGrant(stmt, privilege, columns)
+		if err != nil {
+			return err
+		}
+		return m.DB.Exec("GRANT "+sql+" TO ROLE ?", append(values, clause.Column{Name: role})...).Error
+	})
+}
+
+func (m spannerMigrator) RevokePrivilege(value interface{}, privilege, role string, columns ...string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		sql, values, err := m.buildGrant(stmt, privilege, columns)
+		if err != nil {
+			return err
+		}
+		return m.DB.Exec("REVOKE "+sql+" FROM ROLE ?", append(values, clause.Column{Name: role})...).Error
+	})
+}
+
+func (m spannerMigrator) HasPrivilege(value interface{}, privilege, role string, columns ...string) bool {
+	var count int64
+	if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		currentSchema, curTable := m.CurrentSchema(stmt, stmt.Table)
+		privilege = strings.ToUpper(privilege)
+		if len(columns) == 0 {
+			return m.DB.Raw(
+				"SELECT count(*) FROM information_schema.table_privileges WHERE table_schema = ? AND table_name = ? AND privilege_type = ? AND grantee = ?",
+				currentSchema, curTable, privilege, role,
+			).Row().Scan(&count)
+		}
+		names := columnNames(stmt, columns)
+		if err := m.DB.Raw(
+			"SELECT count(*) FROM information_schema.column_privileges WHERE table_schema = ? AND table_name = ? AND privilege_type = ? AND grantee = ? AND column_name IN ?",
+			currentSchema, curTable, privilege, role, names,
+		).Row().Scan(&count); err != nil {
+			return err
+		}
+		if count < int64(len(names)) {
+			count = 0
+		}
+		return nil
+	}); err != nil {
+		return false
+	}
+	return count > 0
+}
+
+// buildGrant returns the privilege and the object of a GRANT or REVOKE
+// statement, for example `SELECT(id, name) ON TABLE singers`.
+func (m spannerMigrator) buildGrant(stmt *gorm.Statement, privilege string, columns []string) (string, []interface{}, error) {
+	privilege = strings.ToUpper(privilege)
+	switch privilege {
+	case "SELECT", "INSERT", "UPDATE", "DELETE":
+	default:
+		return "", nil, fmt.Errorf("unsupported privilege: %s", privilege)
+	}
+	sql := privilege
+	var values []interface{}
+	if len(columns) > 0 {
+		sql += "(" + strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",") + ")"
+		for _, name := range columnNames(stmt, columns) {
+			values = append(values, clause.Column{Name: name})
+		}
+	}
+	if stmt.Schema != nil && isView(stmt.Schema) {
+		sql += " ON VIEW ?"
+	} else {
+		sql += " ON TABLE ?"
+	}
+	return sql, append(values, m.CurrentTable(stmt)), nil
+}
+
+// columnNames returns the database column names of the given field or
+// column names.
+func columnNames(stmt *gorm.Statement, columns []string) []string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column
+		if stmt.Schema != nil {
+			if field := stmt.Schema.LookUpField(column); field != nil {
+				names[i] = field.DBName
+			}
+		}
+	}
+	return names
+}
+
 func (m spannerMigrator) StartBatchDDL() error {
 	return m.DB.Exec("START BATCH DDL").Error
 }
@@ -166,6 +491,229 @@ func (m spannerMigrator) FullDataTypeOf(field *schema.Field) (expr clause.Expr)
 	return
 }
 
+// sequenceOf returns the sequence that is used to generate values for the
+// given field.
+func (m spannerMigrator) sequenceOf(stmt *gorm.Statement, f *schema.Field) (Sequence, error) {
+	name := stmt.Table + "_seq"
+	if currentSchema, _ := m.CurrentSchema(stmt, stmt.Table); currentSchema != "" {
+		name = currentSchema + "." + name
+	}
+	return sequenceOf(f, name)
+}
+
+// migrateSequences creates or alters the sequences of an existing table if
+// the sequences do not exist or if their options differ from the options in
+// the model.
+func (m spannerMigrator) migrateSequences(value interface{}) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		for _, f := range stmt.Schema.Fields {
+			if !m.shouldUseSequence(f) {
+				continue
+			}
+			sequence, err := m.sequenceOf(stmt, f)
+			if err != nil {
+				return err
+			}
+			existing, err := m.getSequences(sequence.Name)
+			if err != nil {
+				return err
+			}
+			if len(existing) == 0 {
+				if err := m.CreateSequence(sequence); err != nil {
+					return err
+				}
+			} else if !sequence.EqualOptions(existing[0]) {
+				if err := m.AlterSequence(sequence); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// migrateProtoBundle creates or alters the proto bundle of the database so
+// that it contains the current version of all proto message and enum types
+// that are used by the given models. The proto bundle is not migrated if no
+// database admin client has been set. The statement is returned instead of
+// executed if dryRun is true.
+func (m spannerMigrator) migrateProtoBundle(dryRun bool, values ...interface{}) ([]spanner.Statement, error) {
+	if m.DatabaseAdminClient == nil {
+		return nil, nil
+	}
+	var types []protoreflect.Descriptor
+	for _, value := range values {
+		stmt := &gorm.Statement{DB: m.DB}
+		if err := stmt.Parse(value); err != nil {
+			return nil, err
+		}
+		for _, field := range stmt.Schema.Fields {
+			if descriptor := protoDescriptorOf(field.FieldType); descriptor != nil && !field.IgnoreMigration {
+				types = append(types, descriptor)
+			}
+		}
+	}
+	if len(types) == 0 {
+		return nil, nil
+	}
+	types = protoBundleTypes(types)
+	ddl, err := m.DatabaseAdminClient.GetDatabaseDdl(m.DB.Statement.Context, &databasepb.GetDatabaseDdlRequest{Database: m.databaseName()})
+	if err != nil {
+		return nil, err
+	}
+	statement := protoBundleStatement(ddl.Statements, ddl.ProtoDescriptors, types)
+	if statement == "" {
+		return nil, nil
+	}
+	if dryRun {
+		return []spanner.Statement{{SQL: statement}}, nil
+	}
+	return nil, m.updateProtoBundle(statement, types)
+}
+
+func (m spannerMigrator) CreateProtoBundle(types ...protoreflect.Descriptor) error {
+	types = protoBundleTypes(types)
+	return m.updateProtoBundle("CREATE PROTO BUNDLE ("+strings.Join(protoTypeNames(types), ", ")+")", types)
+}
+
+func (m spannerMigrator) AlterProtoBundle(insert, update []protoreflect.Descriptor, delete []string) error {
+	statement := alterProtoBundleStatement(insert, update, delete)
+	if statement == "" {
+		return nil
+	}
+	return m.updateProtoBundle(statement, append(slices.Clone(insert), update...))
+}
+
+func (m spannerMigrator) GetProtoBundle() ([]string, error) {
+	if m.DatabaseAdminClient == nil {
+		return nil, errDatabaseAdminClientRequired
+	}
+	ddl, err := m.DatabaseAdminClient.GetDatabaseDdl(m.DB.Statement.Context, &databasepb.GetDatabaseDdlRequest{Database: m.databaseName()})
+	if err != nil {
+		return nil, err
+	}
+	for _, statement := range ddl.Statements {
+		if isCreateProtoBundle(statement) {
+			return protoBundleNames(statement), nil
+		}
+	}
+	return nil, nil
+}
+
+// updateProtoBundle executes the given CREATE or ALTER PROTO BUNDLE statement
+// with the descriptors of the given types.
+func (m spannerMigrator) updateProtoBundle(statement string, types []protoreflect.Descriptor) error {
+	if m.DatabaseAdminClient == nil {
+		return errDatabaseAdminClientRequired
+	}
+	descriptors, err := protoDescriptorSet(types)
+	if err != nil {
+		return err
+	}
+	ctx := m.DB.Statement.Context
+	op, err := m.DatabaseAdminClient.UpdateDatabaseDdl(ctx, &databasepb.UpdateDatabaseDdlRequest{
+		Database:         m.databaseName(),
+		Statements:       []string{statement},
+		ProtoDescriptors: descriptors,
+	})
+	if err != nil {
+		return err
+	}
+	return op.Wait(ctx)
+}
+
+// GetTypeAliases returns the aliases of the given database type. The types of
+// proto columns are returned by Spanner as PROTO<name> and ENUM<name>, while
+// the DDL for these columns uses the name of the type.
+func (m spannerMigrator) GetTypeAliases(databaseTypeName string) []string {
+	if aliases := protoTypeAliases(databaseTypeName); aliases != nil {
+		return aliases
+	}
+	return m.Migrator.GetTypeAliases(databaseTypeName)
+}
+
+func (m spannerMigrator) CreateSequence(sequence Sequence) error {
+	return m.DB.Exec("CREATE SEQUENCE IF NOT EXISTS " + sequence.Name + " " + sequence.options(false)).Error
+}
+
+func (m spannerMigrator) AlterSequence(sequence Sequence) error {
+	return m.DB.Exec("ALTER SEQUENCE " + sequence.Name + " SET " + sequence.options(true)).Error
+}
+
+func (m spannerMigrator) DropSequence(name string) error {
+	return m.DB.Exec("DROP SEQUENCE " + name).Error
+}
+
+func (m spannerMigrator) HasSequence(name string) bool {
+	var count int64
+	currentSchema, curName := m.CurrentSchema(nil, name)
+	m.DB.Raw("SELECT count(*) FROM information_schema.sequences WHERE `schema` = ? AND name = ?", currentSchema, curName).Row().Scan(&count)
+	return count > 0
+}
+
+func (m spannerMigrator) GetSequences() ([]Sequence, error) {
+	return m.getSequences("")
+}
+
+// getSequences returns the sequence with the given name, or all sequences if
+// name is empty.
+func (m spannerMigrator) getSequences(name string) ([]Sequence, error) {
+	query := "SELECT s.`schema`, s.name, o.option_name, o.option_value " +
+		"FROM information_schema.sequences s " +
+		"LEFT JOIN information_schema.sequence_options o ON o.`schema` = s.`schema` AND o.name = s.name "
+	var values []interface{}
+	if name != "" {
+		currentSchema, curName := m.CurrentSchema(nil, name)
+		query += "WHERE s.`schema` = ? AND s.name = ? "
+		values = append(values, currentSchema, curName)
+	}
+	rows, err := m.DB.Raw(query+"ORDER BY s.`schema`, s.name", values...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var sequences []Sequence
+	for rows.Next() {
+		var (
+			sequenceSchema, sequenceName string
+			optionName, optionValue      sql.NullString
+		)
+		if err := rows.Scan(&sequenceSchema, &sequenceName, &optionName, &optionValue); err != nil {
+			return nil, err
+		}
+		if sequenceSchema != "" {
+			sequenceName = sequenceSchema + "." + sequenceName
+		}
+		if len(sequences) == 0 || sequences[len(sequences)-1].Name != sequenceName {
+			sequences = append(sequences, Sequence{Name: sequenceName})
+		}
+		sequence := &sequences[len(sequences)-1]
+		if !optionValue.Valid {
+			continue
+		}
+		switch strings.ToLower(optionName.String) {
+		case "sequence_kind":
+			sequence.Kind = strings.ToLower(optionValue.String)
+		case "skip_range_min":
+			sequence.SkipRangeMin = parseInt64Option(optionValue.String)
+		case "skip_range_max":
+			sequence.SkipRangeMax = parseInt64Option(optionValue.String)
+		case "start_with_counter":
+			sequence.StartWithCounter = parseInt64Option(optionValue.String)
+		}
+	}
+	return sequences, rows.Err()
+}
+
+func parseInt64Option(value string) *int64 {
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
 func (m spannerMigrator) shouldUseSequence(f *schema.Field) bool {
 	sequence := f.Tag.Get(gormSpannerSequenceTag)
 	return (sequence != "" || m.DefaultSequenceKind == DisableIdentityColumns) && f.AutoIncrement && f.HasDefaultValue && f.DefaultValue == "" && f.DefaultValueInterface == nil
@@ -180,18 +728,22 @@ func (m spannerMigrator) CreateTable(values ...interface{}) error {
 				values                  = []interface{}{m.CurrentTable(stmt)}
 				hasPrimaryKeyInDataType bool
 			)
+			currentSchema, _ := m.CurrentSchema(stmt, stmt.Table)
+			if currentSchema != "" && !gormutil.SchemaCreated(m.DB, currentSchema) && !m.HasSchema(currentSchema) {
+				if err := m.CreateSchema(currentSchema); err != nil {
+					return err
+				}
+			}
 			for _, f := range stmt.Schema.Fields {
 				if m.shouldUseSequence(f) {
-					sequence := f.Tag.Get(gormSpannerSequenceTag)
-					if sequence == "" {
-						sequence = stmt.Table + "_seq"
+					sequence, err := m.sequenceOf(stmt, f)
+					if err != nil {
+						return err
 					}
-					if err := tx.Exec("CREATE SEQUENCE IF NOT EXISTS " +
-						sequence +
-						` OPTIONS (sequence_kind = "bit_reversed_positive")`).Error; err != nil {
+					if err := m.CreateSequence(sequence); err != nil {
 						return err
 					}
-					f.DefaultValue = "GET_NEXT_SEQUENCE_VALUE(Sequence " + sequence + ")"
+					f.DefaultValue = "GET_NEXT_SEQUENCE_VALUE(Sequence " + sequence.Name + ")"
 					// Reset the default value to nothing after finishing migration.
 					//goland:noinspection GoDeferInLoop
 					defer func() { f.DefaultValue = "" }()
@@ -295,17 +847,136 @@ func (m spannerMigrator) DropTable(values ...interface{}) error {
 	return nil
 }
 
+// GetTables returns the names of all tables in the database. Tables in a
+// named schema are returned as `schema.table`.
+func (m spannerMigrator) GetTables() (tableList []string, err error) {
+	err = m.DB.Raw(
+		"SELECT IF(table_schema = '', table_name, CONCAT(table_schema, '.', table_name)) FROM information_schema.tables " +
+			"WHERE table_schema NOT IN ('INFORMATION_SCHEMA', 'SPANNER_SYS') AND table_type = 'BASE TABLE' " +
+			"ORDER BY table_schema, table_name",
+	).Scan(&tableList).Error
+	return
+}
+
+func (m spannerMigrator) HasTable(value interface{}) bool {
+	var count int64
+	m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		currentSchema, curTable := m.CurrentSchema(stmt, stmt.Table)
+		return m.DB.Raw(
+			"SELECT count(*) FROM information_schema.tables WHERE table_schema = ? AND table_name = ? AND table_type = ?",
+			currentSchema, curTable, "BASE TABLE",
+		).Row().Scan(&count)
+	})
+
+	return count > 0
+}
+
+// RenameTable renames a table. Use RenameTableWithSynonym to keep the old
+// name of the table as a synonym.
+func (m spannerMigrator) RenameTable(oldName, newName interface{}) error {
+	oldTable, err := m.tableOf(oldName)
+	if err != nil {
+		return err
+	}
+	newTable, err := m.tableOf(newName)
+	if err != nil {
+		return err
+	}
+	return m.DB.Exec("ALTER TABLE ? RENAME TO ?", oldTable, newTable).Error
+}
+
+func (m spannerMigrator) RenameTableWithSynonym(oldName, newName interface{}) error {
+	oldTable, err := m.tableOf(oldName)
+	if err != nil {
+		return err
+	}
+	newTable, err := m.tableOf(newName)
+	if err != nil {
+		return err
+	}
+	return m.DB.Exec("ALTER TABLE ? RENAME TO ?, ADD SYNONYM ?", oldTable, newTable, oldTable).Error
+}
+
+func (m spannerMigrator) AddSynonym(value interface{}, synonym string) error {
+	table, err := m.tableOf(value)
+	if err != nil {
+		return err
+	}
+	return m.DB.Exec("ALTER TABLE ? ADD SYNONYM ?", table, clause.Table{Name: synonym}).Error
+}
+
+func (m spannerMigrator) DropSynonym(value interface{}, synonym string) error {
+	table, err := m.tableOf(value)
+	if err != nil {
+		return err
+	}
+	return m.DB.Exec("ALTER TABLE ? DROP SYNONYM ?", table, clause.Table{Name: synonym}).Error
+}
+
+// ErrRenameColumnNotSupported is returned by RenameColumn.
+var ErrRenameColumnNotSupported = errors.New("renaming columns is not supported by Spanner, add a new column and copy the data instead")
+
+// RenameColumn is not supported. Spanner supports renaming tables, see
+// RenameTable, but not renaming columns. RenameColumn therefore always returns
+// ErrRenameColumnNotSupported without executing any statements. Rename a
+// column by adding a column with the new name, copying the data to the new
+// column, and dropping the old column.
+func (m spannerMigrator) RenameColumn(value interface{}, oldName, newName string) error {
+	return ErrRenameColumnNotSupported
+}
+
+func (m spannerMigrator) HasColumn(value interface{}, field string) bool {
+	var count int64
+	m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		name := field
+		if stmt.Schema != nil {
+			if field := stmt.Schema.LookUpField(field); field != nil {
+				name = field.DBName
+			}
+		}
+
+		currentSchema, curTable := m.CurrentSchema(stmt, stmt.Table)
+		return m.DB.Raw(
+			"SELECT count(*) FROM INFORMATION_SCHEMA.columns WHERE table_schema = ? AND table_name = ? AND column_name = ?",
+			currentSchema, curTable, name,
+		).Row().Scan(&count)
+	})
+
+	return count > 0
+}
+
+// HasConstraint returns true if the table has a foreign key or check
+// constraint with the given name.
+func (m spannerMigrator) HasConstraint(value interface{}, name string) bool {
+	var count int64
+	m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		constraint, table := m.GuessConstraintInterfaceAndTable(stmt, name)
+		if constraint != nil {
+			name = constraint.GetName()
+		}
+		currentSchema, curTable := m.CurrentSchema(stmt, table)
+
+		return m.DB.Raw(
+			"SELECT count(*) FROM INFORMATION_SCHEMA.table_constraints WHERE table_schema = ? AND table_name = ? AND constraint_name = ?",
+			currentSchema, curTable, name,
+		).Row().Scan(&count)
+	})
+
+	return count > 0
+}
+
 func (m spannerMigrator) HasIndex(value interface{}, name string) bool {
 	var count int64
 	m.RunWithValue(value, func(stmt *gorm.Statement) error {
-		currentDatabase := m.DB.Migrator().CurrentDatabase()
+		currentSchema, curTable := m.CurrentSchema(stmt, stmt.Table)
 		if idx := stmt.Schema.LookIndex(name); idx != nil {
 			name = idx.Name
 		}
+		_, name = m.CurrentSchema(stmt, name)
 
 		return m.DB.Raw(
 			"SELECT count(*) FROM information_schema.indexes WHERE table_schema = ? AND table_name = ? AND index_name = ?",
-			currentDatabase, stmt.Table, name,
+			currentSchema, curTable, name,
 		).Row().Scan(&count)
 	})
 
@@ -339,9 +1010,9 @@ func (m spannerMigrator) GetIndexes(value interface{}) ([]gorm.Index, error) {
 	`
 	indexes := make([]gorm.Index, 0)
 	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
-		currentDatabase := m.DB.Migrator().CurrentDatabase()
+		currentSchema, curTable := m.CurrentSchema(stmt, stmt.Table)
 		result := make([]*Index, 0)
-		if err := m.DB.Raw(indexSQL, currentDatabase, stmt.Table).Scan(&result).Error; err != nil {
+		if err := m.DB.Raw(indexSQL, currentSchema, curTable).Scan(&result).Error; err != nil {
 			return err
 		}
 		indexMap := make(map[string]*migrator.Index)
@@ -370,13 +1041,39 @@ func (m spannerMigrator) GetIndexes(value interface{}) ([]gorm.Index, error) {
 	return indexes, err
 }
 
+func (m spannerMigrator) CreateIndex(value interface{}, name string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema == nil {
+			return errors.New("failed to get schema")
+		}
+		if idx := stmt.Schema.LookIndex(name); idx != nil {
+			opts := m.BuildIndexOptions(idx.Fields, stmt)
+			values := []interface{}{clause.Column{Name: m.qualifiedIndexName(stmt, idx.Name)}, m.CurrentTable(stmt), opts}
+
+			createIndexSQL := "CREATE "
+			if idx.Class != "" {
+				createIndexSQL += idx.Class + " "
+			}
+			createIndexSQL += "INDEX ? ON ??"
+
+			if idx.Option != "" {
+				createIndexSQL += " " + idx.Option
+			}
+
+			return m.DB.Exec(createIndexSQL, values...).Error
+		}
+
+		return fmt.Errorf("failed to create index with name %s", name)
+	})
+}
+
 func (m spannerMigrator) DropIndex(value interface{}, name string) error {
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
 		if idx := stmt.Schema.LookIndex(name); idx != nil {
 			name = idx.Name
 		}
 
-		return m.DB.Exec("DROP INDEX ?", clause.Column{Name: name}).Error
+		return m.DB.Exec("DROP INDEX ?", clause.Column{Name: m.qualifiedIndexName(stmt, name)}).Error
 	})
 }
 
@@ -430,8 +1127,8 @@ func (m spannerMigrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, erro
 		}
 
 		columnTypeSQL += "FROM INFORMATION_SCHEMA.COLUMNS C WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION"
-		currentDatabase := m.CurrentDatabase()
-		columns, rowErr := m.DB.Table(stmt.Table).Raw(columnTypeSQL, &currentDatabase, &stmt.Table).Rows()
+		currentSchema, curTable := m.CurrentSchema(stmt, stmt.Table)
+		columns, rowErr := m.DB.Table(stmt.Table).Raw(columnTypeSQL, &currentSchema, &curTable).Rows()
 		if rowErr != nil {
 			return rowErr
 		}
@@ -489,7 +1186,7 @@ func (m spannerMigrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, erro
 func (m spannerMigrator) isColumnGenerated(value interface{}, field string) bool {
 	var count int64
 	m.RunWithValue(value, func(stmt *gorm.Statement) error {
-		currentDatabase := m.DB.Migrator().CurrentDatabase()
+		currentSchema, curTable := m.CurrentSchema(stmt, stmt.Table)
 		name := field
 		if field := stmt.Schema.LookUpField(field); field != nil {
 			name = field.DBName
@@ -497,7 +1194,7 @@ func (m spannerMigrator) isColumnGenerated(value interface{}, field string) bool
 
 		return m.DB.Raw(
 			"SELECT count(*) FROM INFORMATION_SCHEMA.columns WHERE table_schema = ? AND table_name = ? AND column_name = ? AND generation_expression IS NOT NULL",
-			currentDatabase, stmt.Table, name,
+			currentSchema, curTable, name,
 		).Row().Scan(&count)
 	})
 
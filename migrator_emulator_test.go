@@ -20,6 +20,7 @@ import (
 	"log"
 	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -404,6 +405,68 @@ func TestMigrateUniqueFieldFails(t *testing.T) {
 	}
 }
 
+func TestMigrateConstraintsAndRenameTable(t *testing.T) {
+	skipIfShortOrNotEmulator(t)
+	t.Parallel()
+
+	dsn, cleanup, err := testutil.CreateTestDB(context.Background(), databasepb.DatabaseDialect_GOOGLE_STANDARD_SQL)
+	if err != nil {
+		log.Fatalf("could not init integration tests while creating database: %v", err)
+	}
+	defer cleanup()
+	// Open db.
+	db, err := gorm.Open(New(Config{
+		DriverName: "spanner",
+		DSN:        dsn,
+	}), &gorm.Config{PrepareStmt: true})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	type Product struct {
+		gorm.Model
+		Price int64 `gorm:"check:chk_products_price,price > 0"`
+	}
+	if err := db.AutoMigrate(&Singer{}, &Album{}, &Product{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	m := db.Migrator()
+	if !m.HasConstraint(&Album{}, "fk_singers_albums") {
+		t.Fatal("missing foreign key fk_singers_albums")
+	}
+	if !m.HasConstraint(&Product{}, "chk_products_price") {
+		t.Fatal("missing check constraint chk_products_price")
+	}
+	if !m.HasColumn(&Album{}, "Title") {
+		t.Fatal("missing column title")
+	}
+	if g, w := m.CurrentDatabase(), dsn[strings.LastIndex(dsn, "/")+1:]; g != w {
+		t.Fatalf("current database mismatch\n Got: %v\nWant: %v", g, w)
+	}
+
+	if err := m.(SpannerMigrator).RenameTableWithSynonym(&Product{}, "items"); err != nil {
+		t.Fatalf("failed to rename table: %v", err)
+	}
+	if !m.HasTable("items") {
+		t.Fatal("missing renamed table")
+	}
+	// The synonym can be used to query the table.
+	var count int64
+	if err := db.Model(&Product{}).Count(&count).Error; err != nil {
+		t.Fatalf("failed to query table using synonym: %v", err)
+	}
+	if err := m.(SpannerMigrator).DropSynonym("items", "products"); err != nil {
+		t.Fatalf("failed to drop synonym: %v", err)
+	}
+	tables, err := m.GetTables()
+	if err != nil {
+		t.Fatalf("failed to get tables: %v", err)
+	}
+	if g, w := tables, []string{"albums", "items", "singers"}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("tables mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
 func TestMigrateAllTypes(t *testing.T) {
 	skipIfShortOrNotEmulator(t)
 	t.Parallel()
@@ -579,3 +642,54 @@ func verifyColumnType(t *testing.T, db *gorm.DB, table, column, want string) {
 		t.Fatalf("data type mismatch for column %v.%v\n Got: %v\nWant: %v", table, column, g, w)
 	}
 }
+
+func TestMigrateView(t *testing.T) {
+	skipIfShortOrNotEmulator(t)
+	t.Parallel()
+
+	dsn, cleanup, err := testutil.CreateTestDB(context.Background(), databasepb.DatabaseDialect_GOOGLE_STANDARD_SQL)
+	if err != nil {
+		log.Fatalf("could not init integration tests while creating database: %v", err)
+	}
+	defer cleanup()
+	// Open db.
+	db, err := gorm.Open(New(Config{
+		DriverName: "spanner",
+		DSN:        dsn,
+	}), &gorm.Config{PrepareStmt: true})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := db.AutoMigrate(&singer{}, &activeSinger{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	m := db.Migrator().(SpannerMigrator)
+	if !m.HasView("active_singers") {
+		t.Fatal("missing view active_singers")
+	}
+	// Migrating an unchanged view should be a no-op.
+	statements, err := m.AutoMigrateDryRun(&singer{}, &activeSinger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := len(statements), 0; g != w {
+		t.Fatalf("statement count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if err := db.Create(&singer{FullName: "Alice", Active: true}).Error; err != nil {
+		t.Fatal(err)
+	}
+	var singers []activeSinger
+	if err := db.Find(&singers).Error; err != nil {
+		t.Fatal(err)
+	}
+	if g, w := len(singers), 1; g != w {
+		t.Fatalf("singer count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if err := db.Migrator().DropView("active_singers"); err != nil {
+		t.Fatal(err)
+	}
+	if db.Migrator().(SpannerMigrator).HasView("active_singers") {
+		t.Fatal("view active_singers was not dropped")
+	}
+}
@@ -16,7 +16,9 @@ package gorm
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
 	"testing"
 
@@ -177,6 +179,62 @@ func TestCustomDefaultSequenceKind(t *testing.T) {
 	}
 }
 
+func TestMigrateDefaultSchema(t *testing.T) {
+	t.Parallel()
+
+	server, _, serverTeardown := setupMockedTestServer(t)
+	db, server, teardown := setupTestGormConnectionWithDialector(t, server, serverTeardown, New(Config{
+		DriverName:    "spanner",
+		DSN:           fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true", server.Address),
+		DefaultSchema: "sales",
+	}))
+	defer teardown()
+	anyProto, err := anypb.New(&emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.TestDatabaseAdmin.SetResps([]proto.Message{
+		&longrunningpb.Operation{
+			Name:   "test-operation",
+			Done:   true,
+			Result: &longrunningpb.Operation_Response{Response: anyProto},
+		},
+	})
+
+	err = db.Migrator().AutoMigrate(&singer{}, &album{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests := server.TestDatabaseAdmin.Reqs()
+	if g, w := len(requests), 1; g != w {
+		t.Fatalf("request count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	request := requests[0].(*databasepb.UpdateDatabaseDdlRequest)
+	for i, w := range []string{
+		"CREATE SCHEMA IF NOT EXISTS `sales`",
+		"CREATE TABLE `sales`.`singers` (" +
+			"`id` INT64 GENERATED BY DEFAULT AS IDENTITY (BIT_REVERSED_POSITIVE),`created_at` TIMESTAMP,`updated_at` TIMESTAMP,`deleted_at` TIMESTAMP," +
+			"`first_name` STRING(MAX),`last_name` STRING(MAX),`full_name` STRING(MAX),`active` BOOL) " +
+			"PRIMARY KEY (`id`)",
+		"CREATE INDEX `sales`.`idx_sales_singers_deleted_at` ON `sales`.`singers`(`deleted_at`)",
+		"CREATE TABLE `sales`.`albums` (`id` INT64 GENERATED BY DEFAULT AS IDENTITY (BIT_REVERSED_POSITIVE),`created_at` TIMESTAMP,`updated_at` TIMESTAMP,`deleted_at` TIMESTAMP," +
+			"`title` STRING(MAX),`rating` FLOAT32,`singer_id` INT64," +
+			"CONSTRAINT `fk_sales_albums_singer` FOREIGN KEY (`singer_id`) REFERENCES `sales`.`singers`(`id`)) " +
+			"PRIMARY KEY (`id`)",
+		"CREATE INDEX `sales`.`idx_sales_albums_deleted_at` ON `sales`.`albums`(`deleted_at`)",
+	} {
+		if i >= len(request.GetStatements()) {
+			t.Fatalf("missing statement %d: %s", i, w)
+		}
+		if g := request.GetStatements()[i]; g != w {
+			t.Fatalf("%d: statement text mismatch\n Got: %s\nWant: %s", i, g, w)
+		}
+	}
+	if g, w := len(request.GetStatements()), 5; g != w {
+		t.Fatalf("statement count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
 func TestAutoIncrementColumns(t *testing.T) {
 	t.Parallel()
 
@@ -343,6 +401,251 @@ func TestMigrateMultipleTimes(t *testing.T) {
 	}
 }
 
+func TestRenameTable(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+	anyProto, err := anypb.New(&emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.TestDatabaseAdmin.SetResps([]proto.Message{
+		&longrunningpb.Operation{
+			Name:   "test-operation-1",
+			Done:   true,
+			Result: &longrunningpb.Operation_Response{Response: anyProto},
+		},
+		&longrunningpb.Operation{
+			Name:   "test-operation-2",
+			Done:   true,
+			Result: &longrunningpb.Operation_Response{Response: anyProto},
+		},
+		&longrunningpb.Operation{
+			Name:   "test-operation-3",
+			Done:   true,
+			Result: &longrunningpb.Operation_Response{Response: anyProto},
+		},
+	})
+
+	m := db.Migrator().(SpannerMigrator)
+	if err := m.RenameTable(&singer{}, "performers"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.RenameTableWithSynonym("performers", "sales.singers"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.DropSynonym("sales.singers", "performers"); err != nil {
+		t.Fatal(err)
+	}
+	requests := server.TestDatabaseAdmin.Reqs()
+	if g, w := len(requests), 3; g != w {
+		t.Fatalf("request count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	for i, want := range []string{
+		"ALTER TABLE `singers` RENAME TO `performers`",
+		"ALTER TABLE `performers` RENAME TO `sales`.`singers`, ADD SYNONYM `performers`",
+		"ALTER TABLE `sales`.`singers` DROP SYNONYM `performers`",
+	} {
+		request := requests[i].(*databasepb.UpdateDatabaseDdlRequest)
+		if g, w := request.GetStatements()[0], want; g != w {
+			t.Errorf("%d: statement mismatch\n Got: %v\nWant: %v", i, g, w)
+		}
+	}
+}
+
+func TestRenameColumn(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	if err := db.Migrator().RenameColumn(&singer{}, "FirstName", "GivenName"); !errors.Is(err, ErrRenameColumnNotSupported) {
+		t.Fatalf("error mismatch\n Got: %v\nWant: %v", err, ErrRenameColumnNotSupported)
+	}
+	if g, w := len(server.TestDatabaseAdmin.Reqs()), 0; g != w {
+		t.Fatalf("DDL request count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestHasConstraint(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	hasConstraintSql := "SELECT count(*) FROM INFORMATION_SCHEMA.table_constraints WHERE table_schema = @p1 AND table_name = @p2 AND constraint_name = @p3"
+	_ = putCountStatementResult(server, hasConstraintSql, 1)
+
+	if !db.Migrator().HasConstraint(&album{}, "Singer") {
+		t.Fatal("missing constraint")
+	}
+	req := getLastSqlRequest(server)
+	if g, w := req.Sql, hasConstraintSql; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.Params.Fields["p1"].GetStringValue(), ""; g != w {
+		t.Errorf("schema mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.Params.Fields["p2"].GetStringValue(), "albums"; g != w {
+		t.Errorf("table mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.Params.Fields["p3"].GetStringValue(), "fk_albums_singer"; g != w {
+		t.Errorf("constraint mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestGetTables(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	getTablesSql := "SELECT IF(table_schema = '', table_name, CONCAT(table_schema, '.', table_name)) FROM information_schema.tables " +
+		"WHERE table_schema NOT IN ('INFORMATION_SCHEMA', 'SPANNER_SYS') AND table_type = 'BASE TABLE' " +
+		"ORDER BY table_schema, table_name"
+	_ = server.TestSpanner.PutStatementResult(getTablesSql, &testutil.StatementResult{
+		Type: testutil.StatementResultResultSet,
+		ResultSet: &spannerpb.ResultSet{
+			Metadata: &spannerpb.ResultSetMetadata{
+				RowType: &spannerpb.StructType{
+					Fields: []*spannerpb.StructType_Field{
+						{Type: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}, Name: ""},
+					},
+				},
+			},
+			Rows: []*structpb.ListValue{
+				{Values: []*structpb.Value{{Kind: &structpb.Value_StringValue{StringValue: "singers"}}}},
+				{Values: []*structpb.Value{{Kind: &structpb.Value_StringValue{StringValue: "sales.orders"}}}},
+			},
+		},
+	})
+
+	tables, err := db.Migrator().GetTables()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := tables, []string{"singers", "sales.orders"}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("tables mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestCurrentDatabase(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	if g, w := db.Migrator().CurrentDatabase(), "d"; g != w {
+		t.Fatalf("database mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+type activeSinger struct {
+	ID       uint
+	FullName string
+}
+
+func (activeSinger) ViewQuery(db *gorm.DB) *gorm.DB {
+	return db.Model(&singer{}).Select("id", "full_name").Where("active = ?", true)
+}
+
+func TestAutoMigrateView(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	statements, err := db.Migrator().(SpannerMigrator).AutoMigrateDryRun(&activeSinger{}, &singer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := len(statements), 3; g != w {
+		t.Fatalf("statement count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	// Views are created after all tables.
+	if g, w := statements[2].SQL,
+		"CREATE OR REPLACE VIEW `active_singers` SQL SECURITY INVOKER AS "+
+			"SELECT `id`,`full_name` FROM `singers` WHERE active = true AND `singers`.`deleted_at` IS NULL"; g != w {
+		t.Fatalf("create view statement text mismatch\n Got: %s\nWant: %s", g, w)
+	}
+}
+
+func TestAutoMigrateUnchangedView(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	_ = server.TestSpanner.PutStatementResult(
+		"SELECT view_definition FROM information_schema.views WHERE table_schema = @p1 AND table_name = @p2",
+		&testutil.StatementResult{
+			Type: testutil.StatementResultResultSet,
+			ResultSet: &spannerpb.ResultSet{
+				Metadata: &spannerpb.ResultSetMetadata{
+					RowType: &spannerpb.StructType{
+						Fields: []*spannerpb.StructType_Field{
+							{Type: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}, Name: "view_definition"},
+						},
+					},
+				},
+				Rows: []*structpb.ListValue{
+					{Values: []*structpb.Value{{Kind: &structpb.Value_StringValue{
+						StringValue: "SELECT `id`,`full_name` FROM `singers` WHERE active = true AND `singers`.`deleted_at` IS NULL",
+					}}}},
+				},
+			},
+		})
+	statements, err := db.Migrator().(SpannerMigrator).AutoMigrateDryRun(&activeSinger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := len(statements), 0; g != w {
+		t.Fatalf("statement count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestDropAndHasView(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+	anyProto, err := anypb.New(&emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.TestDatabaseAdmin.SetResps([]proto.Message{
+		&longrunningpb.Operation{
+			Name:   "test-operation",
+			Done:   true,
+			Result: &longrunningpb.Operation_Response{Response: anyProto},
+		},
+	})
+	_ = putCountStatementResult(server, "SELECT count(*) FROM information_schema.views WHERE table_schema = @p1 AND table_name = @p2", 1)
+
+	m := db.Migrator().(SpannerMigrator)
+	if !m.HasView("active_singers") {
+		t.Fatal("HasView returned false")
+	}
+	req := getLastSqlRequest(server)
+	if g, w := req.Params.Fields["p2"].GetStringValue(), "active_singers"; g != w {
+		t.Fatalf("table name mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if err := m.DropView("active_singers"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.CreateView("checked", gorm.ViewOption{Query: db.Model(&singer{}), CheckOption: "WITH CHECK OPTION"}); !errors.Is(err, errViewCheckOptionNotSupported) {
+		t.Fatalf("error mismatch\n Got: %v\nWant: %v", err, errViewCheckOptionNotSupported)
+	}
+	requests := server.TestDatabaseAdmin.Reqs()
+	if g, w := len(requests), 1; g != w {
+		t.Fatalf("request count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := requests[0].(*databasepb.UpdateDatabaseDdlRequest).GetStatements()[0], "DROP VIEW `active_singers`"; g != w {
+		t.Fatalf("drop view statement text mismatch\n Got: %s\nWant: %s", g, w)
+	}
+}
+
 func putCountStatementResult(server *testutil.MockedSpannerInMemTestServer, sql string, count int) error {
 	return server.TestSpanner.PutStatementResult(sql, &testutil.StatementResult{
 		Type: testutil.StatementResultResultSet,
@@ -470,11 +773,11 @@ func putSelectSingerRowResult(server *testutil.MockedSpannerInMemTestServer, sql
 	})
 }
 
-func setupTestGormConnection(t *testing.T) (db *gorm.DB, server *testutil.MockedSpannerInMemTestServer, teardown func()) {
+func setupTestGormConnection(t testing.TB) (db *gorm.DB, server *testutil.MockedSpannerInMemTestServer, teardown func()) {
 	return setupTestGormConnectionWithParams(t, "")
 }
 
-func setupTestGormConnectionWithParams(t *testing.T, params string) (db *gorm.DB, server *testutil.MockedSpannerInMemTestServer, teardown func()) {
+func setupTestGormConnectionWithParams(t testing.TB, params string) (db *gorm.DB, server *testutil.MockedSpannerInMemTestServer, teardown func()) {
 	server, _, serverTeardown := setupMockedTestServer(t)
 	return setupTestGormConnectionWithDialector(t, server, serverTeardown, New(Config{
 		DriverName: "spanner",
@@ -482,7 +785,7 @@ func setupTestGormConnectionWithParams(t *testing.T, params string) (db *gorm.DB
 	}))
 }
 
-func setupTestGormConnectionWithDialector(t *testing.T, s *testutil.MockedSpannerInMemTestServer, serverTeardown func(), dialector gorm.Dialector) (db *gorm.DB, server *testutil.MockedSpannerInMemTestServer, teardown func()) {
+func setupTestGormConnectionWithDialector(t testing.TB, s *testutil.MockedSpannerInMemTestServer, serverTeardown func(), dialector gorm.Dialector) (db *gorm.DB, server *testutil.MockedSpannerInMemTestServer, teardown func()) {
 	db, err := gorm.Open(
 		dialector,
 		&gorm.Config{
@@ -526,15 +829,15 @@ func setupTestGormConnectionWithCustomConfig(t *testing.T, config spannerdriver.
 	}
 }
 
-func setupMockedTestServer(t *testing.T) (server *testutil.MockedSpannerInMemTestServer, client *spanner.Client, teardown func()) {
+func setupMockedTestServer(t testing.TB) (server *testutil.MockedSpannerInMemTestServer, client *spanner.Client, teardown func()) {
 	return setupMockedTestServerWithConfig(t, spanner.ClientConfig{})
 }
 
-func setupMockedTestServerWithConfig(t *testing.T, config spanner.ClientConfig) (server *testutil.MockedSpannerInMemTestServer, client *spanner.Client, teardown func()) {
+func setupMockedTestServerWithConfig(t testing.TB, config spanner.ClientConfig) (server *testutil.MockedSpannerInMemTestServer, client *spanner.Client, teardown func()) {
 	return setupMockedTestServerWithConfigAndClientOptions(t, config, []option.ClientOption{})
 }
 
-func setupMockedTestServerWithConfigAndClientOptions(t *testing.T, config spanner.ClientConfig, clientOptions []option.ClientOption) (server *testutil.MockedSpannerInMemTestServer, client *spanner.Client, teardown func()) {
+func setupMockedTestServerWithConfigAndClientOptions(t testing.TB, config spanner.ClientConfig, clientOptions []option.ClientOption) (server *testutil.MockedSpannerInMemTestServer, client *spanner.Client, teardown func()) {
 	server, opts, serverTeardown := testutil.NewMockedSpannerInMemTestServer(t)
 	opts = append(opts, clientOptions...)
 	ctx := context.Background()
@@ -0,0 +1,360 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"cloud.google.com/go/spanner"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	// NumericPrecision is the maximum number of digits of a GoogleSQL NUMERIC
+	// value.
+	NumericPrecision = 38
+	// NumericScale is the maximum number of digits after the decimal point of
+	// a GoogleSQL NUMERIC value.
+	NumericScale = 9
+)
+
+// ErrNumericOutOfRange is returned when a value is written to a NUMERIC
+// column that does not fit in the precision and scale of the column.
+var ErrNumericOutOfRange = errors.New("numeric value out of range")
+
+// Numeric is a NUMERIC column for GoogleSQL and a numeric column for
+// PostgreSQL. Numeric embeds big.Rat, so all big.Rat methods can be used on
+// a Numeric value. Values are sent to Spanner as exact decimal values, and
+// values that do not fit in a GoogleSQL NUMERIC column (38 digits, of which
+// 9 after the decimal point) are rejected before they are sent to Spanner.
+//
+// NULL values are scanned as zero. Use *Numeric for a column that can be
+// NULL.
+type Numeric struct {
+	big.Rat
+}
+
+// NewNumeric returns a Numeric with the value of r.
+func NewNumeric(r *big.Rat) Numeric {
+	var n Numeric
+	n.Set(r)
+	return n
+}
+
+// ParseNumeric parses a decimal string, such as "3.14", into a Numeric.
+func ParseNumeric(s string) (Numeric, error) {
+	var n Numeric
+	if _, ok := n.SetString(s); !ok {
+		return Numeric{}, fmt.Errorf("invalid numeric value: %q", s)
+	}
+	return n, nil
+}
+
+// String returns the value as a decimal string. Values that cannot be
+// represented exactly as a decimal number are rounded to 9 decimal places.
+// Such values cannot be written to the database, and return
+// ErrNumericOutOfRange.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (n Numeric) String() string {
+	return decimalString(&n.Rat)
+}
+
+// Value implements driver.Valuer. The value is sent to Spanner as a GoogleSQL
+// NUMERIC value. gorm uses GormValue, which also supports PostgreSQL.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (n Numeric) Value() (driver.Value, error) {
+	if err := checkNumeric(&n.Rat, NumericPrecision, NumericScale); err != nil {
+		return nil, err
+	}
+	return spanner.NullNumeric{Numeric: n.Rat, Valid: true}, nil
+}
+
+// GormValue returns the value as a NUMERIC value for GoogleSQL and as a
+// numeric value for PostgreSQL.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (n Numeric) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	if isPostgreSQL(db) {
+		value, err := exactDecimalString(&n.Rat)
+		if err != nil && db.Error == nil {
+			_ = db.AddError(err)
+		}
+		return clause.Expr{SQL: "?", Vars: []interface{}{spanner.PGNumeric{Numeric: value, Valid: true}}}
+	}
+	if err := checkNumeric(&n.Rat, NumericPrecision, NumericScale); err != nil && db.Error == nil {
+		_ = db.AddError(err)
+	}
+	return clause.Expr{SQL: "?", Vars: []interface{}{spanner.NullNumeric{Numeric: n.Rat, Valid: true}}}
+}
+
+// Scan implements sql.Scanner.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (n *Numeric) Scan(v any) error {
+	switch val := v.(type) {
+	case nil:
+		n.SetInt64(0)
+	case big.Rat:
+		n.Set(&val)
+	case *big.Rat:
+		n.Set(val)
+	case spanner.NullNumeric:
+		if !val.Valid {
+			n.SetInt64(0)
+			return nil
+		}
+		n.Set(&val.Numeric)
+	case spanner.PGNumeric:
+		if !val.Valid {
+			n.SetInt64(0)
+			return nil
+		}
+		return n.Scan(val.Numeric)
+	case string:
+		if _, ok := n.SetString(val); !ok {
+			return fmt.Errorf("invalid numeric value: %q", val)
+		}
+	case []byte:
+		return n.Scan(string(val))
+	case int64:
+		n.SetInt64(val)
+	case float64:
+		n.SetFloat64(val)
+	default:
+		return fmt.Errorf("cannot scan %T into %T", v, n)
+	}
+	return nil
+}
+
+// MarshalJSON marshals the value as a JSON string to prevent loss of
+// precision.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (n Numeric) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.String())
+}
+
+// UnmarshalJSON unmarshals a JSON string or number.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (n *Numeric) UnmarshalJSON(b []byte) error {
+	return n.Scan(strings.Trim(string(b), `"`))
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (n Numeric) GormDataType() string {
+	return "numeric"
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (n Numeric) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	if isPostgreSQL(db) {
+		return "numeric"
+	}
+	return "NUMERIC"
+}
+
+// isNumericField returns true if the given field is a NUMERIC column, either
+// because the type of the field is Numeric, or because the field has a
+// `gorm:"type:numeric"` tag.
+func isNumericField(field *schema.Field) bool {
+	return strings.EqualFold(string(field.DataType), "numeric")
+}
+
+// ValidateNumerics returns ErrNumericOutOfRange for create and update
+// operations that would write a value to a NUMERIC column that does not fit
+// in the column. The precision and scale of a column can be set with the
+// `precision` and `scale` tags. The default for GoogleSQL is 38 digits, of
+// which 9 after the decimal point. PostgreSQL columns are only validated if
+// the precision is set.
+//
+// Values of NUMERIC columns can be Numeric, big.Rat, float64, a decimal
+// string or any type that implements driver.Valuer and returns one of
+// these, such as shopspring/decimal.Decimal:
+//
+//	type Product struct {
+//	  ID    int64
+//	  Price decimal.Decimal `gorm:"type:numeric;precision:10;scale:2"`
+//	}
+func ValidateNumerics(db *gorm.DB) {
+	if db.Statement.Schema == nil {
+		return
+	}
+	var fields []*schema.Field
+	for _, field := range db.Statement.Schema.Fields {
+		if isNumericField(field) {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return
+	}
+	postgreSQL := isPostgreSQL(db)
+	validate := func(field *schema.Field, value interface{}) {
+		precision, scale := NumericPrecision, NumericScale
+		if field.Precision > 0 {
+			precision, scale = field.Precision, field.Scale
+		} else if postgreSQL {
+			return
+		}
+		r, err := numericRat(value)
+		if err == nil && r != nil {
+			err = checkNumeric(r, precision, scale)
+		}
+		if err != nil {
+			_ = db.AddError(fmt.Errorf("%s: %w", field.Name, err))
+		}
+	}
+	validateStruct := func(rv reflect.Value) {
+		for _, field := range fields {
+			if value, zero := field.ValueOf(db.Statement.Context, rv); !zero {
+				validate(field, value)
+			}
+		}
+	}
+	var walk func(rv reflect.Value)
+	walk = func(rv reflect.Value) {
+		rv = reflect.Indirect(rv)
+		switch rv.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < rv.Len(); i++ {
+				walk(rv.Index(i))
+			}
+		case reflect.Struct:
+			if rv.Type() == db.Statement.Schema.ModelType {
+				validateStruct(rv)
+			}
+		case reflect.Map:
+			iter := rv.MapRange()
+			for iter.Next() {
+				name, ok := iter.Key().Interface().(string)
+				if !ok {
+					continue
+				}
+				if field := db.Statement.Schema.LookUpField(name); field != nil && isNumericField(field) {
+					validate(field, iter.Value().Interface())
+				}
+			}
+		}
+	}
+	walk(reflect.ValueOf(db.Statement.Dest))
+}
+
+func registerValidateNumerics(db *gorm.DB) error {
+	if err := db.Callback().Create().
+		Before("gorm:create").
+		Register("gorm:spanner:validate_numerics", ValidateNumerics); err != nil {
+		return err
+	}
+	return db.Callback().Update().
+		Before("gorm:update").
+		Register("gorm:spanner:validate_numerics", ValidateNumerics)
+}
+
+// numericRat returns the value of a NUMERIC column as a big.Rat, or nil if
+// the value is NULL or of an unknown type.
+func numericRat(value interface{}) (*big.Rat, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case Numeric:
+		return &v.Rat, nil
+	case *Numeric:
+		if v == nil {
+			return nil, nil
+		}
+		return &v.Rat, nil
+	case big.Rat:
+		return &v, nil
+	case *big.Rat:
+		return v, nil
+	case spanner.NullNumeric:
+		if !v.Valid {
+			return nil, nil
+		}
+		return &v.Numeric, nil
+	case spanner.PGNumeric:
+		if !v.Valid {
+			return nil, nil
+		}
+		return numericRat(v.Numeric)
+	case string:
+		r, ok := new(big.Rat).SetString(v)
+		if !ok {
+			return nil, fmt.Errorf("invalid numeric value: %q", v)
+		}
+		return r, nil
+	case float64:
+		r := new(big.Rat)
+		if r.SetFloat64(v) == nil {
+			return nil, fmt.Errorf("invalid numeric value: %v", v)
+		}
+		return r, nil
+	case driver.Valuer:
+		val, err := v.Value()
+		if err != nil {
+			return nil, err
+		}
+		return numericRat(val)
+	}
+	return nil, nil
+}
+
+// checkNumeric returns ErrNumericOutOfRange if r cannot be represented
+// exactly with the given number of digits, of which scale digits after the
+// decimal point.
+func checkNumeric(r *big.Rat, precision, scale int) error {
+	pow := func(n int) *big.Rat {
+		return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil))
+	}
+	if !new(big.Rat).Mul(r, pow(scale)).IsInt() {
+		return fmt.Errorf("%w: %s has more than %d digits after the decimal point", ErrNumericOutOfRange, decimalString(r), scale)
+	}
+	if new(big.Rat).Abs(r).Cmp(pow(precision-scale)) >= 0 {
+		return fmt.Errorf("%w: %s has more than %d digits before the decimal point", ErrNumericOutOfRange, decimalString(r), precision-scale)
+	}
+	return nil
+}
+
+// decimalString returns r as an exact decimal string, or rounded to 9
+// decimal places if r cannot be represented exactly as a decimal number.
+func decimalString(r *big.Rat) string {
+	if prec, exact := r.FloatPrec(); exact {
+		return r.FloatString(prec)
+	}
+	return r.FloatString(NumericScale)
+}
+
+// exactDecimalString returns r as an exact decimal string. It returns
+// ErrNumericOutOfRange if r cannot be represented exactly as a decimal
+// number, such as 1/3.
+func exactDecimalString(r *big.Rat) (string, error) {
+	prec, exact := r.FloatPrec()
+	if !exact {
+		return "", fmt.Errorf("%w: %s cannot be represented exactly as a decimal number", ErrNumericOutOfRange, r.RatString())
+	}
+	return r.FloatString(prec), nil
+}
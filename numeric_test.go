@@ -0,0 +1,202 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/googleapis/go-sql-spanner/testutil"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type product struct {
+	ID       int64 `gorm:"primaryKey;autoIncrement:false"`
+	Price    Numeric
+	Discount decimal.Decimal `gorm:"type:numeric;precision:4;scale:2"`
+	Tax      *Numeric
+}
+
+const insertProductSql = "INSERT INTO `products` (`id`,`price`,`discount`,`tax`) VALUES (@p1,@p2,@p3,@p4)"
+
+func TestNumericDataType(t *testing.T) {
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	statements, err := db.Migrator().(SpannerMigrator).AutoMigrateDryRun(&product{})
+	if err != nil {
+		t.Fatalf("failed to run AutoMigrateDryRun: %v", err)
+	}
+	if g, w := len(statements), 1; g != w {
+		t.Fatalf("num statements mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := statements[0].SQL, "CREATE TABLE `products` (`id` INT64,`price` NUMERIC,`discount` NUMERIC,`tax` NUMERIC) PRIMARY KEY (`id`)"; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	postgreSQL := &gorm.DB{Config: &gorm.Config{Dialector: postgreSQLDialector{}}}
+	if g, w := (Numeric{}).GormDBDataType(postgreSQL, nil), "numeric"; g != w {
+		t.Fatalf("PostgreSQL data type mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestCheckNumeric(t *testing.T) {
+	for _, test := range []struct {
+		value     string
+		precision int
+		scale     int
+		valid     bool
+	}{
+		{value: "0", precision: 38, scale: 9, valid: true},
+		{value: "3.14", precision: 38, scale: 9, valid: true},
+		{value: "-0.000000001", precision: 38, scale: 9, valid: true},
+		{value: "0.0000000001", precision: 38, scale: 9},
+		{value: "1/3", precision: 38, scale: 9},
+		{value: "99999999999999999999999999999.999999999", precision: 38, scale: 9, valid: true},
+		{value: "-99999999999999999999999999999.999999999", precision: 38, scale: 9, valid: true},
+		{value: "100000000000000000000000000000", precision: 38, scale: 9},
+		{value: "99.99", precision: 4, scale: 2, valid: true},
+		{value: "100", precision: 4, scale: 2},
+		{value: "1.001", precision: 4, scale: 2},
+	} {
+		r, _ := new(big.Rat).SetString(test.value)
+		err := checkNumeric(r, test.precision, test.scale)
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.value, err)
+		}
+		if !test.valid && !errors.Is(err, ErrNumericOutOfRange) {
+			t.Errorf("%s: error mismatch\n Got: %v\nWant: %v", test.value, err, ErrNumericOutOfRange)
+		}
+	}
+}
+
+func TestNumericRoundTrip(t *testing.T) {
+	// decode_numeric_to_string is required for decimal.Decimal, which cannot
+	// scan big.Rat values.
+	db, server, teardown := setupTestGormConnectionWithParams(t, "decode_numeric_to_string=true")
+	defer teardown()
+
+	_ = server.TestSpanner.PutStatementResult(insertProductSql, &testutil.StatementResult{
+		Type:        testutil.StatementResultUpdateCount,
+		UpdateCount: 1,
+	})
+	price, err := ParseNumeric("12345678901234567890.123456789")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&product{ID: 1, Price: price, Discount: decimal.RequireFromString("0.25")}).Error; err != nil {
+		t.Fatalf("failed to create product: %v", err)
+	}
+	req := getLastSqlRequest(server)
+	if g, w := req.ParamTypes["p2"].GetCode(), spannerpb.TypeCode_NUMERIC; g != w {
+		t.Fatalf("param type mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.Params.Fields["p2"].GetStringValue(), "12345678901234567890.123456789"; g != w {
+		t.Fatalf("param value mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.Params.Fields["p3"].GetStringValue(), "0.25"; g != w {
+		t.Fatalf("param value mismatch\n Got: %v\nWant: %v", g, w)
+	}
+
+	_ = server.TestSpanner.PutStatementResult("SELECT * FROM `products` ORDER BY `products`.`id` LIMIT @p1",
+		&testutil.StatementResult{
+			Type: testutil.StatementResultResultSet,
+			ResultSet: &spannerpb.ResultSet{
+				Metadata: &spannerpb.ResultSetMetadata{
+					RowType: &spannerpb.StructType{
+						Fields: []*spannerpb.StructType_Field{
+							{Name: "id", Type: &spannerpb.Type{Code: spannerpb.TypeCode_INT64}},
+							{Name: "price", Type: &spannerpb.Type{Code: spannerpb.TypeCode_NUMERIC}},
+							{Name: "discount", Type: &spannerpb.Type{Code: spannerpb.TypeCode_NUMERIC}},
+							{Name: "tax", Type: &spannerpb.Type{Code: spannerpb.TypeCode_NUMERIC}},
+						},
+					},
+				},
+				Rows: []*structpb.ListValue{
+					{Values: []*structpb.Value{
+						{Kind: &structpb.Value_StringValue{StringValue: "1"}},
+						req.Params.Fields["p2"],
+						{Kind: &structpb.Value_StringValue{StringValue: "0.25"}},
+						{Kind: &structpb.Value_NullValue{}},
+					}},
+				},
+			},
+		})
+	var result product
+	if err := db.First(&result).Error; err != nil {
+		t.Fatalf("failed to fetch product: %v", err)
+	}
+	if g, w := result.Price.String(), price.String(); g != w {
+		t.Errorf("price mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := result.Discount, decimal.RequireFromString("0.25"); !g.Equal(w) {
+		t.Errorf("discount mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if result.Tax != nil {
+		t.Errorf("tax mismatch\n Got: %v\nWant: nil", result.Tax)
+	}
+}
+
+func TestValidateNumerics(t *testing.T) {
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	for _, p := range []product{
+		{ID: 1, Price: NewNumeric(big.NewRat(1, 3))},
+		{ID: 1, Discount: decimal.NewFromInt(100)},
+		{ID: 1, Discount: decimal.RequireFromString("1.001")},
+	} {
+		err := db.Create(&p).Error
+		if !errors.Is(err, ErrNumericOutOfRange) {
+			t.Errorf("%v: error mismatch\n Got: %v\nWant: %v", p, err, ErrNumericOutOfRange)
+		}
+	}
+	err := db.Model(&product{ID: 1}).Updates(map[string]interface{}{"discount": decimal.RequireFromString("123.4")}).Error
+	if !errors.Is(err, ErrNumericOutOfRange) {
+		t.Errorf("update error mismatch\n Got: %v\nWant: %v", err, ErrNumericOutOfRange)
+	}
+	for _, req := range server.TestSpanner.DrainRequestsFromServer() {
+		if sqlReq, ok := req.(*spannerpb.ExecuteSqlRequest); ok && sqlReq.Sql != "SELECT 1" {
+			t.Errorf("unexpected request: %v", sqlReq.Sql)
+		}
+	}
+}
+
+func TestNumericJSON(t *testing.T) {
+	n, err := ParseNumeric("-1.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := string(b), `"-1.5"`; g != w {
+		t.Fatalf("json mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	var res Numeric
+	for _, s := range []string{`"-1.5"`, `-1.5`} {
+		if err := json.Unmarshal([]byte(s), &res); err != nil {
+			t.Fatal(err)
+		}
+		if res.Cmp(&n.Rat) != 0 {
+			t.Fatalf("%s: value mismatch\n Got: %v\nWant: %v", s, res, n)
+		}
+	}
+}
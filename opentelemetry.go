@@ -0,0 +1,300 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	spannerdriver "github.com/googleapis/go-sql-spanner"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const openTelemetryPluginName = "gorm:spanner:opentelemetry"
+
+// openTelemetryScope is the instrumentation scope of the spans and metrics
+// that are created by OpenTelemetryPlugin.
+const openTelemetryScope = "github.com/googleapis/go-gorm-spanner"
+
+const operationSpanKey = "gorm:spanner:operation_span"
+
+// OpenTelemetryConfig contains the configuration of an OpenTelemetryPlugin.
+type OpenTelemetryConfig struct {
+	// TracerProvider is used to create spans. The global TracerProvider is
+	// used if this is not set.
+	TracerProvider trace.TracerProvider
+
+	// MeterProvider is used to create metrics. The global MeterProvider is
+	// used if this is not set.
+	MeterProvider metric.MeterProvider
+
+	// DisableQueryText excludes the SQL text of statements from spans.
+	DisableQueryText bool
+}
+
+// OpenTelemetryPlugin is a gorm plugin that creates an OpenTelemetry span for
+// each gorm operation, and records the latency of the operations. The spans
+// are created before the statement is sent to Spanner, which means that the
+// spans that are created by the Spanner client are children of the gorm
+// span.
+//
+// RunTransaction creates a span for the transaction and records the number of
+// times that the transaction was retried if the plugin is registered.
+//
+// Example:
+//
+//	db, err := gorm.Open(spannergorm.New(spannergorm.Config{DSN: dsn}), &gorm.Config{})
+//	if err != nil {
+//		return err
+//	}
+//	if err := db.Use(spannergorm.NewOpenTelemetryPlugin(spannergorm.OpenTelemetryConfig{})); err != nil {
+//		return err
+//	}
+type OpenTelemetryPlugin struct {
+	config             OpenTelemetryConfig
+	tracer             trace.Tracer
+	operationDuration  metric.Float64Histogram
+	transactionRetries metric.Int64Histogram
+}
+
+// NewOpenTelemetryPlugin returns a new OpenTelemetryPlugin with the given
+// configuration. Register the plugin with db.Use.
+func NewOpenTelemetryPlugin(config OpenTelemetryConfig) *OpenTelemetryPlugin {
+	if config.TracerProvider == nil {
+		config.TracerProvider = otel.GetTracerProvider()
+	}
+	if config.MeterProvider == nil {
+		config.MeterProvider = otel.GetMeterProvider()
+	}
+	return &OpenTelemetryPlugin{config: config}
+}
+
+func (p *OpenTelemetryPlugin) Name() string {
+	return openTelemetryPluginName
+}
+
+func (p *OpenTelemetryPlugin) Initialize(db *gorm.DB) (err error) {
+	p.tracer = p.config.TracerProvider.Tracer(openTelemetryScope)
+	meter := p.config.MeterProvider.Meter(openTelemetryScope)
+	if p.operationDuration, err = meter.Float64Histogram(
+		"spanner.gorm.operation.duration",
+		metric.WithDescription("Duration of gorm operations on Spanner"),
+		metric.WithUnit("s"),
+	); err != nil {
+		return err
+	}
+	if p.transactionRetries, err = meter.Int64Histogram(
+		"spanner.gorm.transaction.retries",
+		metric.WithDescription("Number of times that a transaction was retried after it was aborted by Spanner"),
+		metric.WithUnit("{retry}"),
+	); err != nil {
+		return err
+	}
+
+	callback := db.Callback()
+	for _, c := range []struct {
+		operation     string
+		before, after callbackRegisterer
+	}{
+		{"create", callback.Create().Before("*"), callback.Create().After("*")},
+		{"query", callback.Query().Before("*"), callback.Query().After("*")},
+		{"update", callback.Update().Before("*"), callback.Update().After("*")},
+		{"delete", callback.Delete().Before("*"), callback.Delete().After("*")},
+		{"raw", callback.Raw().Before("*"), callback.Raw().After("*")},
+		{"row", callback.Row().Before("*"), callback.Row().After("*")},
+	} {
+		if err := c.before.Register("gorm:spanner:start_"+c.operation+"_span", p.startSpan(c.operation)); err != nil {
+			return err
+		}
+		if err := c.after.Register("gorm:spanner:end_"+c.operation+"_span", p.endSpan); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// callbackRegisterer registers a gorm callback at a position that has
+// already been selected.
+type callbackRegisterer interface {
+	Register(name string, fn func(*gorm.DB)) error
+}
+
+// operationSpan is the span of a gorm operation.
+type operationSpan struct {
+	operation string
+	parent    context.Context
+	span      trace.Span
+	start     time.Time
+}
+
+func (p *OpenTelemetryPlugin) startSpan(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx, span := p.tracer.Start(db.Statement.Context, "gorm."+operation, trace.WithSpanKind(trace.SpanKindClient))
+		db.Statement.Settings.Store(operationSpanKey, &operationSpan{
+			operation: operation,
+			parent:    db.Statement.Context,
+			span:      span,
+			start:     time.Now(),
+		})
+		db.Statement.Context = ctx
+	}
+}
+
+func (p *OpenTelemetryPlugin) endSpan(db *gorm.DB) {
+	value, ok := db.Statement.Settings.LoadAndDelete(operationSpanKey)
+	if !ok {
+		return
+	}
+	s := value.(*operationSpan)
+	// Restore the parent span instead of the parent context, so values that
+	// were added to the context by other callbacks are preserved.
+	db.Statement.Context = trace.ContextWithSpan(db.Statement.Context, trace.SpanFromContext(s.parent))
+
+	attributes := append(p.attributes(db), attribute.String("db.operation.name", s.operation))
+	if db.Statement.Table != "" {
+		attributes = append(attributes, attribute.String("db.collection.name", db.Statement.Table))
+	}
+	spanAttributes := attributes
+	if db.RowsAffected >= 0 {
+		spanAttributes = append(spanAttributes, attribute.Int64("db.rows_affected", db.RowsAffected))
+	}
+	if !p.config.DisableQueryText && db.Statement.SQL.Len() > 0 {
+		spanAttributes = append(spanAttributes, attribute.String("db.query.text", db.Statement.SQL.String()))
+	}
+	if tag := requestTag(db.Statement.Vars); tag != "" {
+		spanAttributes = append(spanAttributes, attribute.String("db.spanner.request_tag", tag))
+	}
+	s.span.SetAttributes(spanAttributes...)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		s.span.RecordError(db.Error)
+		s.span.SetStatus(codes.Error, db.Error.Error())
+	}
+	s.span.End()
+	p.operationDuration.Record(s.parent, time.Since(s.start).Seconds(), metric.WithAttributes(attributes...))
+}
+
+// runTransaction runs a transaction with a span for the transaction, and
+// records the number of retries of the transaction.
+func (p *OpenTelemetryPlugin) runTransaction(ctx context.Context, db *gorm.DB, fc func(tx *gorm.DB) error, opts ...*sql.TxOptions) error {
+	spanCtx, span := p.tracer.Start(db.Statement.Context, "gorm.transaction", trace.WithSpanKind(trace.SpanKindClient))
+	var retries int64
+	err := runTransaction(ctx, db.WithContext(spanCtx), fc, func(err error) {
+		retries++
+		span.AddEvent("Transaction aborted", trace.WithAttributes(
+			attribute.Int64("retry", retries),
+			attribute.String("exception.message", err.Error()),
+		))
+	}, opts...)
+
+	attributes := p.attributes(db)
+	span.SetAttributes(append(attributes, attribute.Int64("db.spanner.transaction_retries", retries))...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+	p.transactionRetries.Record(db.Statement.Context, retries, metric.WithAttributes(attributes...))
+	return err
+}
+
+// attributes returns the attributes that are added to all spans and metrics.
+func (p *OpenTelemetryPlugin) attributes(db *gorm.DB) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{attribute.String("db.system.name", "gcp.spanner")}
+	if isPostgreSQL(db) {
+		attributes = append(attributes, attribute.String("db.spanner.dialect", "POSTGRESQL"))
+	} else {
+		attributes = append(attributes, attribute.String("db.spanner.dialect", "GOOGLE_STANDARD_SQL"))
+	}
+	if name := databaseName(db); name != "" {
+		attributes = append(attributes, attribute.String("db.namespace", name))
+	}
+	return attributes
+}
+
+// openTelemetryPlugin returns the OpenTelemetryPlugin that is registered for
+// the given database, or nil if there is none.
+func openTelemetryPlugin(db *gorm.DB) *OpenTelemetryPlugin {
+	if db == nil || db.Config == nil {
+		return nil
+	}
+	plugin, _ := db.Plugins[openTelemetryPluginName].(*OpenTelemetryPlugin)
+	return plugin
+}
+
+// requestTag returns the request tag in the query options of a statement. The
+// Spanner driver uses the last options if a statement has multiple options.
+func requestTag(vars []interface{}) string {
+	tag := ""
+	for _, v := range vars {
+		switch options := v.(type) {
+		case spannerdriver.ExecOptions:
+			tag = options.QueryOptions.RequestTag
+		case *spannerdriver.ExecOptions:
+			tag = options.QueryOptions.RequestTag
+		}
+	}
+	return tag
+}
+
+// databaseNamer is implemented by dialectors that know the name of the
+// database that they connect to.
+type databaseNamer interface {
+	DatabaseName() string
+}
+
+// databaseName returns the fully qualified name of the database that the
+// dialector of the given gorm database connects to, or an empty string if the
+// name is not known.
+func databaseName(db *gorm.DB) string {
+	switch dialector := db.Dialector.(type) {
+	case *Dialector:
+		return dialector.DatabaseName()
+	case Dialector:
+		return dialector.DatabaseName()
+	case databaseNamer:
+		return dialector.DatabaseName()
+	}
+	return ""
+}
+
+// DatabaseName returns the fully qualified name of the database in the DSN of
+// the dialector, or an empty string if the dialector does not use a DSN.
+func (dialector Dialector) DatabaseName() string {
+	if dialector.Config == nil {
+		return ""
+	}
+	return DatabaseNameFromDSN(dialector.DSN)
+}
+
+// DatabaseNameFromDSN returns the fully qualified name of the database in the
+// given DSN, or an empty string if the DSN is not valid.
+func DatabaseNameFromDSN(dsn string) string {
+	if dsn == "" {
+		return ""
+	}
+	config, err := spannerdriver.ExtractConnectorConfig(dsn)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("projects/%s/instances/%s/databases/%s", config.Project, config.Instance, config.Database)
+}
@@ -0,0 +1,228 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	spannerdriver "github.com/googleapis/go-sql-spanner"
+	"github.com/googleapis/go-sql-spanner/testutil"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type otelSinger struct {
+	ID   int64 `gorm:"primaryKey;autoIncrement:false"`
+	Name string
+}
+
+func setupOpenTelemetryPlugin(t *testing.T, db *gorm.DB) (*tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	exporter := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+	if err := db.Use(NewOpenTelemetryPlugin(OpenTelemetryConfig{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})); err != nil {
+		t.Fatal(err)
+	}
+	return exporter, reader
+}
+
+func spanAttribute(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestOpenTelemetryPlugin(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+	exporter, reader := setupOpenTelemetryPlugin(t, db)
+
+	const insertSql = "INSERT INTO `otel_singers` (`id`,`name`) VALUES (@p1,@p2)"
+	_ = server.TestSpanner.PutStatementResult(insertSql, &testutil.StatementResult{
+		Type:        testutil.StatementResultUpdateCount,
+		UpdateCount: 1,
+	})
+	if err := db.Create(&otelSinger{ID: 1, Name: "Alice"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	const query = "SELECT * FROM otel_singers WHERE id = @p1"
+	_ = server.TestSpanner.PutStatementResult(query, &testutil.StatementResult{
+		Type:      testutil.StatementResultResultSet,
+		ResultSet: &spannerpb.ResultSet{Metadata: &spannerpb.ResultSetMetadata{RowType: &spannerpb.StructType{}}},
+	})
+	var singer otelSinger
+	if err := db.Raw("SELECT * FROM otel_singers WHERE id = ?", 1, spannerdriver.ExecOptions{QueryOptions: spanner.QueryOptions{RequestTag: "find_singer"}}).Scan(&singer).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	if g, w := len(spans), 2; g != w {
+		t.Fatalf("span count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	for i, want := range []struct {
+		name      string
+		operation string
+		table     attribute.Value
+		sql       string
+		rows      attribute.Value
+		tag       string
+	}{
+		{name: "gorm.create", operation: "create", table: attribute.StringValue("otel_singers"), sql: "INSERT INTO `otel_singers` (`id`,`name`) VALUES (?,?)", rows: attribute.Int64Value(1)},
+		{name: "gorm.row", operation: "row", sql: "SELECT * FROM otel_singers WHERE id = ?", tag: "find_singer"},
+	} {
+		span := spans[i]
+		if g, w := span.Name, want.name; g != w {
+			t.Errorf("%d: span name mismatch\n Got: %v\nWant: %v", i, g, w)
+		}
+		for _, attr := range []struct {
+			key  attribute.Key
+			want attribute.Value
+		}{
+			{key: "db.system.name", want: attribute.StringValue("gcp.spanner")},
+			{key: "db.spanner.dialect", want: attribute.StringValue("GOOGLE_STANDARD_SQL")},
+			{key: "db.namespace", want: attribute.StringValue("projects/p/instances/i/databases/d")},
+			{key: "db.operation.name", want: attribute.StringValue(want.operation)},
+			{key: "db.collection.name", want: want.table},
+			{key: "db.query.text", want: attribute.StringValue(want.sql)},
+			{key: "db.rows_affected", want: want.rows},
+		} {
+			if g, w := spanAttribute(span, attr.key), attr.want; g != w {
+				t.Errorf("%s: attribute %s mismatch\n Got: %v\nWant: %v", span.Name, attr.key, g.Emit(), w.Emit())
+			}
+		}
+		if g, w := spanAttribute(span, "db.spanner.request_tag").AsString(), want.tag; g != w {
+			t.Errorf("%s: request tag mismatch\n Got: %v\nWant: %v", span.Name, g, w)
+		}
+	}
+
+	var metrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &metrics); err != nil {
+		t.Fatal(err)
+	}
+	histogram := metrics.ScopeMetrics[0].Metrics[0]
+	if g, w := histogram.Name, "spanner.gorm.operation.duration"; g != w {
+		t.Fatalf("metric name mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := len(histogram.Data.(metricdata.Histogram[float64]).DataPoints), 2; g != w {
+		t.Fatalf("data point count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestOpenTelemetryPluginRunTransaction(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+	exporter, reader := setupOpenTelemetryPlugin(t, db)
+
+	const insertSql = "INSERT INTO `otel_singers` (`id`,`name`) VALUES (@p1,@p2)"
+	_ = server.TestSpanner.PutStatementResult(insertSql, &testutil.StatementResult{
+		Type:        testutil.StatementResultUpdateCount,
+		UpdateCount: 1,
+	})
+	server.TestSpanner.PutExecutionTime(testutil.MethodCommitTransaction, testutil.SimulatedExecutionTime{
+		Errors: []error{status.Error(codes.Aborted, "Aborted")},
+	})
+	if err := RunTransaction(context.Background(), db, func(tx *gorm.DB) error {
+		return tx.Create(&otelSinger{ID: 1, Name: "Alice"}).Error
+	}, &sql.TxOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	var transaction tracetest.SpanStub
+	var creates int
+	for _, span := range spans {
+		switch span.Name {
+		case "gorm.transaction":
+			transaction = span
+		case "gorm.create":
+			creates++
+		}
+	}
+	if g, w := creates, 2; g != w {
+		t.Fatalf("create span count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := spanAttribute(transaction, "db.spanner.transaction_retries").AsInt64(), int64(1); g != w {
+		t.Fatalf("retries mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	// The Spanner client also adds events to the transaction span.
+	var aborted int
+	for _, event := range transaction.Events {
+		if event.Name == "Transaction aborted" {
+			aborted++
+		}
+	}
+	if g, w := aborted, 1; g != w {
+		t.Fatalf("aborted event count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	for _, span := range spans {
+		if span.Name == "gorm.create" && span.Parent.SpanID() != transaction.SpanContext.SpanID() {
+			t.Fatalf("create span is not a child of the transaction span")
+		}
+	}
+
+	var metrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &metrics); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range metrics.ScopeMetrics[0].Metrics {
+		if m.Name != "spanner.gorm.transaction.retries" {
+			continue
+		}
+		points := m.Data.(metricdata.Histogram[int64]).DataPoints
+		if g, w := len(points), 1; g != w {
+			t.Fatalf("data point count mismatch\n Got: %v\nWant: %v", g, w)
+		}
+		if g, w := points[0].Sum, int64(1); g != w {
+			t.Fatalf("retries mismatch\n Got: %v\nWant: %v", g, w)
+		}
+		return
+	}
+	t.Fatal("missing transaction retries metric")
+}
+
+func TestDatabaseNameFromDSN(t *testing.T) {
+	for _, test := range []struct {
+		dsn  string
+		want string
+	}{
+		{dsn: "projects/p/instances/i/databases/d", want: "projects/p/instances/i/databases/d"},
+		{dsn: "localhost:9010/projects/p/instances/i/databases/d?autoConfigEmulator=true", want: "projects/p/instances/i/databases/d"},
+		{dsn: "", want: ""},
+		{dsn: "invalid", want: ""},
+	} {
+		if g, w := DatabaseNameFromDSN(test.dsn), test.want; g != w {
+			t.Errorf("%q: database name mismatch\n Got: %v\nWant: %v", test.dsn, g, w)
+		}
+	}
+}
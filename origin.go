@@ -0,0 +1,273 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/spanner"
+	spannerdriver "github.com/googleapis/go-sql-spanner"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OriginAnnotation determines how the origin of a statement is added to the
+// statement.
+type OriginAnnotation int
+
+const (
+	// AnnotateWithComment adds the origin as a sqlcommenter-style comment in
+	// front of the statement, for example
+	// /*caller='main.go%3A42',model='Singer',service='singers'*/ SELECT ...
+	// The comment is included in the text of the statement in the query
+	// statistics of Spanner. This is the default.
+	AnnotateWithComment OriginAnnotation = iota
+	// AnnotateWithRequestTag sends the origin as the request tag of the
+	// statement, for example caller=main.go:42,model=Singer,service=singers.
+	// Request tags are included in the query statistics of Spanner without
+	// changing the text of the statement. A request tag that is set with
+	// spannerdriver.ExecOptions for a statement replaces the origin.
+	AnnotateWithRequestTag
+)
+
+// RequestOriginConfig contains the configuration for annotating statements
+// with their origin.
+type RequestOriginConfig struct {
+	// Service is the name of the service that executes the statements.
+	Service string
+	// Annotation determines how the origin is added to the statements. The
+	// default is AnnotateWithComment.
+	Annotation OriginAnnotation
+	// DisableCaller removes the file and line number of the application code
+	// that executed the statement from the origin.
+	DisableCaller bool
+}
+
+// RequestOrigin is the origin of a statement.
+type RequestOrigin struct {
+	// Service is the name of the service that executed the statement.
+	Service string
+	// Route is the route or operation of the service that executed the
+	// statement, for example the path of an HTTP request.
+	Route string
+	// Caller is the file and line number of the application code that
+	// executed the statement.
+	Caller string
+	// Model is the name of the model of the statement.
+	Model string
+}
+
+type requestOriginKey struct{}
+
+// WithRequestOrigin returns a context that overrides the origin of all
+// statements that are executed with the context. Only the fields that have
+// been set in the given origin are overridden. Statements are only annotated
+// if RequestOrigin has been set in the configuration of the dialector, or if
+// UseRequestOrigin has been called for the database.
+//
+// Example:
+//
+//	ctx := spannergorm.WithRequestOrigin(r.Context(), spannergorm.RequestOrigin{Route: r.URL.Path})
+//	db.WithContext(ctx).Find(&singers)
+func WithRequestOrigin(ctx context.Context, origin RequestOrigin) context.Context {
+	return context.WithValue(ctx, requestOriginKey{}, origin)
+}
+
+// UseRequestOrigin registers callbacks that annotate all statements that are
+// generated by gorm with their origin. Statements that are executed with Exec
+// are not annotated, as these can also be DDL statements.
+//
+// This function can be used for both GoogleSQL-dialect and PostgreSQL-dialect databases.
+func UseRequestOrigin(db *gorm.DB, config RequestOriginConfig) error {
+	callback := db.Callback()
+	for _, c := range []struct {
+		operation string
+		clauses   []string
+		register  callbackRegisterer
+	}{
+		{"create", []string{"INSERT"}, callback.Create().Before("*")},
+		{"query", []string{"SELECT"}, callback.Query().Before("*")},
+		{"update", []string{"UPDATE"}, callback.Update().Before("*")},
+		// Soft deletes are executed as UPDATE statements.
+		{"delete", []string{"DELETE", "UPDATE"}, callback.Delete().Before("*")},
+		{"row", []string{"SELECT"}, callback.Row().Before("*")},
+	} {
+		if err := c.register.Register("gorm:spanner:"+c.operation+"_request_origin", annotateRequestOrigin(config, c.clauses)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func annotateRequestOrigin(config RequestOriginConfig, clauses []string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Error != nil {
+			return
+		}
+		origin := requestOrigin(db, config)
+		if config.Annotation == AnnotateWithRequestTag {
+			options := spannerdriver.ExecOptions{QueryOptions: spanner.QueryOptions{RequestTag: origin.tag()}}
+			// Statements that are built by gorm get the options as the first
+			// variable, so the options are not replaced by options that are
+			// passed in as variables of the statement.
+			if db.Statement.SQL.Len() == 0 {
+				db.Statement.Vars = append([]interface{}{options}, db.Statement.Vars...)
+			} else {
+				db.Statement.Vars = append(db.Statement.Vars, options)
+			}
+			return
+		}
+		comment := origin.comment()
+		if db.Statement.SQL.Len() > 0 {
+			sql := db.Statement.SQL.String()
+			db.Statement.SQL.Reset()
+			db.Statement.SQL.WriteString(comment + " " + sql)
+			return
+		}
+		for _, name := range clauses {
+			c := db.Statement.Clauses[name]
+			c.BeforeExpression = clause.Expr{SQL: comment}
+			db.Statement.Clauses[name] = c
+		}
+	}
+}
+
+// requestOrigin returns the origin of the statement of the given database.
+func requestOrigin(db *gorm.DB, config RequestOriginConfig) RequestOrigin {
+	origin := RequestOrigin{Service: config.Service}
+	if !config.DisableCaller {
+		origin.Caller = caller()
+	}
+	if db.Statement.Schema != nil {
+		origin.Model = db.Statement.Schema.Name
+	} else {
+		origin.Model = db.Statement.Table
+	}
+	if override, ok := db.Statement.Context.Value(requestOriginKey{}).(RequestOrigin); ok {
+		for _, f := range []struct{ dest, value *string }{
+			{&origin.Service, &override.Service},
+			{&origin.Route, &override.Route},
+			{&origin.Caller, &override.Caller},
+			{&origin.Model, &override.Model},
+		} {
+			if *f.value != "" {
+				*f.dest = *f.value
+			}
+		}
+	}
+	return origin
+}
+
+func (o RequestOrigin) values() map[string]string {
+	values := make(map[string]string, 4)
+	for key, value := range map[string]string{"service": o.Service, "route": o.Route, "caller": o.Caller, "model": o.Model} {
+		if value != "" {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// comment returns the origin as a comment in the sqlcommenter format. The
+// keys are sorted and the values are URL encoded, which also ensures that the
+// comment cannot contain the end of the comment.
+func (o RequestOrigin) comment() string {
+	values := o.values()
+	keys := sortedKeys(values)
+	for i, key := range keys {
+		keys[i] = key + "='" + strings.ReplaceAll(url.QueryEscape(values[key]), "+", "%20") + "'"
+	}
+	return "/*" + strings.Join(keys, ",") + "*/"
+}
+
+// tag returns the origin as a request tag.
+func (o RequestOrigin) tag() string {
+	values := o.values()
+	keys := sortedKeys(values)
+	for i, key := range keys {
+		keys[i] = key + "=" + values[key]
+	}
+	return strings.Join(keys, ",")
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// caller returns the directory, file and line number of the first frame on
+// the stack that is not part of gorm or of this library, for example
+// singers/handlers.go:42.
+func caller() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !internalFrame(frame) {
+			return filepath.Base(filepath.Dir(frame.File)) + "/" + filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+func internalFrame(frame runtime.Frame) bool {
+	if strings.HasSuffix(frame.File, "_test.go") {
+		return false
+	}
+	for _, prefix := range []string{"gorm.io/", "github.com/googleapis/go-gorm-spanner.", "github.com/googleapis/go-gorm-spanner/postgresql."} {
+		if strings.HasPrefix(frame.Function, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// leadingExecOptions returns the spannerdriver.ExecOptions that were added in
+// front of the variables of a statement before the statement was built.
+func leadingExecOptions(vars []interface{}) []interface{} {
+	if len(vars) > 0 {
+		switch vars[0].(type) {
+		case spannerdriver.ExecOptions, *spannerdriver.ExecOptions:
+			return vars[:1:1]
+		}
+	}
+	return nil
+}
+
+// withoutExecOptions returns the given variables without the
+// spannerdriver.ExecOptions, as these are not parameters of the statement.
+func withoutExecOptions(vars []interface{}) []interface{} {
+	result := make([]interface{}, 0, len(vars))
+	for _, v := range vars {
+		switch v.(type) {
+		case spannerdriver.ExecOptions, *spannerdriver.ExecOptions:
+			continue
+		}
+		result = append(result, v)
+	}
+	return result
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/googleapis/go-sql-spanner/testutil"
+	"gorm.io/gorm"
+)
+
+func TestRequestOriginComment(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+	if err := UseRequestOrigin(db, RequestOriginConfig{Service: "singers"}); err != nil {
+		t.Fatal(err)
+	}
+	dryRun := db.Session(&gorm.Session{DryRun: true})
+
+	var singers []planSinger
+	stmt := dryRun.Where("last_name = ?", "Allison").Find(&singers).Statement
+	want := regexp.MustCompile("^/\\*caller='[^']*origin_test.go%3A\\d+',model='planSinger',service='singers'\\*/ SELECT \\* FROM `plan_singers` WHERE last_name = \\?$")
+	if g := stmt.SQL.String(); !want.MatchString(g) {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, want)
+	}
+
+	ctx := WithRequestOrigin(context.Background(), RequestOrigin{Route: "/singers/{id}", Caller: "handlers.go:10"})
+	for _, test := range []struct {
+		stmt *gorm.Statement
+		want string
+	}{
+		{
+			stmt: dryRun.WithContext(ctx).Where("last_name = ?", "Allison").Find(&singers).Statement,
+			want: "/*caller='handlers.go%3A10',model='planSinger',route='%2Fsingers%2F%7Bid%7D',service='singers'*/ SELECT * FROM `plan_singers` WHERE last_name = ?",
+		},
+		{
+			stmt: dryRun.WithContext(ctx).Create(&planSinger{ID: 1, LastName: "Allison"}).Statement,
+			want: "/*caller='handlers.go%3A10',model='planSinger',route='%2Fsingers%2F%7Bid%7D',service='singers'*/ INSERT INTO `plan_singers` (`id`,`last_name`) VALUES (?,?)",
+		},
+		{
+			stmt: dryRun.WithContext(ctx).Model(&planSinger{ID: 1}).Update("last_name", "Allison").Statement,
+			want: "/*caller='handlers.go%3A10',model='planSinger',route='%2Fsingers%2F%7Bid%7D',service='singers'*/ UPDATE `plan_singers` SET `last_name`=? WHERE `id` = ?",
+		},
+		{
+			stmt: dryRun.WithContext(ctx).Delete(&planSinger{ID: 1}).Statement,
+			want: "/*caller='handlers.go%3A10',model='planSinger',route='%2Fsingers%2F%7Bid%7D',service='singers'*/ DELETE FROM `plan_singers` WHERE `plan_singers`.`id` = ?",
+		},
+		{
+			stmt: dryRun.WithContext(ctx).Raw("SELECT 1").Find(&singers).Statement,
+			want: "/*caller='handlers.go%3A10',model='planSinger',route='%2Fsingers%2F%7Bid%7D',service='singers'*/ SELECT 1",
+		},
+	} {
+		if g, w := test.stmt.SQL.String(), test.want; g != w {
+			t.Errorf("sql mismatch\n Got: %v\nWant: %v", g, w)
+		}
+	}
+}
+
+func TestRequestOriginRequestTag(t *testing.T) {
+	t.Parallel()
+
+	server, _, serverTeardown := setupMockedTestServer(t)
+	db, _, teardown := setupTestGormConnectionWithDialector(t, server, serverTeardown, New(Config{
+		DriverName: "spanner",
+		DSN:        fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true", server.Address),
+		RequestOrigin: &RequestOriginConfig{
+			Service:       "singers",
+			Annotation:    AnnotateWithRequestTag,
+			DisableCaller: true,
+		},
+	}))
+	defer teardown()
+
+	const query = "SELECT * FROM `plan_singers` WHERE last_name = @p1"
+	putQueryPlanResult(server, query)
+	var singers []planSinger
+	ctx := WithRequestOrigin(context.Background(), RequestOrigin{Route: "list"})
+	if err := db.WithContext(ctx).Where("last_name = ?", "Allison").Find(&singers).Error; err != nil {
+		t.Fatal(err)
+	}
+	req := getLastSqlRequest(server)
+	if g, w := req.Sql, query; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.RequestOptions.GetRequestTag(), "model=planSinger,route=list,service=singers"; g != w {
+		t.Fatalf("request tag mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := len(req.Params.GetFields()), 1; g != w {
+		t.Fatalf("param count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+
+	const insert = "INSERT INTO `plan_singers` (`id`,`last_name`) VALUES (@p1,@p2)"
+	_ = server.TestSpanner.PutStatementResult(insert, &testutil.StatementResult{
+		Type:        testutil.StatementResultUpdateCount,
+		UpdateCount: 1,
+	})
+	if err := db.Create(&planSinger{ID: 1, LastName: "Allison"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	req = getLastSqlRequest(server)
+	if g, w := req.RequestOptions.GetRequestTag(), "model=planSinger,service=singers"; g != w {
+		t.Fatalf("request tag mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.Params.GetFields()["p1"].GetStringValue(), "1"; g != w {
+		t.Fatalf("id param mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
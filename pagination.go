@@ -0,0 +1,281 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ErrInvalidPageToken is returned by Paginate for a page token that was not
+// returned by Paginate for the same model and columns.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// Cursor determines the page of rows that is returned by Paginate.
+type Cursor struct {
+	// Token is the page token that was returned by Paginate for the previous
+	// page. The first page is returned if Token is empty.
+	Token string
+	// Limit is the maximum number of rows in the page.
+	Limit int
+	// Columns are the columns that the rows are ordered by. The values of
+	// the columns must uniquely identify each row and may not be NULL. The
+	// default is the primary key of the model, including all columns of the
+	// composite primary key of an interleaved table.
+	Columns []string
+	// Index is the name of a secondary index that the query should use. The
+	// Columns should then be the key columns of the index, followed by the
+	// primary key columns of the table.
+	Index string
+	// Desc orders the rows in descending order.
+	Desc bool
+}
+
+// pageToken is the content of a page token.
+type pageToken struct {
+	Table   string            `json:"t"`
+	Columns []string          `json:"c"`
+	Values  []json.RawMessage `json:"v"`
+}
+
+// Paginate loads one page of rows into dest, and returns the token for the
+// next page. The returned token is empty if there are no more rows.
+//
+// Paginate uses keyset pagination instead of LIMIT and OFFSET. The rows are
+// ordered by the columns of the cursor, and each page starts after the last
+// row of the previous page. This means that Spanner does not need to scan
+// all the rows of the previous pages, and that rows that are inserted or
+// deleted while paginating do not cause rows to be skipped or returned
+// twice. The order is the order of the key values, which for bit-reversed
+// sequences is not the order in which the rows were inserted.
+//
+// The conditions of db are applied to all pages. db may not contain an
+// ORDER BY, LIMIT or OFFSET clause.
+//
+// Example:
+//
+//	cursor := spannergorm.Cursor{Limit: 100}
+//	for {
+//		var singers []Singer
+//		token, err := spannergorm.Paginate(db.Where("active"), &singers, cursor)
+//		if err != nil {
+//			return err
+//		}
+//		// Process singers
+//		if token == "" {
+//			break
+//		}
+//		cursor.Token = token
+//	}
+//
+// This function can be used for both GoogleSQL-dialect and PostgreSQL-dialect databases.
+func Paginate(db *gorm.DB, dest interface{}, cursor Cursor) (string, error) {
+	if cursor.Limit <= 0 {
+		return "", fmt.Errorf("cursor limit must be positive, got %d", cursor.Limit)
+	}
+	for _, name := range []string{"ORDER BY", "LIMIT"} {
+		if _, ok := db.Statement.Clauses[name]; ok {
+			return "", fmt.Errorf("Paginate does not support queries with %s", name)
+		}
+	}
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(dest); err != nil {
+		return "", err
+	}
+	fields, err := cursorFields(stmt.Schema, cursor.Columns)
+	if err != nil {
+		return "", err
+	}
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = field.DBName
+	}
+
+	// Add the clauses to a copy of the statement, so the query of the caller
+	// can be used again for the next page.
+	tx := db.Session(&gorm.Session{}).Limit(cursor.Limit)
+	if cursor.Index != "" {
+		tx = tx.Clauses(ForceIndex(cursor.Index))
+	}
+	for _, field := range fields {
+		tx = tx.Order(clause.OrderByColumn{
+			Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName},
+			Desc:   cursor.Desc,
+		})
+	}
+	if cursor.Token != "" {
+		values, err := decodePageToken(cursor.Token, stmt.Schema.Table, columns, fields)
+		if err != nil {
+			return "", err
+		}
+		tx = tx.Where(keysetCondition(fields, values, cursor.Desc))
+	}
+	if err := tx.Find(dest).Error; err != nil {
+		return "", err
+	}
+
+	rows := reflect.Indirect(reflect.ValueOf(dest))
+	if rows.Kind() != reflect.Slice || rows.Len() < cursor.Limit {
+		return "", nil
+	}
+	last := reflect.Indirect(rows.Index(rows.Len() - 1))
+	token := pageToken{Table: stmt.Schema.Table, Columns: columns, Values: make([]json.RawMessage, len(fields))}
+	for i, field := range fields {
+		value, _ := field.ValueOf(tx.Statement.Context, last)
+		if token.Values[i], err = json.Marshal(value); err != nil {
+			return "", fmt.Errorf("failed to encode value of %s in page token: %w", field.DBName, err)
+		}
+	}
+	b, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// FindInBatches loads the rows of the query in db in batches of cursor.Limit
+// rows with Paginate, and calls fc for each batch. This is an alternative to
+// gorm's FindInBatches that also supports composite primary keys, such as the
+// primary keys of interleaved tables, and secondary indexes. Processing stops
+// if fc returns an error. The Token of the cursor can be used to start after
+// a given page.
+//
+// Example:
+//
+//	var tracks []Track
+//	err := spannergorm.FindInBatches(db, &tracks, spannergorm.Cursor{Limit: 1000}, func(tx *gorm.DB, batch int) error {
+//		// Process tracks
+//		return nil
+//	})
+//
+// This function can be used for both GoogleSQL-dialect and PostgreSQL-dialect databases.
+func FindInBatches(db *gorm.DB, dest interface{}, cursor Cursor, fc func(tx *gorm.DB, batch int) error) error {
+	for batch := 1; ; batch++ {
+		token, err := Paginate(db, dest, cursor)
+		if err != nil {
+			return err
+		}
+		if rows := reflect.Indirect(reflect.ValueOf(dest)); rows.Kind() == reflect.Slice && rows.Len() == 0 {
+			return nil
+		}
+		if err := fc(db.Session(&gorm.Session{NewDB: true}), batch); err != nil {
+			return err
+		}
+		if token == "" {
+			return nil
+		}
+		cursor.Token = token
+	}
+}
+
+// cursorFields returns the fields of the given columns, or the primary key
+// fields of the schema if no columns are given.
+func cursorFields(s *schema.Schema, columns []string) ([]*schema.Field, error) {
+	if len(columns) == 0 {
+		if len(s.PrimaryFields) == 0 {
+			return nil, gorm.ErrPrimaryKeyRequired
+		}
+		return s.PrimaryFields, nil
+	}
+	fields := make([]*schema.Field, len(columns))
+	for i, column := range columns {
+		field := s.LookUpField(column)
+		if field == nil || field.DBName == "" {
+			return nil, fmt.Errorf("model %s has no column %s", s.Name, column)
+		}
+		fields[i] = field
+	}
+	return fields, nil
+}
+
+// decodePageToken returns the values in the given page token.
+func decodePageToken(token, table string, columns []string, fields []*schema.Field) ([]interface{}, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var t pageToken
+	if err := json.Unmarshal(b, &t); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	if t.Table != table || !reflect.DeepEqual(t.Columns, columns) || len(t.Values) != len(fields) {
+		return nil, ErrInvalidPageToken
+	}
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		value := reflect.New(field.FieldType)
+		if err := json.Unmarshal(t.Values[i], value.Interface()); err != nil {
+			return nil, ErrInvalidPageToken
+		}
+		values[i] = value.Elem().Interface()
+	}
+	return values, nil
+}
+
+// keysetCondition returns the condition for the rows after the row with the
+// given key values. For the key (a, b, c) and the values (x, y, z) this is
+//
+//	a >= x AND (a > x OR (a = x AND (b > y OR (b = y AND c > z))))
+//
+// The first condition allows Spanner to seek directly to the first row.
+func keysetCondition(fields []*schema.Field, values []interface{}, desc bool) clause.Expression {
+	columns := make([]keyColumn, len(fields))
+	for i, field := range fields {
+		columns[i] = keyColumn{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}}
+	}
+	condition := compareKey(columns, values, !desc, false)
+	if len(fields) == 1 {
+		return condition
+	}
+	return clause.And(compareKey(columns[:1], values[:1], !desc, true), condition)
+}
+
+// keyColumn is a column of a primary key or an index.
+type keyColumn struct {
+	clause.Column
+	Desc bool
+}
+
+// compareKey returns the condition for the rows whose key sorts after (or
+// before if greater is false) the given key values in the order of the key.
+// The values may be a prefix of the key. The condition includes the rows
+// with a key that starts with the given values if inclusive is true.
+func compareKey(columns []keyColumn, values []interface{}, greater, inclusive bool) clause.Expression {
+	compare := func(i int, inclusive bool) clause.Expression {
+		column, value := columns[i].Column, values[i]
+		switch {
+		case greater != columns[i].Desc && inclusive:
+			return clause.Gte{Column: column, Value: value}
+		case greater != columns[i].Desc:
+			return clause.Gt{Column: column, Value: value}
+		case inclusive:
+			return clause.Lte{Column: column, Value: value}
+		}
+		return clause.Lt{Column: column, Value: value}
+	}
+	last := len(values) - 1
+	condition := compare(last, inclusive)
+	for i := last - 1; i >= 0; i-- {
+		condition = clause.Or(compare(i, false), clause.And(clause.Eq{Column: columns[i].Column, Value: values[i]}, condition))
+	}
+	return condition
+}
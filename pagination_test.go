@@ -0,0 +1,234 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/googleapis/go-sql-spanner/testutil"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// pageTrack is interleaved in an album table, and has a composite primary key.
+type pageTrack struct {
+	AlbumID     int64 `gorm:"primaryKey;autoIncrement:false"`
+	TrackNumber int64 `gorm:"primaryKey;autoIncrement:false"`
+	Title       string
+}
+
+func putTrackResult(server *testutil.MockedSpannerInMemTestServer, query string, tracks ...pageTrack) {
+	rows := make([]*structpb.ListValue, len(tracks))
+	for i, track := range tracks {
+		rows[i] = &structpb.ListValue{Values: []*structpb.Value{
+			structpb.NewStringValue(strconv.FormatInt(track.AlbumID, 10)),
+			structpb.NewStringValue(strconv.FormatInt(track.TrackNumber, 10)),
+			structpb.NewStringValue(track.Title),
+		}}
+	}
+	_ = server.TestSpanner.PutStatementResult(query, &testutil.StatementResult{
+		Type: testutil.StatementResultResultSet,
+		ResultSet: &spannerpb.ResultSet{
+			Metadata: &spannerpb.ResultSetMetadata{
+				RowType: &spannerpb.StructType{
+					Fields: []*spannerpb.StructType_Field{
+						{Name: "album_id", Type: &spannerpb.Type{Code: spannerpb.TypeCode_INT64}},
+						{Name: "track_number", Type: &spannerpb.Type{Code: spannerpb.TypeCode_INT64}},
+						{Name: "title", Type: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}},
+					},
+				},
+			},
+			Rows: rows,
+		},
+	})
+}
+
+func TestPaginate(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	const firstPage = "SELECT * FROM `page_tracks` ORDER BY `page_tracks`.`album_id`,`page_tracks`.`track_number` LIMIT @p1"
+	const nextPage = "SELECT * FROM `page_tracks` WHERE `page_tracks`.`album_id` >= @p1 AND " +
+		"(`page_tracks`.`album_id` > @p2 OR (`page_tracks`.`album_id` = @p3 AND `page_tracks`.`track_number` > @p4)) " +
+		"ORDER BY `page_tracks`.`album_id`,`page_tracks`.`track_number` LIMIT @p5"
+	putTrackResult(server, firstPage, pageTrack{1, 1, "One"}, pageTrack{1, 2, "Two"})
+	putTrackResult(server, nextPage, pageTrack{2, 1, "Three"})
+
+	var tracks []pageTrack
+	cursor := Cursor{Limit: 2}
+	token, err := Paginate(db, &tracks, cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token == "" {
+		t.Fatal("missing page token")
+	}
+	if g, w := len(tracks), 2; g != w {
+		t.Fatalf("track count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	cursor.Token = token
+	if token, err = Paginate(db, &tracks, cursor); err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		t.Fatalf("unexpected page token: %v", token)
+	}
+	if g, w := tracks, []pageTrack{{2, 1, "Three"}}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("tracks mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	req := getLastSqlRequest(server)
+	if g, w := req.Sql, nextPage; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	for name, want := range map[string]string{"p1": "1", "p2": "1", "p3": "1", "p4": "2", "p5": "2"} {
+		if g := req.Params.GetFields()[name].GetStringValue(); g != want {
+			t.Fatalf("param %s mismatch\n Got: %v\nWant: %v", name, g, want)
+		}
+	}
+
+	// The token can only be used with the same columns.
+	cursor.Columns = []string{"title"}
+	if _, err := Paginate(db, &tracks, cursor); !errors.Is(err, ErrInvalidPageToken) {
+		t.Fatalf("error mismatch\n Got: %v\nWant: %v", err, ErrInvalidPageToken)
+	}
+	if _, err := Paginate(db.Order("title"), &tracks, Cursor{Limit: 2}); err == nil {
+		t.Fatal("missing error for query with ORDER BY")
+	}
+}
+
+func TestPaginateDryRun(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+	dryRun := db.Session(&gorm.Session{DryRun: true})
+
+	var tracks []pageTrack
+	if _, err := Paginate(dryRun.Where("title IS NOT NULL"), &tracks, Cursor{
+		Limit:   10,
+		Columns: []string{"title", "album_id", "track_number"},
+		Index:   "idx_tracks_title",
+		Desc:    true,
+		Token:   encodeTestPageToken(t, `{"t":"page_tracks","c":["title","album_id","track_number"],"v":["Two",1,2]}`),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// Paginate does not return the statement, so the statement is rebuilt.
+	stmt := dryRun.Where("title IS NOT NULL").Clauses(ForceIndex("idx_tracks_title")).
+		Where(keysetCondition(mustCursorFields(t, dryRun, []string{"title", "album_id", "track_number"}), []interface{}{"Two", int64(1), int64(2)}, true)).
+		Find(&tracks).Statement
+	want := "SELECT * FROM `page_tracks` @{FORCE_INDEX=`idx_tracks_title`} WHERE title IS NOT NULL AND (`page_tracks`.`title` <= ? AND " +
+		"(`page_tracks`.`title` < ? OR (`page_tracks`.`title` = ? AND (`page_tracks`.`album_id` < ? OR (`page_tracks`.`album_id` = ? AND `page_tracks`.`track_number` < ?)))))"
+	if g, w := stmt.SQL.String(), want; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func encodeTestPageToken(t *testing.T, token string) string {
+	t.Helper()
+	return base64.RawURLEncoding.EncodeToString([]byte(token))
+}
+
+func mustCursorFields(t *testing.T, db *gorm.DB, columns []string) []*schema.Field {
+	t.Helper()
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&pageTrack{}); err != nil {
+		t.Fatal(err)
+	}
+	fields, err := cursorFields(stmt.Schema, columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fields
+}
+
+func TestFindInBatches(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	const firstPage = "SELECT * FROM `page_tracks` ORDER BY `page_tracks`.`album_id`,`page_tracks`.`track_number` LIMIT @p1"
+	const nextPage = "SELECT * FROM `page_tracks` WHERE `page_tracks`.`album_id` >= @p1 AND " +
+		"(`page_tracks`.`album_id` > @p2 OR (`page_tracks`.`album_id` = @p3 AND `page_tracks`.`track_number` > @p4)) " +
+		"ORDER BY `page_tracks`.`album_id`,`page_tracks`.`track_number` LIMIT @p5"
+	putTrackResult(server, firstPage, pageTrack{1, 1, "One"}, pageTrack{1, 2, "Two"})
+	putTrackResult(server, nextPage)
+
+	var tracks []pageTrack
+	var batches []int
+	if err := FindInBatches(db, &tracks, Cursor{Limit: 2}, func(tx *gorm.DB, batch int) error {
+		batches = append(batches, batch)
+		if g, w := len(tracks), 2; g != w {
+			t.Fatalf("track count mismatch\n Got: %v\nWant: %v", g, w)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if g, w := batches, []int{1}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("batches mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestFindInBatchesWithConditions(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	const firstPage = "SELECT * FROM `page_tracks` WHERE title IS NOT NULL ORDER BY `page_tracks`.`album_id`,`page_tracks`.`track_number` LIMIT @p1"
+	const nextPage = "SELECT * FROM `page_tracks` WHERE title IS NOT NULL AND (`page_tracks`.`album_id` >= @p1 AND " +
+		"(`page_tracks`.`album_id` > @p2 OR (`page_tracks`.`album_id` = @p3 AND `page_tracks`.`track_number` > @p4))) " +
+		"ORDER BY `page_tracks`.`album_id`,`page_tracks`.`track_number` LIMIT @p5"
+	putTrackResult(server, firstPage, pageTrack{1, 1, "One"}, pageTrack{1, 2, "Two"})
+	putTrackResult(server, nextPage, pageTrack{2, 1, "Three"}, pageTrack{2, 2, "Four"})
+
+	query := db.Where("title IS NOT NULL")
+	var tracks []pageTrack
+	var titles []string
+	if err := FindInBatches(query, &tracks, Cursor{Limit: 2}, func(tx *gorm.DB, batch int) error {
+		for _, track := range tracks {
+			titles = append(titles, track.Title)
+		}
+		if batch == 2 {
+			// The mock server returns the same result for the same SQL string,
+			// so the last page is registered after the second page.
+			putTrackResult(server, nextPage, pageTrack{3, 1, "Five"})
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if g, w := titles, []string{"One", "Two", "Three", "Four", "Five"}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("titles mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	for _, name := range []string{"ORDER BY", "LIMIT"} {
+		if _, ok := query.Statement.Clauses[name]; ok {
+			t.Fatalf("query was modified with %s clause", name)
+		}
+	}
+	if _, err := Paginate(query, &tracks, Cursor{Limit: 2}); err != nil {
+		t.Fatalf("query could not be used again: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spannerpg
+
+import (
+	spannergorm "github.com/googleapis/go-gorm-spanner"
+	"gorm.io/gorm/clause"
+)
+
+// ArrayContains returns an expression that is true if the given array column
+// contains the given value. The expression uses `$1 = ANY(column)`.
+//
+// Example:
+//
+//	db.Where(spannerpg.ArrayContains("tags", "rock")).Find(&albums)
+func ArrayContains(column string, value interface{}) clause.Expression {
+	return clause.Expr{SQL: "? = ANY(?)", Vars: []interface{}{value, clause.Column{Name: column}}}
+}
+
+// ArrayIncludesAny returns an expression that is true if the given array
+// column contains at least one of the given values. The expression uses the
+// array overlap operator `&&`.
+func ArrayIncludesAny[T any](column string, values []T) clause.Expression {
+	return clause.Expr{SQL: "? && ?", Vars: []interface{}{clause.Column{Name: column}, spannergorm.Array[T](values)}}
+}
+
+// ArrayIncludesAll returns an expression that is true if the given array
+// column contains all the given values. The expression uses the array
+// contains operator `@>`.
+func ArrayIncludesAll[T any](column string, values []T) clause.Expression {
+	return clause.Expr{SQL: "? @> ?", Vars: []interface{}{clause.Column{Name: column}, spannergorm.Array[T](values)}}
+}
+
+// ArrayLength returns the length of the given array column.
+//
+// Example:
+//
+//	db.Where(spannerpg.ArrayLength("tags").Gt(2)).Find(&albums)
+func ArrayLength(column string) spannergorm.ComparableExpr {
+	return spannergorm.ComparableExpr{Expr: clause.Expr{SQL: "array_length(?, 1)", Vars: []interface{}{clause.Column{Name: column}}}}
+}
+
+// InArray returns an expression that is true if the value of the given
+// column is one of the given values. The expression uses
+// `column = ANY($1)`, which means that the SQL string is the same for any
+// number of values.
+func InArray[T any](column string, values []T) clause.Expression {
+	return clause.Expr{SQL: "? = ANY(?)", Vars: []interface{}{clause.Column{Name: column}, spannergorm.Array[T](values)}}
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spannerpg
+
+import (
+	"fmt"
+	"testing"
+
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type taggedAlbum struct {
+	ID   int64
+	Tags TextArray
+}
+
+func TestArrayExpressions(t *testing.T) {
+	t.Parallel()
+
+	server, _, serverTeardown := setupMockedTestServer(t)
+	server.SetupSelectDialectResult(databasepb.DatabaseDialect_POSTGRESQL)
+	db, _, teardown := setupTestGormConnectionWithDialector(t, server, serverTeardown, New(postgres.Config{
+		DSN: fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true", server.Address),
+	}))
+	defer teardown()
+	dryRun := db.Session(&gorm.Session{DryRun: true})
+
+	for _, test := range []struct {
+		query func(db *gorm.DB) *gorm.DB
+		sql   string
+	}{
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(ArrayContains("tags", "rock")) },
+			sql:   `SELECT * FROM "tagged_albums" WHERE $1 = ANY("tags")`,
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(ArrayIncludesAny("tags", []string{"rock", "pop"})).Or(ArrayIncludesAll("tags", []string{"jazz"}))
+			},
+			sql: `SELECT * FROM "tagged_albums" WHERE "tags" && $1 OR "tags" @> $2`,
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(ArrayLength("tags").Gte(2)) },
+			sql:   `SELECT * FROM "tagged_albums" WHERE array_length("tags", 1) >= $1`,
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(InArray("id", []int64{1, 2, 3})) },
+			sql:   `SELECT * FROM "tagged_albums" WHERE "id" = ANY($1)`,
+		},
+	} {
+		stmt := test.query(dryRun).Find(&[]taggedAlbum{}).Statement
+		if g, w := stmt.SQL.String(), test.sql; g != w {
+			t.Errorf("sql mismatch\n Got: %v\nWant: %v", g, w)
+		}
+	}
+}
+
+func TestInListToArray(t *testing.T) {
+	t.Parallel()
+
+	server, _, serverTeardown := setupMockedTestServer(t)
+	server.SetupSelectDialectResult(databasepb.DatabaseDialect_POSTGRESQL)
+	db, _, teardown := setupTestGormConnectionWithDialector(t, server, serverTeardown, NewWithSpannerConfig(postgres.Config{
+		DSN: fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true", server.Address),
+	}, SpannerConfig{InListThreshold: 2}))
+	defer teardown()
+	dryRun := db.Session(&gorm.Session{DryRun: true})
+
+	for _, test := range []struct {
+		query func(db *gorm.DB) *gorm.DB
+		sql   string
+	}{
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where([]int64{1, 2, 3}) },
+			sql:   `SELECT * FROM "tagged_albums" WHERE "tagged_albums"."id" = ANY($1)`,
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where([]int64{1}) },
+			sql:   `SELECT * FROM "tagged_albums" WHERE "tagged_albums"."id" = $1`,
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where("id NOT IN ?", []int64{1, 2}) },
+			sql:   `SELECT * FROM "tagged_albums" WHERE id <> ALL($1)`,
+		},
+	} {
+		stmt := test.query(dryRun).Find(&[]taggedAlbum{}).Statement
+		if g, w := stmt.SQL.String(), test.sql; g != w {
+			t.Errorf("sql mismatch\n Got: %v\nWant: %v", g, w)
+		}
+	}
+}
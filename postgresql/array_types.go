@@ -0,0 +1,190 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spannerpg
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	spannergorm "github.com/googleapis/go-gorm-spanner"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// TextArray is a text[] column. This type cannot contain any NULL elements.
+type TextArray = spannergorm.Array[string]
+
+// NullTextArray is a text[] column that can contain NULL elements.
+type NullTextArray = spannergorm.Array[spanner.NullString]
+
+// BigintArray is a bigint[] column. This type cannot contain any NULL
+// elements.
+type BigintArray = spannergorm.Array[int64]
+
+// NullBigintArray is a bigint[] column that can contain NULL elements.
+type NullBigintArray = spannergorm.Array[spanner.NullInt64]
+
+// Float8Array is a float8[] column. This type cannot contain any NULL
+// elements.
+type Float8Array = spannergorm.Array[float64]
+
+// NullFloat8Array is a float8[] column that can contain NULL elements.
+type NullFloat8Array = spannergorm.Array[spanner.NullFloat64]
+
+// BoolArray is a bool[] column. This type cannot contain any NULL elements.
+type BoolArray = spannergorm.Array[bool]
+
+// NullBoolArray is a bool[] column that can contain NULL elements.
+type NullBoolArray = spannergorm.Array[spanner.NullBool]
+
+// ByteaArray is a bytea[] column. NULL elements are represented by nil.
+type ByteaArray = spannergorm.Array[[]byte]
+
+// DateArray is a date[] column. This type cannot contain any NULL elements.
+type DateArray = spannergorm.Array[civil.Date]
+
+// NullDateArray is a date[] column that can contain NULL elements.
+type NullDateArray = spannergorm.Array[spanner.NullDate]
+
+// TimestamptzArray is a timestamptz[] column. This type cannot contain any
+// NULL elements.
+type TimestamptzArray = spannergorm.Array[time.Time]
+
+// NullTimestamptzArray is a timestamptz[] column that can contain NULL
+// elements.
+type NullTimestamptzArray = spannergorm.Array[spanner.NullTime]
+
+// NullNumericArray is a numeric[] column that can contain NULL elements.
+type NullNumericArray = spannergorm.Array[spanner.PGNumeric]
+
+// NullJSONBArray is a jsonb[] column that can contain NULL elements.
+type NullJSONBArray = spannergorm.Array[spanner.PGJsonB]
+
+// NumericArray is a numeric[] column. This type cannot contain any NULL
+// elements. The values are sent to Spanner as PostgreSQL numeric values.
+// Values that cannot be represented exactly as a decimal number, such as
+// 1/3, return spannergorm.ErrNumericOutOfRange.
+type NumericArray []big.Rat
+
+//goland:noinspection GoMixedReceiverTypes
+func (a NumericArray) Value() (driver.Value, error) {
+	if a == nil {
+		return []spanner.PGNumeric(nil), nil
+	}
+	res := make([]spanner.PGNumeric, len(a))
+	for i := range a {
+		value, err := numericString(&a[i])
+		if err != nil {
+			return nil, fmt.Errorf("index %d of NumericArray: %w", i, err)
+		}
+		res[i] = spanner.PGNumeric{Numeric: value, Valid: true}
+	}
+	return res, nil
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (a *NumericArray) Scan(v any) error {
+	return (*spannergorm.Array[big.Rat])(a).Scan(v)
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (a NumericArray) GormDataType() string {
+	return "numeric[]"
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (a NumericArray) GormDBDataType(_ *gorm.DB, _ *schema.Field) string {
+	return "numeric[]"
+}
+
+// numericString returns r as an exact decimal string.
+func numericString(r *big.Rat) (string, error) {
+	prec, exact := r.FloatPrec()
+	if !exact {
+		return "", fmt.Errorf("%w: %s cannot be represented exactly as a decimal number", spannergorm.ErrNumericOutOfRange, r.RatString())
+	}
+	return r.FloatString(prec), nil
+}
+
+// JSONBArray is a jsonb[] column. Each element is marshalled to and
+// unmarshalled from JSON. NULL elements are only supported if T can be nil,
+// for example a map or a pointer.
+type JSONBArray[T any] []T
+
+//goland:noinspection GoMixedReceiverTypes
+func (a JSONBArray[T]) Value() (driver.Value, error) {
+	if a == nil {
+		return []spanner.PGJsonB(nil), nil
+	}
+	res := make([]spanner.PGJsonB, len(a))
+	for i, v := range a {
+		res[i] = spanner.PGJsonB{Value: v, Valid: true}
+	}
+	return res, nil
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (a *JSONBArray[T]) Scan(v any) error {
+	return (*spannergorm.Array[T])(a).Scan(v)
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (a JSONBArray[T]) GormDataType() string {
+	return "jsonb[]"
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (a JSONBArray[T]) GormDBDataType(_ *gorm.DB, _ *schema.Field) string {
+	return "jsonb[]"
+}
+
+// arrayElementTypeAliases contains the names that are used for array element
+// types in DDL statements for the names that Spanner returns in the
+// spanner_type column of information_schema.columns.
+var arrayElementTypeAliases = map[string][]string{
+	"character varying":        {"varchar", "text"},
+	"boolean":                  {"bool"},
+	"bigint":                   {"int8"},
+	"real":                     {"float4"},
+	"double precision":         {"float8"},
+	"numeric":                  {"decimal"},
+	"timestamp with time zone": {"timestamptz"},
+}
+
+var arrayTypeRegexp = regexp.MustCompile(`^([a-z ]+?)\s*(\(\d+\))?\[]$`)
+
+// arrayTypeAliases returns the aliases of the given array type, or nil if
+// the type is not an array type.
+func arrayTypeAliases(databaseTypeName string) []string {
+	matches := arrayTypeRegexp.FindStringSubmatch(strings.ToLower(databaseTypeName))
+	if matches == nil {
+		return nil
+	}
+	element, size := matches[1], matches[2]
+	aliases := make([]string, 0, len(arrayElementTypeAliases[element]))
+	for _, alias := range arrayElementTypeAliases[element] {
+		if alias == "text" && size != "" {
+			continue
+		}
+		aliases = append(aliases, alias+size+"[]")
+	}
+	return aliases
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spannerpg
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	spannergorm "github.com/googleapis/go-gorm-spanner"
+	"github.com/googleapis/go-sql-spanner/testutil"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gorm.io/driver/postgres"
+)
+
+type arrayEntity struct {
+	ID           int64 `gorm:"primaryKey;autoIncrement:false"`
+	Texts        TextArray
+	Bigints      BigintArray
+	Float8s      Float8Array
+	Bools        NullBoolArray
+	Byteas       ByteaArray
+	Dates        DateArray
+	Timestamps   TimestamptzArray
+	Numerics     NumericArray
+	Venues       JSONBArray[map[string]any]
+	ShortStrings spannergorm.Array[string] `gorm:"size:100"`
+}
+
+func TestArrayDataTypes(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	statements, err := db.Migrator().(spannergorm.SpannerMigrator).AutoMigrateDryRun(&arrayEntity{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := len(statements), 1; g != w {
+		t.Fatalf("statement count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := statements[0].SQL, `CREATE TABLE "array_entities" ("id" int,"texts" text[],"bigints" bigint[],"float8s" float8[],"bools" bool[],"byteas" bytea[],"dates" date[],"timestamps" timestamptz[],"numerics" numeric[],"venues" jsonb[],"short_strings" varchar(100)[],PRIMARY KEY ("id"))`; g != w {
+		t.Fatalf("create table statement mismatch\n Got: %s\nWant: %s", g, w)
+	}
+}
+
+func TestArrayTypeAliases(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	// The types that are returned by Spanner in the spanner_type column of
+	// information_schema.columns, and the types that are used in DDL.
+	for spannerType, dataType := range map[string]string{
+		"character varying[]":        "text[]",
+		"character varying(100)[]":   "varchar(100)[]",
+		"bigint[]":                   "bigint[]",
+		"double precision[]":         "float8[]",
+		"real[]":                     "float4[]",
+		"boolean[]":                  "bool[]",
+		"bytea[]":                    "bytea[]",
+		"date[]":                     "date[]",
+		"timestamp with time zone[]": "timestamptz[]",
+		"numeric[]":                  "numeric[]",
+		"jsonb[]":                    "jsonb[]",
+	} {
+		same := strings.HasPrefix(dataType, spannerType)
+		for _, alias := range db.Migrator().GetTypeAliases(spannerType) {
+			same = same || strings.HasPrefix(dataType, alias)
+		}
+		if !same {
+			t.Errorf("%s is not recognized as %s, aliases: %v", spannerType, dataType, db.Migrator().GetTypeAliases(spannerType))
+		}
+	}
+	if g, w := db.Migrator().GetTypeAliases("character varying(100)[]"), []string{"varchar(100)[]"}; !reflect.DeepEqual(g, w) {
+		t.Errorf("aliases mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestInsertAndSelectArrays(t *testing.T) {
+	t.Parallel()
+
+	server, _, serverTeardown := setupMockedTestServer(t)
+	server.SetupSelectDialectResult(databasepb.DatabaseDialect_POSTGRESQL)
+	db, server, teardown := setupTestGormConnectionWithDialector(t, server, serverTeardown, New(postgres.Config{
+		DSN: fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true", server.Address),
+	}))
+	defer teardown()
+
+	insertSql := `INSERT INTO "array_entities" ("id","texts","bigints","float8s","bools","byteas","dates","timestamps","numerics","venues","short_strings") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`
+	_ = server.TestSpanner.PutStatementResult(insertSql, &testutil.StatementResult{
+		Type:        testutil.StatementResultUpdateCount,
+		UpdateCount: 1,
+	})
+	entity := arrayEntity{
+		ID:         1,
+		Texts:      TextArray{"text1", "text2"},
+		Bigints:    BigintArray{1, 2},
+		Float8s:    Float8Array{3.14},
+		Bools:      NullBoolArray{{Bool: true, Valid: true}, {}},
+		Byteas:     ByteaArray{[]byte("bytes1"), nil},
+		Dates:      DateArray{{Year: 2025, Month: 2, Day: 10}},
+		Timestamps: TimestamptzArray{time.UnixMilli(1739192107974).UTC()},
+		Numerics:   NumericArray{*big.NewRat(314, 100), *big.NewRat(-1, 8)},
+		Venues:     JSONBArray[map[string]any]{{"name": "Concert Hall", "rating": 4.5}},
+	}
+	if err := db.Create(&entity).Error; err != nil {
+		t.Fatalf("failed to create entity: %v", err)
+	}
+	req := getLastSqlRequest(server)
+	if g, w := req.Sql, insertSql; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.ParamTypes["p9"].ArrayElementType.TypeAnnotation, spannerpb.TypeAnnotationCode_PG_NUMERIC; g != w {
+		t.Errorf("numeric type annotation mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.Params.Fields["p9"].GetListValue().GetValues()[1].GetStringValue(), "-0.125"; g != w {
+		t.Errorf("numeric value mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.ParamTypes["p10"].ArrayElementType.TypeAnnotation, spannerpb.TypeAnnotationCode_PG_JSONB; g != w {
+		t.Errorf("jsonb type annotation mismatch\n Got: %v\nWant: %v", g, w)
+	}
+
+	// Return the inserted values from a query.
+	columns := []string{"id", "texts", "bigints", "float8s", "bools", "byteas", "dates", "timestamps", "numerics", "venues", "short_strings"}
+	fields := make([]*spannerpb.StructType_Field, len(columns))
+	row := &structpb.ListValue{Values: make([]*structpb.Value, len(columns))}
+	for i, column := range columns {
+		param := fmt.Sprintf("p%d", i+1)
+		tp, ok := req.ParamTypes[param]
+		if !ok {
+			tp = &spannerpb.Type{Code: spannerpb.TypeCode_ARRAY, ArrayElementType: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}}
+		}
+		fields[i] = &spannerpb.StructType_Field{Name: column, Type: tp}
+		row.Values[i] = req.Params.Fields[param]
+	}
+	_ = server.TestSpanner.PutStatementResult(`SELECT * FROM "array_entities" ORDER BY "array_entities"."id" LIMIT $1`, &testutil.StatementResult{
+		Type: testutil.StatementResultResultSet,
+		ResultSet: &spannerpb.ResultSet{
+			Metadata: &spannerpb.ResultSetMetadata{RowType: &spannerpb.StructType{Fields: fields}},
+			Rows:     []*structpb.ListValue{row},
+		},
+	})
+	var result arrayEntity
+	if err := db.First(&result).Error; err != nil {
+		t.Fatalf("failed to fetch entity: %v", err)
+	}
+	if g, w := result.Texts, entity.Texts; !reflect.DeepEqual(g, w) {
+		t.Errorf("texts mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := result.Bigints, entity.Bigints; !reflect.DeepEqual(g, w) {
+		t.Errorf("bigints mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := result.Float8s, entity.Float8s; !reflect.DeepEqual(g, w) {
+		t.Errorf("float8s mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := result.Bools, entity.Bools; !reflect.DeepEqual(g, w) {
+		t.Errorf("bools mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := result.Byteas, entity.Byteas; !reflect.DeepEqual(g, w) {
+		t.Errorf("byteas mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := result.Dates, (DateArray{civil.Date{Year: 2025, Month: 2, Day: 10}}); !reflect.DeepEqual(g, w) {
+		t.Errorf("dates mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := result.Timestamps, entity.Timestamps; !reflect.DeepEqual(g, w) {
+		t.Errorf("timestamps mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := len(result.Numerics), len(entity.Numerics); g != w {
+		t.Fatalf("numerics length mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	for i := range entity.Numerics {
+		if g, w := &result.Numerics[i], &entity.Numerics[i]; g.Cmp(w) != 0 {
+			t.Errorf("numerics[%d] mismatch\n Got: %v\nWant: %v", i, g, w)
+		}
+	}
+	if g, w := result.Venues, entity.Venues; !reflect.DeepEqual(g, w) {
+		t.Errorf("venues mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
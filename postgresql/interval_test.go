@@ -0,0 +1,59 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spannerpg
+
+import (
+	"fmt"
+	"testing"
+
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	spannergorm "github.com/googleapis/go-gorm-spanner"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type intervalSubscription struct {
+	ID        int64 `gorm:"primaryKey;autoIncrement:false"`
+	Period    spannergorm.Interval
+	Reminders spannergorm.IntervalArray
+	Grace     string `gorm:"type:interval"`
+}
+
+func TestInterval(t *testing.T) {
+	t.Parallel()
+
+	server, _, serverTeardown := setupMockedTestServer(t)
+	server.SetupSelectDialectResult(databasepb.DatabaseDialect_POSTGRESQL)
+	db, _, teardown := setupTestGormConnectionWithDialector(t, server, serverTeardown, New(postgres.Config{
+		DSN: fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true", server.Address),
+	}))
+	defer teardown()
+
+	statements, err := db.Migrator().(spannergorm.SpannerMigrator).AutoMigrateDryRun(&intervalSubscription{})
+	if err != nil {
+		t.Fatalf("failed to run AutoMigrateDryRun: %v", err)
+	}
+	if g, w := statements[0].SQL,
+		`CREATE TABLE "interval_subscriptions" ("id" int,"period" interval,"reminders" interval[],"grace" interval,PRIMARY KEY ("id"))`; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	// INTERVAL columns are selected as strings, as the Spanner database/sql
+	// driver does not decode INTERVAL values.
+	stmt := db.Session(&gorm.Session{DryRun: true}).Find(&[]intervalSubscription{}).Statement
+	if g, w := stmt.SQL.String(),
+		`SELECT "id",CAST("period" AS varchar) AS "period",CAST("reminders" AS varchar[]) AS "reminders",CAST("grace" AS varchar) AS "grace" FROM "interval_subscriptions"`; g != w {
+		t.Fatalf("select sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spannerpg
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/spanner"
+	spannergorm "github.com/googleapis/go-gorm-spanner"
+	"gorm.io/gorm/clause"
+)
+
+// JSONGet returns the jsonb value at the given path in the given jsonb
+// column. Each element of the path is either an object key (string) or an
+// array index (int). The expression uses the `->` operator.
+//
+// Example:
+//
+//	db.Select(spannerpg.JSONGet("venue_details", "address", "city")).Find(&venues)
+func JSONGet(column string, path ...interface{}) clause.Expr {
+	return clause.Expr{SQL: "?" + jsonPath(path, "->"), Vars: []interface{}{clause.Column{Name: column}}}
+}
+
+// JSONGetText returns the value at the given path in the given jsonb column
+// as text. The last element of the path uses the `->>` operator. The value is
+// cast to float8 or bool if the expression is compared with a number or a
+// bool.
+//
+// Example:
+//
+//	db.Where(spannerpg.JSONGetText("venue_details", "rating").Gt(4)).Find(&venues)
+func JSONGetText(column string, path ...interface{}) spannergorm.ComparableExpr {
+	var sql string
+	if len(path) == 0 {
+		sql = "?::text"
+	} else {
+		sql = "?" + jsonPath(path[:len(path)-1], "->") + jsonPath(path[len(path)-1:], "->>")
+	}
+	return spannergorm.ComparableExpr{
+		Expr: clause.Expr{SQL: sql, Vars: []interface{}{clause.Column{Name: column}}},
+		TypedSQL: func(value interface{}) string {
+			if cast := jsonCast(value); cast != "" {
+				return "(" + sql + ")::" + cast
+			}
+			return sql
+		},
+	}
+}
+
+// JSONContains returns an expression that is true if the given jsonb column
+// contains the given value. The value is marshalled to JSON. The expression
+// uses the `@>` operator.
+//
+// Example:
+//
+//	db.Where(spannerpg.JSONContains("venue_details", map[string]any{"open": true})).Find(&venues)
+func JSONContains(column string, value interface{}) clause.Expr {
+	return clause.Expr{SQL: "? @> ?", Vars: []interface{}{clause.Column{Name: column}, spanner.PGJsonB{Value: value, Valid: true}}}
+}
+
+// jsonPath returns the given path as a sequence of the given operator
+// followed by a string literal or an integer.
+func jsonPath(path []interface{}, operator string) string {
+	var b strings.Builder
+	for _, p := range path {
+		b.WriteString(" " + operator + " ")
+		switch v := p.(type) {
+		case int:
+			b.WriteString(strconv.Itoa(v))
+		case int64:
+			b.WriteString(strconv.FormatInt(v, 10))
+		default:
+			b.WriteString("'" + strings.ReplaceAll(fmt.Sprint(v), "'", "''") + "'")
+		}
+	}
+	return b.String()
+}
+
+// jsonCast returns the type that a text value from a jsonb column must be
+// cast to for a comparison with the given value, or an empty string if no
+// cast is needed.
+func jsonCast(value interface{}) string {
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			return ""
+		}
+		value = v
+	}
+	switch value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return "float8"
+	case bool:
+		return "bool"
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spannerpg
+
+import (
+	"fmt"
+	"testing"
+
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	spannergorm "github.com/googleapis/go-gorm-spanner"
+	"github.com/googleapis/go-sql-spanner/testutil"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type venueDetails struct {
+	Rating float64 `json:"rating"`
+}
+
+type jsonVenue struct {
+	ID      int64 `gorm:"primaryKey;autoIncrement:false"`
+	Details spannergorm.JSON[venueDetails]
+}
+
+func TestJSONExpressions(t *testing.T) {
+	t.Parallel()
+
+	server, _, serverTeardown := setupMockedTestServer(t)
+	server.SetupSelectDialectResult(databasepb.DatabaseDialect_POSTGRESQL)
+	db, _, teardown := setupTestGormConnectionWithDialector(t, server, serverTeardown, New(postgres.Config{
+		DSN: fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true", server.Address),
+	}))
+	defer teardown()
+	dryRun := db.Session(&gorm.Session{DryRun: true})
+
+	for _, test := range []struct {
+		query func(db *gorm.DB) *gorm.DB
+		sql   string
+	}{
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(JSONGetText("details", "rating").Gt(4)) },
+			sql:   `SELECT * FROM "json_venues" WHERE ("details" ->> 'rating')::float8 > $1`,
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(JSONGetText("details", "genres", 0).Eq("rock")) },
+			sql:   `SELECT * FROM "json_venues" WHERE "details" -> 'genres' ->> 0 = $1`,
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(JSONGetText("details", "open").Eq(true)) },
+			sql:   `SELECT * FROM "json_venues" WHERE ("details" ->> 'open')::bool = $1`,
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(JSONGetText("details", "it's").Neq("")) },
+			sql:   `SELECT * FROM "json_venues" WHERE "details" ->> 'it''s' <> $1`,
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Select("?", JSONGet("details", "address", "city")) },
+			sql:   `SELECT "details" -> 'address' -> 'city' FROM "json_venues"`,
+		},
+		{
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(JSONContains("details", map[string]any{"open": true})) },
+			sql:   `SELECT * FROM "json_venues" WHERE "details" @> $1`,
+		},
+	} {
+		stmt := test.query(dryRun).Find(&[]jsonVenue{}).Statement
+		if g, w := stmt.SQL.String(), test.sql; g != w {
+			t.Errorf("sql mismatch\n Got: %v\nWant: %v", g, w)
+		}
+	}
+}
+
+func TestInsertJSON(t *testing.T) {
+	t.Parallel()
+
+	server, _, serverTeardown := setupMockedTestServer(t)
+	server.SetupSelectDialectResult(databasepb.DatabaseDialect_POSTGRESQL)
+	db, server, teardown := setupTestGormConnectionWithDialector(t, server, serverTeardown, New(postgres.Config{
+		DSN: fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true", server.Address),
+	}))
+	defer teardown()
+
+	insertSql := `INSERT INTO "json_venues" ("id","details") VALUES ($1,$2)`
+	_ = server.TestSpanner.PutStatementResult(insertSql, &testutil.StatementResult{
+		Type:        testutil.StatementResultUpdateCount,
+		UpdateCount: 1,
+	})
+	if err := db.Create(&jsonVenue{ID: 1, Details: spannergorm.NewJSON(venueDetails{Rating: 4.5})}).Error; err != nil {
+		t.Fatalf("failed to create venue: %v", err)
+	}
+	req := getLastSqlRequest(server)
+	if g, w := req.Sql, insertSql; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.ParamTypes["p2"].GetTypeAnnotation(), spannerpb.TypeAnnotationCode_PG_JSONB; g != w {
+		t.Fatalf("type annotation mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.Params.Fields["p2"].GetStringValue(), `{"rating":4.5}`; g != w {
+		t.Fatalf("param value mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
@@ -16,13 +16,17 @@ package spannerpg
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
 	"strings"
 
 	"cloud.google.com/go/spanner"
+	spannergorm "github.com/googleapis/go-gorm-spanner"
+	"github.com/googleapis/go-gorm-spanner/internal/gormutil"
 	spannerdriver "github.com/googleapis/go-sql-spanner"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -58,12 +62,12 @@ func (m spannerPostgresMigrator) CurrentDatabase() (name string) {
 	return "db"
 }
 
+// CurrentSchema returns the schema and the unqualified name of the given
+// table. Tables in the default schema are in the public schema.
 func (m spannerPostgresMigrator) CurrentSchema(stmt *gorm.Statement, table string) (interface{}, interface{}) {
-	s, t := m.Migrator.CurrentSchema(stmt, table)
-	if expr, ok := s.(clause.Expr); ok {
-		if expr.SQL == "CURRENT_SCHEMA()" {
-			return "public", t
-		}
+	s, t := gormutil.TableSchema(stmt, table)
+	if s == "" {
+		return "public", t
 	}
 	return s, t
 }
@@ -121,7 +125,10 @@ func (m spannerPostgresMigrator) autoMigrate(dryRun bool, values ...interface{})
 			return nil, err
 		}
 	}
-	err = m.Migrator.AutoMigrate(values...)
+	// Schemas that are created in a batch do not exist until the batch has
+	// been executed, and must only be created once.
+	m.DB = gormutil.WithCreatedSchemas(m.DB)
+	err = m.autoMigrateModels(values...)
 	if err == nil {
 		if !dryRun && disableAutoBatching {
 			return nil, nil
@@ -150,6 +157,371 @@ func (m spannerPostgresMigrator) autoMigrate(dryRun bool, values ...interface{})
 	return nil, err
 }
 
+// autoMigrateModels migrates all tables before creating or replacing the
+// views that are defined by models that implement View. Roles and privileges
+// of models that implement TableGrants are migrated last.
+func (m spannerPostgresMigrator) autoMigrateModels(values ...interface{}) error {
+	var tables, views []interface{}
+	for _, value := range values {
+		if _, ok := value.(spannergorm.View); ok {
+			views = append(views, value)
+		} else {
+			tables = append(tables, value)
+		}
+	}
+	// Sequences of new tables are created by CreateTable.
+	var existingTables []interface{}
+	for _, value := range tables {
+		if m.HasTable(value) {
+			existingTables = append(existingTables, value)
+		}
+	}
+	if err := m.Migrator.AutoMigrate(tables...); err != nil {
+		return err
+	}
+	for _, value := range existingTables {
+		if err := m.migrateSequences(value); err != nil {
+			return err
+		}
+	}
+	for _, value := range views {
+		if err := m.views().MigrateView(value); err != nil {
+			return err
+		}
+	}
+	return m.migrateGrants(values...)
+}
+
+// migrateGrants creates the roles and grants the privileges that are declared
+// by models that implement TableGrants and that do not yet exist.
+func (m spannerPostgresMigrator) migrateGrants(values ...interface{}) error {
+	roles := make(map[string]bool)
+	for _, value := range values {
+		grants, ok := value.(spannergorm.TableGrants)
+		if !ok {
+			continue
+		}
+		for _, grant := range grants.TableGrants() {
+			if !roles[grant.Role] && !m.HasRole(grant.Role) {
+				if err := m.CreateRole(grant.Role); err != nil {
+					return err
+				}
+			}
+			roles[grant.Role] = true
+			if !m.HasPrivilege(value, grant.Privilege, grant.Role, grant.Columns...) {
+				if err := m.GrantPrivilege(value, grant.Privilege, grant.Role, grant.Columns...); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// views returns the migrator for the views of the models.
+func (m spannerPostgresMigrator) views() spannergorm.ViewMigrator {
+	return spannergorm.ViewMigrator{
+		Migrator:      m.Migrator.Migrator,
+		CurrentSchema: m.CurrentSchema,
+		QueryRaw:      m.queryRaw,
+	}
+}
+
+func (m spannerPostgresMigrator) CreateView(name string, option gorm.ViewOption) error {
+	return m.views().CreateView(name, option)
+}
+
+func (m spannerPostgresMigrator) DropView(name string) error {
+	return m.views().DropView(name)
+}
+
+func (m spannerPostgresMigrator) HasView(name string) bool {
+	return m.views().HasView(name)
+}
+
+func (m spannerPostgresMigrator) CreateRole(name string) error {
+	return m.DB.Exec("CREATE ROLE ?", clause.Column{Name: name}).Error
+}
+
+func (m spannerPostgresMigrator) DropRole(name string) error {
+	return m.DB.Exec("DROP ROLE ?", clause.Column{Name: name}).Error
+}
+
+func (m spannerPostgresMigrator) HasRole(name string) bool {
+	var count int64
+	m.queryRaw("SELECT count(*) FROM information_schema.enabled_roles WHERE role_name = ?", name).Scan(&count)
+	return count > 0
+}
+
+func (m spannerPostgresMigrator) GrantPrivilege(value interface{}, privilege, role string, columns ...string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		sql, values, err := m.buildGrant(stmt, privilege, columns)
+		if err != nil {
+			return err
+		}
+		return m.DB.Exec("GRANT "+sql+" TO ?", append(values, clause.Column{Name: role})...).Error
+	})
+}
+
+func (m spannerPostgresMigrator) RevokePrivilege(value interface{}, privilege, role string, columns ...string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		sql, values, err := m.buildGrant(stmt, privilege, columns)
+		if err != nil {
+			return err
+		}
+		return m.DB.Exec("REVOKE "+sql+" FROM ?", append(values, clause.Column{Name: role})...).Error
+	})
+}
+
+func (m spannerPostgresMigrator) HasPrivilege(value interface{}, privilege, role string, columns ...string) bool {
+	var count int64
+	if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		currentSchema, curTable := m.CurrentSchema(stmt, stmt.Table)
+		privilege = strings.ToUpper(privilege)
+		if len(columns) == 0 {
+			return m.queryRaw(
+				"SELECT count(*) FROM information_schema.table_privileges WHERE table_schema = ? AND table_name = ? AND privilege_type = ? AND grantee = ?",
+				currentSchema, curTable, privilege, role,
+			).Scan(&count).Error
+		}
+		names := columnNames(stmt, columns)
+		if err := m.queryRaw(
+			"SELECT count(*) FROM information_schema.column_privileges WHERE table_schema = ? AND table_name = ? AND privilege_type = ? AND grantee = ? AND column_name IN ?",
+			currentSchema, curTable, privilege, role, names,
+		).Scan(&count).Error; err != nil {
+			return err
+		}
+		if count < int64(len(names)) {
+			count = 0
+		}
+		return nil
+	}); err != nil {
+		return false
+	}
+	return count > 0
+}
+
+// buildGrant returns the privilege and the object of a GRANT or REVOKE
+// statement, for example `SELECT(id, name) ON TABLE singers`.
+func (m spannerPostgresMigrator) buildGrant(stmt *gorm.Statement, privilege string, columns []string) (string, []interface{}, error) {
+	privilege = strings.ToUpper(privilege)
+	switch privilege {
+	case "SELECT", "INSERT", "UPDATE", "DELETE":
+	default:
+		return "", nil, fmt.Errorf("unsupported privilege: %s", privilege)
+	}
+	sql := privilege
+	var values []interface{}
+	if len(columns) > 0 {
+		sql += "(" + strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",") + ")"
+		for _, name := range columnNames(stmt, columns) {
+			values = append(values, clause.Column{Name: name})
+		}
+	}
+	// Spanner PostgreSQL uses ON TABLE for both tables and views.
+	sql += " ON TABLE ?"
+	return sql, append(values, m.CurrentTable(stmt)), nil
+}
+
+// columnNames returns the database column names of the given field or
+// column names.
+func columnNames(stmt *gorm.Statement, columns []string) []string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column
+		if stmt.Schema != nil {
+			if field := stmt.Schema.LookUpField(column); field != nil {
+				names[i] = field.DBName
+			}
+		}
+	}
+	return names
+}
+
+func (m spannerPostgresMigrator) CreateSequence(sequence spannergorm.Sequence) error {
+	sql := "CREATE SEQUENCE IF NOT EXISTS ? " + sequenceKind(sequence)
+	if sequence.SkipRangeMin != nil && sequence.SkipRangeMax != nil {
+		sql += fmt.Sprintf(" SKIP RANGE %d %d", *sequence.SkipRangeMin, *sequence.SkipRangeMax)
+	}
+	if sequence.StartWithCounter != nil {
+		sql += fmt.Sprintf(" START COUNTER WITH %d", *sequence.StartWithCounter)
+	}
+	return m.DB.Exec(sql, clause.Table{Name: sequence.Name}).Error
+}
+
+func (m spannerPostgresMigrator) AlterSequence(sequence spannergorm.Sequence) error {
+	sql := "ALTER SEQUENCE ?"
+	if sequence.SkipRangeMin != nil && sequence.SkipRangeMax != nil {
+		sql += fmt.Sprintf(" SKIP RANGE %d %d", *sequence.SkipRangeMin, *sequence.SkipRangeMax)
+	} else {
+		sql += " NO SKIP RANGE"
+	}
+	if sequence.StartWithCounter != nil {
+		sql += fmt.Sprintf(" RESTART COUNTER WITH %d", *sequence.StartWithCounter)
+	}
+	return m.DB.Exec(sql, clause.Table{Name: sequence.Name}).Error
+}
+
+func sequenceKind(sequence spannergorm.Sequence) string {
+	if sequence.Kind == "" {
+		return "BIT_REVERSED_POSITIVE"
+	}
+	return strings.ToUpper(sequence.Kind)
+}
+
+func (m spannerPostgresMigrator) DropSequence(name string) error {
+	return m.DB.Exec("DROP SEQUENCE ?", clause.Table{Name: name}).Error
+}
+
+func (m spannerPostgresMigrator) HasSequence(name string) bool {
+	var count int64
+	currentSchema, curName := "public", name
+	if idx := strings.LastIndex(name, "."); idx > -1 {
+		currentSchema, curName = name[:idx], name[idx+1:]
+	}
+	m.queryRaw("SELECT count(*) FROM information_schema.sequences WHERE sequence_schema = ? AND sequence_name = ?", currentSchema, curName).Scan(&count)
+	return count > 0
+}
+
+func (m spannerPostgresMigrator) GetSequences() ([]spannergorm.Sequence, error) {
+	return m.getSequences("")
+}
+
+// getSequences returns the sequence with the given name, or all sequences if
+// name is empty. The names of sequences in the public schema do not include
+// the schema.
+func (m spannerPostgresMigrator) getSequences(name string) ([]spannergorm.Sequence, error) {
+	query := "SELECT sequence_schema, sequence_name, sequence_kind, skip_range_min, skip_range_max, counter_start_value " +
+		"FROM information_schema.sequences "
+	var values []interface{}
+	if name != "" {
+		currentSchema, curName := m.CurrentSchema(&gorm.Statement{DB: m.DB}, name)
+		query += "WHERE sequence_schema = ? AND sequence_name = ? "
+		values = append(values, currentSchema, curName)
+	} else {
+		query += "WHERE sequence_schema NOT IN ('information_schema', 'spanner_sys', 'pg_catalog') "
+	}
+	rows, err := m.queryRaw(query+"ORDER BY sequence_schema, sequence_name", values...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var sequences []spannergorm.Sequence
+	for rows.Next() {
+		var (
+			sequenceSchema, sequenceName string
+			kind                         sql.NullString
+			skipRangeMin, skipRangeMax   sql.NullInt64
+			startWithCounter             sql.NullInt64
+		)
+		if err := rows.Scan(&sequenceSchema, &sequenceName, &kind, &skipRangeMin, &skipRangeMax, &startWithCounter); err != nil {
+			return nil, err
+		}
+		if sequenceSchema != "public" {
+			sequenceName = sequenceSchema + "." + sequenceName
+		}
+		sequences = append(sequences, spannergorm.Sequence{
+			Name:             sequenceName,
+			Kind:             strings.ToLower(kind.String),
+			SkipRangeMin:     int64Ptr(skipRangeMin),
+			SkipRangeMax:     int64Ptr(skipRangeMax),
+			StartWithCounter: int64Ptr(startWithCounter),
+		})
+	}
+	return sequences, rows.Err()
+}
+
+func int64Ptr(v sql.NullInt64) *int64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Int64
+}
+
+// createSequences creates the sequences that are declared with a
+// gorm_sequence_name tag on the fields of the given models, and sets the
+// default value of these fields to the next value of the sequence. The
+// returned function resets the default values.
+func (m spannerPostgresMigrator) createSequences(values ...interface{}) (func(), error) {
+	var fields []*schema.Field
+	reset := func() {
+		for _, f := range fields {
+			f.DefaultValue = ""
+		}
+	}
+	for _, value := range values {
+		if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+			if stmt.Schema == nil {
+				return nil
+			}
+			for _, f := range stmt.Schema.Fields {
+				sequence, ok, err := spannergorm.NamedSequence(f)
+				if err != nil {
+					return err
+				}
+				if !ok || f.DefaultValue != "" || f.DefaultValueInterface != nil {
+					continue
+				}
+				if err := m.CreateSequence(sequence); err != nil {
+					return err
+				}
+				f.DefaultValue = "nextval('" + strings.ReplaceAll(sequence.Name, "'", "''") + "')"
+				fields = append(fields, f)
+			}
+			return nil
+		}); err != nil {
+			return reset, err
+		}
+	}
+	return reset, nil
+}
+
+// migrateSequences creates or alters the sequences of an existing table if
+// the sequences do not exist or if their options differ from the options in
+// the model.
+func (m spannerPostgresMigrator) migrateSequences(value interface{}) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		for _, f := range stmt.Schema.Fields {
+			sequence, ok, err := spannergorm.NamedSequence(f)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			existing, err := m.getSequences(sequence.Name)
+			if err != nil {
+				return err
+			}
+			if len(existing) == 0 {
+				if err := m.CreateSequence(sequence); err != nil {
+					return err
+				}
+			} else if !sequence.EqualOptions(existing[0]) {
+				if err := m.AlterSequence(sequence); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+var errProtoBundleNotSupported = errors.New("proto bundles are not supported by Spanner PostgreSQL databases")
+
+func (m spannerPostgresMigrator) CreateProtoBundle(...protoreflect.Descriptor) error {
+	return errProtoBundleNotSupported
+}
+
+func (m spannerPostgresMigrator) AlterProtoBundle(_, _ []protoreflect.Descriptor, _ []string) error {
+	return errProtoBundleNotSupported
+}
+
+func (m spannerPostgresMigrator) GetProtoBundle() ([]string, error) {
+	return nil, errProtoBundleNotSupported
+}
+
 func (m spannerPostgresMigrator) GetTables() (tableList []string, err error) {
 	currentSchema, _ := m.CurrentSchema(m.DB.Statement, "")
 	return tableList, m.queryRaw("SELECT table_name FROM information_schema.tables WHERE table_schema = ? AND table_type = ?", currentSchema, "BASE TABLE").Scan(&tableList).Error
@@ -166,7 +538,85 @@ func (m spannerPostgresMigrator) HasTable(value interface{}) bool {
 	return count > 0
 }
 
+func (m spannerPostgresMigrator) CreateSchema(name string) error {
+	return m.DB.Exec("CREATE SCHEMA IF NOT EXISTS ?", clause.Table{Name: name}).Error
+}
+
+func (m spannerPostgresMigrator) DropSchema(name string) error {
+	return m.DB.Exec("DROP SCHEMA ?", clause.Table{Name: name}).Error
+}
+
+func (m spannerPostgresMigrator) HasSchema(name string) bool {
+	var count int64
+	m.queryRaw("SELECT count(*) FROM information_schema.schemata WHERE schema_name = ?", name).Scan(&count)
+	return count > 0
+}
+
+// createSchemas creates the named schemas of the given models that do not
+// already exist.
+func (m spannerPostgresMigrator) createSchemas(values ...interface{}) error {
+	for _, value := range values {
+		if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+			if currentSchema, _ := m.CurrentSchema(stmt, stmt.Table); currentSchema != "public" && !gormutil.SchemaCreated(m.DB, currentSchema.(string)) && !m.HasSchema(currentSchema.(string)) {
+				return m.CreateSchema(currentSchema.(string))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m spannerPostgresMigrator) tableOf(value interface{}) (interface{}, error) {
+	if v, ok := value.(string); ok {
+		return clause.Table{Name: v}, nil
+	}
+	stmt := &gorm.Statement{DB: m.DB}
+	if err := stmt.Parse(value); err != nil {
+		return nil, err
+	}
+	return m.CurrentTable(stmt), nil
+}
+
+func (m spannerPostgresMigrator) RenameTableWithSynonym(oldName, newName interface{}) error {
+	oldTable, err := m.tableOf(oldName)
+	if err != nil {
+		return err
+	}
+	newTable, err := m.tableOf(newName)
+	if err != nil {
+		return err
+	}
+	return m.DB.Exec("ALTER TABLE ? RENAME TO ?, ADD SYNONYM ?", oldTable, newTable, oldTable).Error
+}
+
+func (m spannerPostgresMigrator) AddSynonym(value interface{}, synonym string) error {
+	table, err := m.tableOf(value)
+	if err != nil {
+		return err
+	}
+	return m.DB.Exec("ALTER TABLE ? ADD SYNONYM ?", table, clause.Table{Name: synonym}).Error
+}
+
+func (m spannerPostgresMigrator) DropSynonym(value interface{}, synonym string) error {
+	table, err := m.tableOf(value)
+	if err != nil {
+		return err
+	}
+	return m.DB.Exec("ALTER TABLE ? DROP SYNONYM ?", table, clause.Table{Name: synonym}).Error
+}
+
 func (m spannerPostgresMigrator) CreateTable(values ...interface{}) (err error) {
+	if err := m.createSchemas(values...); err != nil {
+		return err
+	}
+	resetDefaultValues, err := m.createSequences(values...)
+	// Reset the default values to nothing after finishing migration.
+	defer resetDefaultValues()
+	if err != nil {
+		return err
+	}
 	if !m.autoAddPrimaryKey {
 		return m.Migrator.CreateTable(values...)
 	}
@@ -279,7 +729,7 @@ func (m spannerPostgresMigrator) dropTableIndexes(tx *gorm.DB, stmt *gorm.Statem
 		if err := rows.Scan(&name); err != nil {
 			return err
 		}
-		if err := m.DropIndex(curTable, name); err != nil {
+		if err := m.DropIndex(stmt.Table, name); err != nil {
 			return err
 		}
 	}
@@ -289,6 +739,22 @@ func (m spannerPostgresMigrator) dropTableIndexes(tx *gorm.DB, stmt *gorm.Statem
 	return nil
 }
 
+func (m spannerPostgresMigrator) DropIndex(value interface{}, name string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema != nil {
+			if idx := stmt.Schema.LookIndex(name); idx != nil {
+				name = idx.Name
+			}
+		}
+		// Indexes are created in the same schema as the table.
+		if currentSchema, _ := m.CurrentSchema(stmt, stmt.Table); currentSchema != "public" && !strings.Contains(name, ".") {
+			name = currentSchema.(string) + "." + name
+		}
+
+		return m.DB.Exec("DROP INDEX ?", clause.Column{Name: name}).Error
+	})
+}
+
 func (m spannerPostgresMigrator) HasIndex(value interface{}, name string) bool {
 	var count int64
 	if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
@@ -494,7 +960,7 @@ func (m spannerPostgresMigrator) ColumnTypes(value interface{}) (columnTypes []g
 						// Handle array type: _text -> text[] , _int4 -> integer[]
 						// Not support array size limits and array size limits because:
 						// https://www.postgresql.org/docs/current/arrays.html#ARRAYS-DECLARATION
-						if strings.HasPrefix(mc.DataTypeValue.String, "_") {
+						if strings.HasPrefix(mc.DataTypeValue.String, "_") || strings.HasSuffix(dataType, "[]") {
 							mc.DataTypeValue = sql.NullString{String: dataType, Valid: true}
 						}
 						break
@@ -514,6 +980,17 @@ func (m spannerPostgresMigrator) ColumnTypes(value interface{}) (columnTypes []g
 	return
 }
 
+// GetTypeAliases returns the aliases of the given database type. Array
+// types are returned by Spanner with the full name of the element type, for
+// example `double precision[]`, while the DDL for array columns normally uses
+// the short name, for example `float8[]`.
+func (m spannerPostgresMigrator) GetTypeAliases(databaseTypeName string) []string {
+	if aliases := arrayTypeAliases(databaseTypeName); aliases != nil {
+		return aliases
+	}
+	return m.Migrator.GetTypeAliases(databaseTypeName)
+}
+
 func (m spannerPostgresMigrator) MigrateColumn(value interface{}, field *schema.Field, columnType gorm.ColumnType) error {
 	// Do not migrate primary key fields.
 	if !field.PrimaryKey {
@@ -573,7 +1050,7 @@ func (m spannerPostgresMigrator) AlterColumn(value interface{}, field string) er
 						}
 					} else if field.AutoIncrement && !filedColumnAutoIncrement { // create
 						serialDatabaseType, _ := getSerialDatabaseType(fileType.SQL)
-						if err := m.CreateSequence(m.DB, stmt, field, serialDatabaseType); err != nil {
+						if err := m.Migrator.CreateSequence(m.DB, stmt, field, serialDatabaseType); err != nil {
 							return err
 						}
 					} else if !field.AutoIncrement && filedColumnAutoIncrement { // delete
@@ -668,7 +1145,7 @@ func (m spannerPostgresMigrator) modifyColumn(stmt *gorm.Statement, field *schem
 func (m spannerPostgresMigrator) isColumnGenerated(value interface{}, field string) bool {
 	var count int64
 	_ = m.RunWithValue(value, func(stmt *gorm.Statement) error {
-		currentSchema := "public"
+		currentSchema, curTable := m.CurrentSchema(stmt, stmt.Table)
 		name := field
 		if field := stmt.Schema.LookUpField(field); field != nil {
 			name = field.DBName
@@ -676,7 +1153,7 @@ func (m spannerPostgresMigrator) isColumnGenerated(value interface{}, field stri
 
 		return m.DB.Raw(
 			"SELECT count(*) FROM INFORMATION_SCHEMA.columns WHERE table_schema = ? AND table_name = ? AND column_name = ? AND generation_expression IS NOT NULL",
-			currentSchema, stmt.Table, name,
+			currentSchema, curTable, name,
 		).Row().Scan(&count)
 	})
 
@@ -742,6 +1219,7 @@ INNER JOIN
 WHERE
     ic.ordinal_position is not null
 AND i.spanner_is_managed = 'NO'
+AND i.table_schema = ?
 AND i.table_name = ?
 ORDER BY i.table_schema, i.table_name, i.index_name, ic.ordinal_position
 `
@@ -751,7 +1229,8 @@ func (m spannerPostgresMigrator) GetIndexes(value interface{}) ([]gorm.Index, er
 
 	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
 		result := make([]*postgres.Index, 0)
-		scanErr := m.queryRaw(indexSql, stmt.Table).Scan(&result).Error
+		currentSchema, curTable := m.CurrentSchema(stmt, stmt.Table)
+		scanErr := m.queryRaw(indexSql, currentSchema, curTable).Scan(&result).Error
 		if scanErr != nil {
 			return scanErr
 		}
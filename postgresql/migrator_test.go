@@ -16,12 +16,17 @@ package spannerpg
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"cloud.google.com/go/longrunning/autogen/longrunningpb"
 	"cloud.google.com/go/spanner"
 	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	spannergorm "github.com/googleapis/go-gorm-spanner"
 	"github.com/googleapis/go-sql-spanner/testutil"
 	"google.golang.org/api/option"
 	"google.golang.org/protobuf/proto"
@@ -141,6 +146,193 @@ func TestDisableAutoMigrateBatching(t *testing.T) {
 	}
 }
 
+func TestMigrateDefaultSchema(t *testing.T) {
+	t.Parallel()
+
+	server, _, serverTeardown := setupMockedTestServer(t)
+	dialector := NewWithSpannerConfig(postgres.Config{
+		DSN: fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true;%s", server.Address, ""),
+	}, SpannerConfig{
+		DefaultSchema: "sales",
+	})
+	db, server, teardown := setupTestGormConnectionWithDialector(t, server, serverTeardown, dialector)
+	defer teardown()
+
+	statements, err := db.Migrator().(spannergorm.SpannerMigrator).AutoMigrateDryRun(&singer{}, &album{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, w := range []string{
+		`CREATE SCHEMA IF NOT EXISTS "sales"`,
+		`CREATE TABLE "sales"."singers" ("id" serial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"first_name" text,"last_name" text,"full_name" text,"active" boolean,PRIMARY KEY ("id"))`,
+		`CREATE INDEX IF NOT EXISTS "idx_sales_singers_deleted_at" ON "sales"."singers" ("deleted_at")`,
+		`CREATE TABLE "sales"."albums" ("id" serial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"title" text,"rating" numeric,"singer_id" int,PRIMARY KEY ("id"),CONSTRAINT "fk_sales_albums_singer" FOREIGN KEY ("singer_id") REFERENCES "sales"."singers"("id"))`,
+		`CREATE INDEX IF NOT EXISTS "idx_sales_albums_deleted_at" ON "sales"."albums" ("deleted_at")`,
+	} {
+		if i >= len(statements) {
+			t.Fatalf("missing statement %d: %s", i, w)
+		}
+		if g := statements[i].SQL; g != w {
+			t.Fatalf("%d: statement mismatch\n Got: %s\nWant: %s", i, g, w)
+		}
+	}
+	if g, w := len(statements), 5; g != w {
+		t.Fatalf("statement count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+type activeSinger struct {
+	ID       uint
+	FullName string
+}
+
+func (activeSinger) ViewQuery(db *gorm.DB) *gorm.DB {
+	return db.Model(&singer{}).Select("id", "full_name").Where("active = ?", true)
+}
+
+func TestAutoMigrateView(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	statements, err := db.Migrator().(spannergorm.SpannerMigrator).AutoMigrateDryRun(&activeSinger{}, &singer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := len(statements), 3; g != w {
+		t.Fatalf("statement count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	// Views are created after all tables.
+	if g, w := statements[2].SQL,
+		`CREATE OR REPLACE VIEW "active_singers" SQL SECURITY INVOKER AS `+
+			`SELECT "id","full_name" FROM "singers" WHERE active = true AND "singers"."deleted_at" IS NULL`; g != w {
+		t.Fatalf("create view statement text mismatch\n Got: %s\nWant: %s", g, w)
+	}
+}
+
+func TestViewIsReadOnly(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	if err := db.Create(&activeSinger{ID: 1, FullName: "Alice"}).Error; !errors.Is(err, spannergorm.ErrViewIsReadOnly) {
+		t.Fatalf("create error mismatch\n Got: %v\nWant: %v", err, spannergorm.ErrViewIsReadOnly)
+	}
+	if err := db.Delete(&activeSinger{ID: 1}).Error; !errors.Is(err, spannergorm.ErrViewIsReadOnly) {
+		t.Fatalf("delete error mismatch\n Got: %v\nWant: %v", err, spannergorm.ErrViewIsReadOnly)
+	}
+}
+
+func TestHasView(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	if err := server.TestSpanner.PutStatementResult("SELECT count(*) FROM information_schema.views WHERE table_schema = $1 AND table_name = $2", &testutil.StatementResult{
+		Type:      testutil.StatementResultResultSet,
+		ResultSet: testutil.CreateSelect1ResultSet(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// HasView should support both unqualified names and names that include
+	// the schema.
+	for _, name := range []string{"active_singers", "music.active_singers"} {
+		if !db.Migrator().(spannergorm.SpannerMigrator).HasView(name) {
+			t.Fatalf("%s: HasView returned false", name)
+		}
+	}
+}
+
+type grantedSinger struct {
+	ID       int64
+	FullName string
+}
+
+func (grantedSinger) TableGrants() []spannergorm.TableGrant {
+	return []spannergorm.TableGrant{
+		{Privilege: "SELECT", Role: "analyst", Columns: []string{"ID", "full_name"}},
+		{Privilege: "UPDATE", Role: "admin"},
+	}
+}
+
+func TestAutoMigrateGrants(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	statements, err := db.Migrator().(spannergorm.SpannerMigrator).AutoMigrateDryRun(&grantedSinger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, statement := range statements[len(statements)-4:] {
+		got = append(got, statement.SQL)
+	}
+	want := []string{
+		`CREATE ROLE "analyst"`,
+		`GRANT SELECT("id","full_name") ON TABLE "granted_singers" TO "analyst"`,
+		`CREATE ROLE "admin"`,
+		`GRANT UPDATE ON TABLE "granted_singers" TO "admin"`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("statements mismatch\n Got: %v\nWant: %v", got, want)
+	}
+}
+
+func TestDatabaseRoleRequiresDSN(t *testing.T) {
+	t.Parallel()
+
+	server, _, serverTeardown := setupMockedTestServer(t)
+	defer serverTeardown()
+	sqlDB, err := sql.Open("spanner", fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true", server.Address))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+	_, err = gorm.Open(NewWithSpannerConfig(postgres.Config{Conn: sqlDB}, SpannerConfig{DatabaseRole: "analyst"}), &gorm.Config{})
+	if !errors.Is(err, spannergorm.ErrDatabaseRoleRequiresDSN) {
+		t.Fatalf("error mismatch\n Got: %v\nWant: %v", err, spannergorm.ErrDatabaseRoleRequiresDSN)
+	}
+}
+
+func TestDatabaseName(t *testing.T) {
+	dialector := New(postgres.Config{DSN: "localhost:9010/projects/p/instances/i/databases/d?useplaintext=true"}).(*Dialector)
+	if g, w := dialector.DatabaseName(), "projects/p/instances/i/databases/d"; g != w {
+		t.Fatalf("database name mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	dialector = New(postgres.Config{}).(*Dialector)
+	if g, w := dialector.DatabaseName(), ""; g != w {
+		t.Fatalf("database name mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestRequestOriginRequestTag(t *testing.T) {
+	t.Parallel()
+
+	server, _, serverTeardown := setupMockedTestServer(t)
+	db, _, teardown := setupTestGormConnectionWithDialector(t, server, serverTeardown, NewWithSpannerConfig(postgres.Config{
+		DSN: fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true", server.Address),
+	}, SpannerConfig{
+		RequestOrigin: &spannergorm.RequestOriginConfig{Service: "singers", Annotation: spannergorm.AnnotateWithRequestTag},
+	}))
+	defer teardown()
+
+	// The request options in front of the parameters must not be included
+	// in the numbering of the parameters.
+	var singers []singer
+	stmt := db.Session(&gorm.Session{DryRun: true}).Where("last_name = ? AND id > ?", "Allison", 10).Find(&singers).Statement
+	if g, w := stmt.SQL.String(), `SELECT * FROM "singers" WHERE (last_name = $1 AND id > $2) AND "singers"."deleted_at" IS NULL`; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := db.Dialector.Explain(stmt.SQL.String(), stmt.Vars...), `SELECT * FROM "singers" WHERE (last_name = 'Allison' AND id > 10) AND "singers"."deleted_at" IS NULL`; g != w {
+		t.Fatalf("explain mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
 func TestMigratorError(t *testing.T) {
 	t.Parallel()
 
@@ -220,3 +412,22 @@ func setupMockedTestServerWithConfigAndClientOptions(t *testing.T, config spanne
 		serverTeardown()
 	}
 }
+
+func getLastSqlRequest(server *testutil.MockedSpannerInMemTestServer) *spannerpb.ExecuteSqlRequest {
+	var last *spannerpb.ExecuteSqlRequest
+loop:
+	for {
+		select {
+		case req := <-server.TestSpanner.ReceivedRequests():
+			if sqlRequest, ok := req.(*spannerpb.ExecuteSqlRequest); ok {
+				last = sqlRequest
+			}
+		default:
+			break loop
+		}
+	}
+	if last == nil {
+		return &spannerpb.ExecuteSqlRequest{}
+	}
+	return last
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spannerpg
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	spannergorm "github.com/googleapis/go-gorm-spanner"
+	"github.com/googleapis/go-sql-spanner/testutil"
+	"gorm.io/driver/postgres"
+)
+
+type product struct {
+	ID       int64 `gorm:"primaryKey;autoIncrement:false"`
+	Price    spannergorm.Numeric
+	Discount string `gorm:"type:numeric;precision:4;scale:2"`
+}
+
+func TestNumeric(t *testing.T) {
+	t.Parallel()
+
+	server, _, serverTeardown := setupMockedTestServer(t)
+	server.SetupSelectDialectResult(databasepb.DatabaseDialect_POSTGRESQL)
+	db, server, teardown := setupTestGormConnectionWithDialector(t, server, serverTeardown, New(postgres.Config{
+		DSN: fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true", server.Address),
+	}))
+	defer teardown()
+
+	statements, err := db.Migrator().(spannergorm.SpannerMigrator).AutoMigrateDryRun(&product{})
+	if err != nil {
+		t.Fatalf("failed to run AutoMigrateDryRun: %v", err)
+	}
+	if g, w := statements[0].SQL, `CREATE TABLE "products" ("id" int,"price" numeric,"discount" numeric,PRIMARY KEY ("id"))`; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+
+	insertSql := `INSERT INTO "products" ("id","price","discount") VALUES ($1,$2,$3)`
+	_ = server.TestSpanner.PutStatementResult(insertSql, &testutil.StatementResult{
+		Type:        testutil.StatementResultUpdateCount,
+		UpdateCount: 1,
+	})
+	// PostgreSQL numeric values are not limited to 38 digits.
+	price, err := spannergorm.ParseNumeric("1234567890123456789012345678901234567890.0123456789")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&product{ID: 1, Price: price, Discount: "0.25"}).Error; err != nil {
+		t.Fatalf("failed to create product: %v", err)
+	}
+	req := getLastSqlRequest(server)
+	if g, w := req.ParamTypes["p2"].GetTypeAnnotation(), spannerpb.TypeAnnotationCode_PG_NUMERIC; g != w {
+		t.Fatalf("type annotation mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.Params.Fields["p2"].GetStringValue(), "1234567890123456789012345678901234567890.0123456789"; g != w {
+		t.Fatalf("param value mismatch\n Got: %v\nWant: %v", g, w)
+	}
+
+	// The precision and scale tags are validated.
+	if err := db.Create(&product{ID: 2, Discount: "100"}).Error; !errors.Is(err, spannergorm.ErrNumericOutOfRange) {
+		t.Fatalf("error mismatch\n Got: %v\nWant: %v", err, spannergorm.ErrNumericOutOfRange)
+	}
+	// Values that cannot be represented exactly as a decimal number are not rounded.
+	if err := db.Create(&product{ID: 3, Price: spannergorm.NewNumeric(big.NewRat(1, 3)), Discount: "0.25"}).Error; !errors.Is(err, spannergorm.ErrNumericOutOfRange) {
+		t.Fatalf("error mismatch\n Got: %v\nWant: %v", err, spannergorm.ErrNumericOutOfRange)
+	}
+	if _, err := (NumericArray{*big.NewRat(1, 8), *big.NewRat(1, 3)}).Value(); !errors.Is(err, spannergorm.ErrNumericOutOfRange) {
+		t.Fatalf("array error mismatch\n Got: %v\nWant: %v", err, spannergorm.ErrNumericOutOfRange)
+	}
+}
+
+type measurement struct {
+	ID     int64 `gorm:"primaryKey;autoIncrement:false"`
+	Weight float32
+	Height float64 `gorm:"type:float8"`
+	Amount float64 `gorm:"type:numeric"`
+}
+
+func TestFloatDataTypes(t *testing.T) {
+	t.Parallel()
+
+	server, _, serverTeardown := setupMockedTestServer(t)
+	server.SetupSelectDialectResult(databasepb.DatabaseDialect_POSTGRESQL)
+	db, _, teardown := setupTestGormConnectionWithDialector(t, server, serverTeardown, New(postgres.Config{
+		DSN: fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true", server.Address),
+	}))
+	defer teardown()
+
+	statements, err := db.Migrator().(spannergorm.SpannerMigrator).AutoMigrateDryRun(&measurement{})
+	if err != nil {
+		t.Fatalf("failed to run AutoMigrateDryRun: %v", err)
+	}
+	// Float fields are created as numeric columns, unless the type is set
+	// with a tag.
+	if g, w := statements[0].SQL, `CREATE TABLE "measurements" ("id" int,"weight" numeric,"height" float8,"amount" numeric,PRIMARY KEY ("id"))`; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spannerpg
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	spannergorm "github.com/googleapis/go-gorm-spanner"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type pageTrack struct {
+	AlbumID     int64 `gorm:"primaryKey;autoIncrement:false"`
+	TrackNumber int64 `gorm:"primaryKey;autoIncrement:false"`
+	Title       string
+}
+
+func TestPaginate(t *testing.T) {
+	t.Parallel()
+
+	server, _, serverTeardown := setupMockedTestServer(t)
+	server.SetupSelectDialectResult(databasepb.DatabaseDialect_POSTGRESQL)
+	db, _, teardown := setupTestGormConnectionWithDialector(t, server, serverTeardown, New(postgres.Config{
+		DSN: fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true", server.Address),
+	}))
+	defer teardown()
+
+	var sql string
+	dryRun := db.Session(&gorm.Session{DryRun: true})
+	dryRun.Callback().Query().After("gorm:query").Register("test:capture_sql", func(tx *gorm.DB) {
+		sql = tx.Statement.SQL.String()
+	})
+	var tracks []pageTrack
+	token := base64.RawURLEncoding.EncodeToString([]byte(`{"t":"page_tracks","c":["album_id","track_number"],"v":[1,2]}`))
+	if _, err := spannergorm.Paginate(dryRun, &tracks, spannergorm.Cursor{
+		Token: token,
+		Limit: 10,
+		Index: "idx_tracks",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	want := `SELECT * FROM "page_tracks" /*@ FORCE_INDEX=idx_tracks */ WHERE ` +
+		`"page_tracks"."album_id" >= $1 AND ("page_tracks"."album_id" > $2 OR ("page_tracks"."album_id" = $3 AND "page_tracks"."track_number" > $4)) ` +
+		`ORDER BY "page_tracks"."album_id","page_tracks"."track_number" LIMIT $5`
+	if g, w := sql, want; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
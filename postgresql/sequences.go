@@ -0,0 +1,40 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spannerpg
+
+import (
+	"gorm.io/gorm"
+)
+
+// NextSequenceValues returns the next n values of the given sequence in a
+// single round-trip. Use this to pre-allocate primary key values on the
+// client. The values are fetched in the current transaction if db is in a
+// transaction, and otherwise in a new read/write transaction.
+func NextSequenceValues(db *gorm.DB, sequence string, n int) ([]int64, error) {
+	values := make([]int64, 0, n)
+	if n <= 0 {
+		return values, nil
+	}
+	query := func(tx *gorm.DB) error {
+		return tx.Raw(
+			"SELECT nextval(?) FROM unnest(?::bigint[])",
+			sequence, make([]int64, n),
+		).Scan(&values).Error
+	}
+	if _, ok := db.Statement.ConnPool.(gorm.TxCommitter); ok {
+		return values, query(db)
+	}
+	return values, db.Transaction(query)
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spannerpg
+
+import (
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	spannergorm "github.com/googleapis/go-gorm-spanner"
+	"github.com/googleapis/go-sql-spanner/testutil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type ticket struct {
+	ID   int64 `gorm:"primarykey" gorm_sequence_name:"ticket_seq" gorm_sequence_skip_range_min:"1" gorm_sequence_skip_range_max:"1000" gorm_sequence_start_with_counter:"5000"`
+	Name string
+}
+
+const getSequencesSql = "SELECT sequence_schema, sequence_name, sequence_kind, skip_range_min, skip_range_max, counter_start_value " +
+	"FROM information_schema.sequences "
+
+func TestCreateSequenceWithOptions(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	statements, err := db.Migrator().(spannergorm.SpannerMigrator).AutoMigrateDryRun(&ticket{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := len(statements), 2; g != w {
+		t.Fatalf("statement count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := statements[0].SQL,
+		`CREATE SEQUENCE IF NOT EXISTS "ticket_seq" BIT_REVERSED_POSITIVE SKIP RANGE 1 1000 START COUNTER WITH 5000`; g != w {
+		t.Fatalf("create sequence statement text mismatch\n Got: %s\nWant: %s", g, w)
+	}
+	if g, w := statements[1].SQL,
+		`CREATE TABLE "tickets" ("id" bigint DEFAULT nextval('ticket_seq'),"name" text,PRIMARY KEY ("id"))`; g != w {
+		t.Fatalf("create table statement text mismatch\n Got: %s\nWant: %s", g, w)
+	}
+}
+
+func TestMigrateSequenceOptionDrift(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+	anyProto, err := anypb.New(&emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.TestDatabaseAdmin.SetResps([]proto.Message{
+		&longrunningpb.Operation{
+			Name:   "test-operation",
+			Done:   true,
+			Result: &longrunningpb.Operation_Response{Response: anyProto},
+		},
+	})
+	// The existing sequence has a different skip range.
+	putSequencesResult(server, getSequencesSql+"WHERE sequence_schema = $1 AND sequence_name = $2 ORDER BY sequence_schema, sequence_name", []*structpb.ListValue{
+		sequenceRow("public", "ticket_seq", "bit_reversed_positive", "1", "100", "5000"),
+	})
+
+	m := db.Migrator().(spannerPostgresMigrator)
+	if err := m.migrateSequences(&ticket{}); err != nil {
+		t.Fatal(err)
+	}
+	requests := server.TestDatabaseAdmin.Reqs()
+	if g, w := len(requests), 1; g != w {
+		t.Fatalf("request count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := requests[0].(*databasepb.UpdateDatabaseDdlRequest).GetStatements()[0],
+		`ALTER SEQUENCE "ticket_seq" SKIP RANGE 1 1000 RESTART COUNTER WITH 5000`; g != w {
+		t.Fatalf("alter sequence statement text mismatch\n Got: %s\nWant: %s", g, w)
+	}
+}
+
+func TestGetSequences(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+	putSequencesResult(server, getSequencesSql+"WHERE sequence_schema NOT IN ('information_schema', 'spanner_sys', 'pg_catalog') ORDER BY sequence_schema, sequence_name", []*structpb.ListValue{
+		sequenceRow("public", "singers_seq", "bit_reversed_positive", "", "", ""),
+		sequenceRow("sales", "orders_seq", "bit_reversed_positive", "1", "1000", "100"),
+	})
+
+	sequences, err := db.Migrator().(spannergorm.SpannerMigrator).GetSequences()
+	if err != nil {
+		t.Fatal(err)
+	}
+	skipRangeMin, skipRangeMax, startWithCounter := int64(1), int64(1000), int64(100)
+	if g, w := sequences, []spannergorm.Sequence{
+		{Name: "singers_seq", Kind: "bit_reversed_positive"},
+		{Name: "sales.orders_seq", Kind: "bit_reversed_positive", SkipRangeMin: &skipRangeMin, SkipRangeMax: &skipRangeMax, StartWithCounter: &startWithCounter},
+	}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("sequences mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+// sequenceRow returns a row of information_schema.sequences. Empty values
+// are returned as NULL.
+func sequenceRow(values ...string) *structpb.ListValue {
+	row := &structpb.ListValue{}
+	for _, v := range values {
+		if v == "" {
+			row.Values = append(row.Values, structpb.NewNullValue())
+		} else {
+			row.Values = append(row.Values, structpb.NewStringValue(v))
+		}
+	}
+	return row
+}
+
+func putSequencesResult(server *testutil.MockedSpannerInMemTestServer, sql string, rows []*structpb.ListValue) {
+	_ = server.TestSpanner.PutStatementResult(sql, &testutil.StatementResult{
+		Type: testutil.StatementResultResultSet,
+		ResultSet: &spannerpb.ResultSet{
+			Metadata: &spannerpb.ResultSetMetadata{
+				RowType: &spannerpb.StructType{
+					Fields: []*spannerpb.StructType_Field{
+						{Type: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}, Name: "sequence_schema"},
+						{Type: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}, Name: "sequence_name"},
+						{Type: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}, Name: "sequence_kind"},
+						{Type: &spannerpb.Type{Code: spannerpb.TypeCode_INT64}, Name: "skip_range_min"},
+						{Type: &spannerpb.Type{Code: spannerpb.TypeCode_INT64}, Name: "skip_range_max"},
+						{Type: &spannerpb.Type{Code: spannerpb.TypeCode_INT64}, Name: "counter_start_value"},
+					},
+				},
+			},
+			Rows: rows,
+		},
+	})
+}
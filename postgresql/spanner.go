@@ -20,8 +20,11 @@ import (
 	"fmt"
 	"math"
 	"runtime"
+	"strconv"
 
-	_ "github.com/googleapis/go-sql-spanner"
+	spannergorm "github.com/googleapis/go-gorm-spanner"
+	"github.com/googleapis/go-gorm-spanner/internal/gormutil"
+	spannerdriver "github.com/googleapis/go-sql-spanner"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -52,6 +55,42 @@ type SpannerConfig struct {
 	// primary key. This flag is primarily intended for testing, as some gorm tests assumes that databases support
 	// tables without a primary key. Spanner does not support this.
 	AutoAddPrimaryKey bool
+
+	// DefaultSchema is the named schema that is used for all models whose table
+	// name is generated by gorm. Tables are created in and read from the public
+	// schema if no value has been set. Models that implement the schema.Tabler
+	// interface must include the schema in the table name, for example
+	// `sales.orders`.
+	DefaultSchema string
+
+	// DatabaseRole is the database role that is used for all statements on
+	// connections that are opened by this dialector. The privileges of the
+	// IAM principal are used if no role has been set. This option can only
+	// be used in combination with DSN. Use spannergorm.WithDatabaseRole to execute
+	// statements with a different role.
+	DatabaseRole string
+
+	// InListThreshold is the minimum number of values in an IN list for the
+	// list to be sent to Spanner as a single array parameter. This ensures
+	// that the SQL string of the statement does not depend on the number of
+	// values in the list, which allows Spanner to cache the query plan. IN
+	// lists are not rewritten if no value has been set.
+	InListThreshold int
+
+	// UUIDAsString stores UUID columns as varchar(36) instead of using the
+	// native uuid data type. Use this option for databases that store UUIDs
+	// as strings.
+	UUIDAsString bool
+
+	// QueryLog replaces the logger of the gorm database with a
+	// spannergorm.QueryLogger with this configuration, if it has been set.
+	QueryLog *spannergorm.QueryLogConfig
+
+	// RequestOrigin annotates all statements that are generated by gorm with
+	// the service, route, caller and model of the statement, if it has been
+	// set. Use spannergorm.WithRequestOrigin to override the origin for a
+	// context.
+	RequestOrigin *spannergorm.RequestOriginConfig
 }
 
 func Open(dsn string) gorm.Dialector {
@@ -68,18 +107,82 @@ func NewWithSpannerConfig(config postgres.Config, spannerConfig SpannerConfig) g
 	return &Dialector{Dialector: postgres.Dialector{Config: &config}, SpannerConfig: spannerConfig}
 }
 
+// DatabaseName returns the fully qualified name of the database in the DSN of
+// the dialector, or an empty string if the dialector does not use a DSN.
+func (dialector Dialector) DatabaseName() string {
+	if dialector.Config == nil {
+		return ""
+	}
+	return spannergorm.DatabaseNameFromDSN(dialector.Config.DSN)
+}
+
+// DataSource returns the driver name and the DSN that the dialector uses to
+// open connections. The DSN is empty if the dialector uses an existing
+// connection.
+func (dialector Dialector) DataSource() (driverName, dsn string) {
+	if dialector.Config == nil || dialector.Conn != nil {
+		return "", ""
+	}
+	return dialector.DriverName, dialector.DSN
+}
+
+// UUIDAsString returns true if UUID columns are stored as strings.
+func (dialector Dialector) UUIDAsString() bool {
+	return dialector.SpannerConfig.UUIDAsString
+}
+
 func (dialector Dialector) Name() string {
 	return "postgres-spanner"
 }
 
+// BindVarTo writes the placeholder of the next parameter of the statement.
+// spannerdriver.ExecOptions that are added in front of the parameters of the
+// statement are not parameters, and are skipped in the numbering.
+func (dialector Dialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, v interface{}) {
+	if len(stmt.Vars) > 0 && isExecOptions(stmt.Vars[0]) {
+		writer.WriteString("$" + strconv.Itoa(len(stmt.Vars)-1))
+		return
+	}
+	dialector.Dialector.BindVarTo(writer, stmt, v)
+}
+
+func (dialector Dialector) Explain(sql string, vars ...interface{}) string {
+	params := make([]interface{}, 0, len(vars))
+	for _, v := range vars {
+		if !isExecOptions(v) {
+			params = append(params, v)
+		}
+	}
+	return dialector.Dialector.Explain(sql, params...)
+}
+
+func isExecOptions(v interface{}) bool {
+	switch v.(type) {
+	case spannerdriver.ExecOptions, *spannerdriver.ExecOptions:
+		return true
+	}
+	return false
+}
+
 func (dialector Dialector) Initialize(db *gorm.DB) (err error) {
 	if dialector.DriverName == "" {
 		dialector.DriverName = "spanner"
 	}
 
+	if role := dialector.SpannerConfig.DatabaseRole; role != "" {
+		if dialector.DSN == "" || dialector.Conn != nil {
+			return spannergorm.ErrDatabaseRoleRequiresDSN
+		}
+		config := *dialector.Config
+		config.DSN = spannergorm.DSNWithDatabaseRole(config.DSN, role)
+		dialector.Config = &config
+	}
 	if err := postgres.Dialector.Initialize(dialector.Dialector, db); err != nil {
 		return err
 	}
+	if dialector.SpannerConfig.DefaultSchema != "" {
+		db.NamingStrategy = gormutil.SchemaNamer{Namer: db.NamingStrategy, DefaultSchema: dialector.SpannerConfig.DefaultSchema}
+	}
 	// Register an UPDATE callback that will ensure that primary key columns are
 	// never included in the SET clause of the statement.
 	updateCallback := db.Callback().Update()
@@ -89,6 +192,33 @@ func (dialector Dialector) Initialize(db *gorm.DB) (err error) {
 		Register("gorm:spanner:remove_primary_key_from_update", BeforeUpdate); err != nil {
 		return err
 	}
+	if err := spannergorm.RegisterRejectWritesToViews(db); err != nil {
+		return err
+	}
+	if err := db.Callback().Create().Before("gorm:create").Register("gorm:spanner:validate_numerics", spannergorm.ValidateNumerics); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("gorm:spanner:validate_numerics", spannergorm.ValidateNumerics); err != nil {
+		return err
+	}
+	if err := spannergorm.RegisterInListToArray(db, dialector.SpannerConfig.InListThreshold); err != nil {
+		return err
+	}
+	// Register the query callbacks that execute queries with
+	// spannergorm.Profile.
+	if err := spannergorm.RegisterQueryCallback(db); err != nil {
+		return err
+	}
+	if dialector.SpannerConfig.QueryLog != nil {
+		if err := spannergorm.UseQueryLogger(db, *dialector.SpannerConfig.QueryLog); err != nil {
+			return err
+		}
+	}
+	if dialector.SpannerConfig.RequestOrigin != nil {
+		if err := spannergorm.UseRequestOrigin(db, *dialector.SpannerConfig.RequestOrigin); err != nil {
+			return err
+		}
+	}
 	if dialector.SpannerConfig.AutoOrderByPk {
 		queryCallback := db.Callback().Query()
 		if err := queryCallback.
@@ -101,6 +231,7 @@ func (dialector Dialector) Initialize(db *gorm.DB) (err error) {
 	for k, v := range dialector.ClauseBuilders() {
 		db.ClauseBuilders[k] = v
 	}
+	spannergorm.RegisterIntervalReads(db)
 	return nil
 }
 
@@ -252,7 +383,11 @@ func (dialector Dialector) Migrator(db *gorm.DB) gorm.Migrator {
 func (dialector Dialector) DataTypeOf(field *schema.Field) string {
 	switch field.DataType {
 	case schema.Int, schema.Uint:
-		if field.AutoIncrement {
+		if _, ok, _ := spannergorm.NamedSequence(field); ok {
+			// The default value of the column is the next value of the
+			// sequence, see spannerPostgresMigrator.createSequences.
+			return "bigint"
+		} else if field.AutoIncrement {
 			return "serial"
 		} else {
 			return "int"
@@ -261,6 +396,10 @@ func (dialector Dialector) DataTypeOf(field *schema.Field) string {
 		return "numeric"
 	case schema.Time:
 		return "timestamptz"
+	case "numeric", "NUMERIC":
+		return "numeric"
+	case "interval", "INTERVAL":
+		return "interval"
 	default:
 		if field.AutoIncrement {
 			return "serial"
@@ -0,0 +1,62 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spannerpg
+
+import (
+	"fmt"
+	"testing"
+
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	spannergorm "github.com/googleapis/go-gorm-spanner"
+	"gorm.io/driver/postgres"
+)
+
+type uuidUser struct {
+	ID      spannergorm.UUID `gorm:"primaryKey;default:spanner.generate_uuid()"`
+	Name    string
+	Friends spannergorm.UUIDArray
+}
+
+func TestUUID(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		config SpannerConfig
+		want   string
+	}{
+		{
+			want: `CREATE TABLE "uuid_users" ("id" uuid DEFAULT spanner.generate_uuid(),"name" text,"friends" uuid[],PRIMARY KEY ("id"))`,
+		},
+		{
+			config: SpannerConfig{UUIDAsString: true},
+			want:   `CREATE TABLE "uuid_users" ("id" varchar(36) DEFAULT spanner.generate_uuid(),"name" text,"friends" varchar(36)[],PRIMARY KEY ("id"))`,
+		},
+	} {
+		server, _, serverTeardown := setupMockedTestServer(t)
+		server.SetupSelectDialectResult(databasepb.DatabaseDialect_POSTGRESQL)
+		db, _, teardown := setupTestGormConnectionWithDialector(t, server, serverTeardown, NewWithSpannerConfig(postgres.Config{
+			DSN: fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true", server.Address),
+		}, test.config))
+
+		statements, err := db.Migrator().(spannergorm.SpannerMigrator).AutoMigrateDryRun(&uuidUser{})
+		teardown()
+		if err != nil {
+			t.Fatalf("failed to run AutoMigrateDryRun: %v", err)
+		}
+		if g, w := statements[0].SQL, test.want; g != w {
+			t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+		}
+	}
+}
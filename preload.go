@@ -0,0 +1,510 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/googleapis/go-gorm-spanner/internal/gormutil"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+type preloadStrategy int
+
+// ArraySubqueryPreload can be passed as the only argument to Preload to load
+// the association in the same query as the parent records, instead of with
+// a separate query per level. The association is selected with a correlated
+// ARRAY(SELECT AS STRUCT ...) subquery, which is efficient for interleaved
+// tables, as the child rows are stored together with the parent row.
+//
+// Nested associations can be loaded in the same query by also passing
+// ArraySubqueryPreload to the Preload call for the nested association. The
+// parent levels of a nested association are always loaded in the same way.
+//
+// Example:
+//
+//	db.Preload("Albums", spannergorm.ArraySubqueryPreload).
+//	  Preload("Albums.Tracks", spannergorm.ArraySubqueryPreload).
+//	  Find(&singers)
+//
+// ArraySubqueryPreload is only supported for GoogleSQL databases, and for
+// has-one, has-many and belongs-to associations.
+const ArraySubqueryPreload preloadStrategy = 1
+
+// arrayPreloadColumnPrefix is the prefix of the columns that contain the
+// preloaded associations.
+const arrayPreloadColumnPrefix = "_preload_"
+
+var errArrayPreloadConditions = errors.New("ArraySubqueryPreload does not support conditions")
+
+// arrayPreload is an association that is loaded with an ARRAY subquery.
+type arrayPreload struct {
+	relation *schema.Relationship
+	alias    string
+	children []*arrayPreload
+}
+
+func (p *arrayPreload) column() string {
+	return arrayPreloadColumnPrefix + p.relation.Name
+}
+
+// queryWithArrayPreloads executes queries that load associations with
+// ArraySubqueryPreload, or that are executed with Profile. It runs directly
+// before the default gorm query callback, and lets that callback skip the
+// queries that it has executed. Other queries are left to the default gorm
+// query callback.
+func queryWithArrayPreloads(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	preloads, err := arrayPreloads(db.Statement)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+	statsDest := queryStatsDestination(db)
+	if len(preloads) == 0 && statsDest == nil {
+		return
+	}
+
+	callbacks.BuildQuerySQL(db)
+	if db.Error != nil {
+		return
+	}
+	if len(preloads) > 0 {
+		// Add the ARRAY subqueries to the SELECT clause and rebuild the statement.
+		c := db.Statement.Clauses["SELECT"]
+		sel, _ := c.Expression.(clause.Select)
+		exprs := make([]clause.Expression, len(preloads))
+		for i, preload := range preloads {
+			expr := arraySubquery(preload, clause.CurrentTable)
+			expr.SQL = "TO_JSON_STRING(" + expr.SQL + ") AS ?"
+			expr.Vars = append(expr.Vars, clause.Column{Name: preload.column()})
+			exprs[i] = expr
+		}
+		c.Expression = selectWithArrayPreloads{Select: sel, preloads: exprs}
+		db.Statement.Clauses["SELECT"] = c
+		db.Statement.SQL.Reset()
+		db.Statement.Vars = leadingExecOptions(db.Statement.Vars)
+		db.Statement.Build(db.Statement.BuildClauses...)
+	}
+	if db.DryRun || db.Error != nil {
+		return
+	}
+	// The default gorm query callback does not execute statements in dry run
+	// mode. The original configuration is restored by restoreQueryConfig.
+	config := *db.Config
+	config.DryRun = true
+	db.Statement.Settings.Store(queryConfigKey, db.Config)
+	db.Config = &config
+
+	conn, args := db.Statement.ConnPool, db.Statement.Vars
+	if statsDest != nil {
+		conn = gormutil.UnpreparedConnPool(conn)
+		options := execOptionsWithMode(spannerpb.ExecuteSqlRequest_PROFILE)
+		options.QueryOptions.RequestTag = requestTag(args)
+		args = append(slices.Clone(args), options)
+	}
+	rows, err := conn.QueryContext(db.Statement.Context, db.Statement.SQL.String(), args...)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+	defer func() {
+		db.AddError(rows.Close())
+	}()
+	preloadRows := &arrayPreloadRows{Rows: rows, n: len(preloads)}
+	gorm.Scan(preloadRows, db, 0)
+	if db.Statement.Result != nil {
+		db.Statement.Result.RowsAffected = db.RowsAffected
+	}
+	if db.Error != nil {
+		return
+	}
+	if statsDest != nil {
+		if *statsDest, err = readQueryStats(rows); err != nil {
+			db.AddError(err)
+			return
+		}
+	}
+
+	reflectValue := db.Statement.ReflectValue
+	for i, values := range preloadRows.values {
+		var record reflect.Value
+		switch reflectValue.Kind() {
+		case reflect.Slice, reflect.Array:
+			if i >= reflectValue.Len() {
+				return
+			}
+			record = reflect.Indirect(reflectValue.Index(i))
+		case reflect.Struct:
+			if i > 0 {
+				return
+			}
+			record = reflectValue
+		default:
+			return
+		}
+		for j, preload := range preloads {
+			if !values[j].Valid {
+				continue
+			}
+			if err := setArrayPreload(db, record, preload, json.RawMessage(values[j].String)); err != nil {
+				db.AddError(fmt.Errorf("failed to load %s: %w", preload.relation.Name, err))
+				return
+			}
+		}
+	}
+}
+
+// arrayPreloads removes the associations that use ArraySubqueryPreload from
+// the preloads of the given statement, and returns them as a tree. The
+// original preloads are restored by restorePreloads after the other
+// associations have been loaded by gorm.
+func arrayPreloads(stmt *gorm.Statement) ([]*arrayPreload, error) {
+	var names []string
+	for name, args := range stmt.Preloads {
+		if !slices.Contains(args, interface{}(ArraySubqueryPreload)) {
+			continue
+		}
+		if len(args) > 1 {
+			return nil, fmt.Errorf("%s: %w", name, errArrayPreloadConditions)
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if isPostgreSQL(stmt.DB) {
+		return nil, fmt.Errorf("ArraySubqueryPreload is only supported for GoogleSQL databases")
+	}
+	if stmt.Schema == nil {
+		return nil, fmt.Errorf("%w when using preload", gorm.ErrModelValueRequired)
+	}
+	slices.Sort(names)
+	stmt.Settings.Store(originalPreloadsKey, stmt.Preloads)
+	stmt.Preloads = maps.Clone(stmt.Preloads)
+
+	var preloads []*arrayPreload
+	for _, name := range names {
+		delete(stmt.Preloads, name)
+		current, relations, path := &preloads, stmt.Schema.Relationships.Relations, ""
+		for _, relationName := range strings.Split(name, ".") {
+			relation, ok := relations[relationName]
+			if !ok {
+				return nil, fmt.Errorf("%s: unsupported relations for schema %s", name, stmt.Schema.Name)
+			}
+			switch relation.Type {
+			case schema.HasOne, schema.HasMany, schema.BelongsTo:
+			default:
+				return nil, fmt.Errorf("%s: ArraySubqueryPreload does not support %s relations", name, relation.Type)
+			}
+			path += relationName
+			i := slices.IndexFunc(*current, func(p *arrayPreload) bool { return p.relation == relation })
+			if i == -1 {
+				alias := arrayPreloadColumnPrefix + strings.ReplaceAll(path, ".", "__")
+				*current = append(*current, &arrayPreload{relation: relation, alias: alias})
+				i = len(*current) - 1
+			}
+			parent := (*current)[i]
+			// The parent levels of an association are loaded by the ARRAY
+			// subquery, and must not be preloaded by gorm.
+			if args, ok := stmt.Preloads[path]; ok {
+				if len(args) > 0 {
+					return nil, fmt.Errorf("%s: %w", path, errArrayPreloadConditions)
+				}
+				delete(stmt.Preloads, path)
+			}
+			current, relations, path = &parent.children, relation.FieldSchema.Relationships.Relations, path+"."
+		}
+	}
+	// gorm cannot preload a nested association of an association that is
+	// loaded with an ARRAY subquery.
+	for name := range stmt.Preloads {
+		for _, arrayName := range names {
+			if strings.HasPrefix(name, arrayName+".") {
+				return nil, fmt.Errorf("%s: nested associations of %s must also use ArraySubqueryPreload", name, arrayName)
+			}
+		}
+	}
+	return preloads, nil
+}
+
+const (
+	originalPreloadsKey = "gorm:spanner:original_preloads"
+	queryConfigKey      = "gorm:spanner:query_config"
+)
+
+// restoreQueryConfig restores the configuration of a statement that was
+// executed by queryWithArrayPreloads, so the default gorm query callback has
+// skipped it.
+func restoreQueryConfig(db *gorm.DB) {
+	if config, ok := db.Statement.Settings.LoadAndDelete(queryConfigKey); ok {
+		db.Config = config.(*gorm.Config)
+	}
+}
+
+// restorePreloads restores the preloads of a statement that were modified by
+// queryWithArrayPreloads, so the statement can be executed again.
+func restorePreloads(db *gorm.DB) {
+	if preloads, ok := db.Statement.Settings.LoadAndDelete(originalPreloadsKey); ok {
+		db.Statement.Preloads = preloads.(map[string][]interface{})
+	}
+}
+
+// RegisterQueryCallback registers the query callbacks of the Spanner
+// dialects that execute queries with ArraySubqueryPreload and Profile. All
+// other queries are executed by the default gorm query callback. The
+// callbacks are registered automatically by the dialectors.
+//
+// This function can be used for both GoogleSQL-dialect and PostgreSQL-dialect databases.
+func RegisterQueryCallback(db *gorm.DB) error {
+	// The callback must run after all other callbacks that modify the
+	// statement before it is executed.
+	if err := db.Callback().Query().Before("gorm:query").After("*").Register("gorm:spanner:query", queryWithArrayPreloads); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().After("gorm:query").Before("gorm:preload").Register("gorm:spanner:restore_query_config", restoreQueryConfig); err != nil {
+		return err
+	}
+	return db.Callback().Query().After("gorm:preload").Register("gorm:spanner:restore_preloads", restorePreloads)
+}
+
+// arraySubquery returns an ARRAY(SELECT AS STRUCT ...) subquery that selects
+// the records of the given association for the record in the given parent
+// table, including the nested associations.
+func arraySubquery(preload *arrayPreload, parent string) clause.Expr {
+	relation := preload.relation
+	var b strings.Builder
+	vars := []interface{}{clause.Table{Name: preload.alias}}
+	b.WriteString("ARRAY(SELECT AS STRUCT ?.*")
+	for _, child := range preload.children {
+		expr := arraySubquery(child, preload.alias)
+		b.WriteString(", " + expr.SQL + " AS ?")
+		vars = append(vars, expr.Vars...)
+		vars = append(vars, clause.Column{Name: child.column()})
+	}
+	b.WriteString(" FROM ? WHERE ")
+	vars = append(vars, clause.Table{Name: relation.FieldSchema.Table, Alias: preload.alias})
+	for i, ref := range relation.References {
+		if i > 0 {
+			b.WriteString(" AND ")
+		}
+		b.WriteString("? = ?")
+		switch {
+		case ref.OwnPrimaryKey:
+			vars = append(vars,
+				clause.Column{Table: preload.alias, Name: ref.ForeignKey.DBName},
+				clause.Column{Table: parent, Name: ref.PrimaryKey.DBName})
+		case ref.PrimaryValue == "":
+			vars = append(vars,
+				clause.Column{Table: preload.alias, Name: ref.PrimaryKey.DBName},
+				clause.Column{Table: parent, Name: ref.ForeignKey.DBName})
+		default:
+			vars = append(vars, clause.Column{Table: preload.alias, Name: ref.ForeignKey.DBName}, ref.PrimaryValue)
+		}
+	}
+	if deletedAt := softDeleteField(relation.FieldSchema); deletedAt != nil {
+		b.WriteString(" AND ? IS NULL")
+		vars = append(vars, clause.Column{Table: preload.alias, Name: deletedAt.DBName})
+	}
+	b.WriteString(")")
+	return clause.Expr{SQL: b.String(), Vars: vars}
+}
+
+// softDeleteField returns the gorm.DeletedAt field of the given schema, or nil
+// if the schema does not use soft deletes.
+func softDeleteField(s *schema.Schema) *schema.Field {
+	for _, field := range s.Fields {
+		if field.DBName != "" && field.FieldType == reflect.TypeFor[gorm.DeletedAt]() {
+			return field
+		}
+	}
+	return nil
+}
+
+// selectWithArrayPreloads is a SELECT clause with additional columns for the
+// associations that are loaded with an ARRAY subquery.
+type selectWithArrayPreloads struct {
+	clause.Select
+	preloads []clause.Expression
+}
+
+func (s selectWithArrayPreloads) Build(builder clause.Builder) {
+	s.Select.Build(builder)
+	for _, preload := range s.preloads {
+		builder.WriteByte(',')
+		preload.Build(builder)
+	}
+}
+
+// arrayPreloadRows hides the columns with the preloaded associations from
+// gorm, and stores the values of these columns for each row. The rows are
+// returned unchanged if there are no preloaded associations.
+type arrayPreloadRows struct {
+	*sql.Rows
+	n      int
+	values [][]sql.NullString
+}
+
+func (r *arrayPreloadRows) Columns() ([]string, error) {
+	columns, err := r.Rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	return columns[:len(columns)-r.n], nil
+}
+
+func (r *arrayPreloadRows) ColumnTypes() ([]*sql.ColumnType, error) {
+	types, err := r.Rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	return types[:len(types)-r.n], nil
+}
+
+func (r *arrayPreloadRows) Scan(dest ...interface{}) error {
+	values := make([]sql.NullString, r.n)
+	all := slices.Clone(dest)
+	for i := range values {
+		all = append(all, &values[i])
+	}
+	if err := r.Rows.Scan(all...); err != nil {
+		return err
+	}
+	if r.n > 0 {
+		r.values = append(r.values, values)
+	}
+	return nil
+}
+
+// setArrayPreload assigns the association in the given JSON array to the
+// given record.
+func setArrayPreload(db *gorm.DB, record reflect.Value, preload *arrayPreload, b json.RawMessage) error {
+	var elements []json.RawMessage
+	if err := json.Unmarshal(b, &elements); err != nil {
+		return err
+	}
+	relation := preload.relation
+	field := relation.Field.ReflectValueOf(db.Statement.Context, record)
+	fieldType := relation.Field.FieldType
+	newElement := func(raw json.RawMessage) (reflect.Value, error) {
+		element := reflect.New(relation.FieldSchema.ModelType)
+		if err := decodeArrayPreload(db, element.Elem(), preload, raw); err != nil {
+			return reflect.Value{}, err
+		}
+		return element, nil
+	}
+
+	if relation.Type == schema.HasMany {
+		slice := reflect.MakeSlice(relation.Field.IndirectFieldType, 0, len(elements))
+		for _, raw := range elements {
+			element, err := newElement(raw)
+			if err != nil {
+				return err
+			}
+			if slice.Type().Elem().Kind() != reflect.Pointer {
+				element = element.Elem()
+			}
+			slice = reflect.Append(slice, element)
+		}
+		if fieldType.Kind() == reflect.Pointer {
+			p := reflect.New(slice.Type())
+			p.Elem().Set(slice)
+			slice = p
+		}
+		field.Set(slice)
+		return nil
+	}
+	if len(elements) == 0 {
+		return nil
+	}
+	element, err := newElement(elements[0])
+	if err != nil {
+		return err
+	}
+	if fieldType.Kind() != reflect.Pointer {
+		element = element.Elem()
+	}
+	field.Set(element)
+	return nil
+}
+
+// decodeArrayPreload decodes a JSON object that was returned by an ARRAY
+// subquery into the given record.
+func decodeArrayPreload(db *gorm.DB, record reflect.Value, preload *arrayPreload, b json.RawMessage) error {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(b, &object); err != nil {
+		return err
+	}
+	for _, child := range preload.children {
+		if value, ok := object[child.column()]; ok {
+			if err := setArrayPreload(db, record, child, value); err != nil {
+				return fmt.Errorf("failed to load %s: %w", child.relation.Name, err)
+			}
+			delete(object, child.column())
+		}
+	}
+	s := preload.relation.FieldSchema
+	for name, value := range object {
+		field := s.LookUpField(name)
+		if field == nil || field.DBName == "" {
+			continue
+		}
+		err := json.Unmarshal(value, field.ReflectValueOf(db.Statement.Context, record).Addr().Interface())
+		if err == nil {
+			continue
+		}
+		// Use gorm to assign values that cannot be unmarshalled directly, for
+		// example to sql.Scanner types without a JSON representation.
+		v, decodeErr := decodeJSONValue(value)
+		if decodeErr != nil || field.Set(db.Statement.Context, record, v) != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+// decodeJSONValue decodes a JSON value without converting numbers to
+// float64, as that would lose the precision of INT64 values above 2^53.
+// Integer numbers are returned as int64 and other numbers as float64.
+func decodeJSONValue(b json.RawMessage) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	number, ok := v.(json.Number)
+	if !ok {
+		return v, nil
+	}
+	if i, err := number.Int64(); err == nil {
+		return i, nil
+	}
+	return number.Float64()
+}
@@ -0,0 +1,206 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/googleapis/go-sql-spanner/testutil"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type preloadSinger struct {
+	ID     int64 `gorm:"primaryKey;autoIncrement:false"`
+	Name   string
+	Albums []preloadAlbum `gorm:"foreignKey:SingerID"`
+}
+
+type preloadAlbum struct {
+	ID       int64 `gorm:"primaryKey;autoIncrement:false"`
+	SingerID int64
+	Title    string
+	Singer   *preloadSinger  `gorm:"foreignKey:SingerID"`
+	Tracks   []*preloadTrack `gorm:"foreignKey:AlbumID"`
+}
+
+type preloadTrack struct {
+	ID        int64 `gorm:"primaryKey;autoIncrement:false"`
+	AlbumID   int64
+	Title     string `gorm:"column:track_title"`
+	SampleID  sql.NullInt64
+	DeletedAt gorm.DeletedAt
+}
+
+func TestArraySubqueryPreloadSQL(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	for _, test := range []struct {
+		name  string
+		query func(tx *gorm.DB) *gorm.DB
+		want  string
+	}{
+		{
+			name: "has many",
+			query: func(tx *gorm.DB) *gorm.DB {
+				return tx.Preload("Albums", ArraySubqueryPreload).Where("name = ?", "Alice").Find(&[]preloadSinger{})
+			},
+			want: "SELECT *,TO_JSON_STRING(ARRAY(SELECT AS STRUCT `_preload_Albums`.* FROM `preload_albums` `_preload_Albums` " +
+				"WHERE `_preload_Albums`.`singer_id` = `preload_singers`.`id`)) AS `_preload_Albums` FROM `preload_singers` WHERE name = ?",
+		},
+		{
+			name: "nested",
+			query: func(tx *gorm.DB) *gorm.DB {
+				return tx.Preload("Albums", ArraySubqueryPreload).Preload("Albums.Tracks", ArraySubqueryPreload).Find(&[]preloadSinger{})
+			},
+			want: "SELECT *,TO_JSON_STRING(ARRAY(SELECT AS STRUCT `_preload_Albums`.*, " +
+				"ARRAY(SELECT AS STRUCT `_preload_Albums__Tracks`.* FROM `preload_tracks` `_preload_Albums__Tracks` " +
+				"WHERE `_preload_Albums__Tracks`.`album_id` = `_preload_Albums`.`id` AND `_preload_Albums__Tracks`.`deleted_at` IS NULL) AS `_preload_Tracks` " +
+				"FROM `preload_albums` `_preload_Albums` WHERE `_preload_Albums`.`singer_id` = `preload_singers`.`id`)) AS `_preload_Albums` FROM `preload_singers`",
+		},
+		{
+			name: "belongs to",
+			query: func(tx *gorm.DB) *gorm.DB {
+				return tx.Preload("Singer", ArraySubqueryPreload).Find(&[]preloadAlbum{})
+			},
+			want: "SELECT *,TO_JSON_STRING(ARRAY(SELECT AS STRUCT `_preload_Singer`.* FROM `preload_singers` `_preload_Singer` " +
+				"WHERE `_preload_Singer`.`id` = `preload_albums`.`singer_id`)) AS `_preload_Singer` FROM `preload_albums`",
+		},
+	} {
+		stmt := test.query(db.Session(&gorm.Session{DryRun: true})).Statement
+		if stmt.Error != nil {
+			t.Fatalf("%s: %v", test.name, stmt.Error)
+		}
+		if g, w := stmt.SQL.String(), test.want; g != w {
+			t.Errorf("%s: sql mismatch\n Got: %v\nWant: %v", test.name, g, w)
+		}
+	}
+}
+
+func TestArraySubqueryPreload(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	const query = "SELECT *,TO_JSON_STRING(ARRAY(SELECT AS STRUCT `_preload_Albums`.*, " +
+		"ARRAY(SELECT AS STRUCT `_preload_Albums__Tracks`.* FROM `preload_tracks` `_preload_Albums__Tracks` " +
+		"WHERE `_preload_Albums__Tracks`.`album_id` = `_preload_Albums`.`id` AND `_preload_Albums__Tracks`.`deleted_at` IS NULL) AS `_preload_Tracks` " +
+		"FROM `preload_albums` `_preload_Albums` WHERE `_preload_Albums`.`singer_id` = `preload_singers`.`id`)) AS `_preload_Albums` FROM `preload_singers`"
+	_ = server.TestSpanner.PutStatementResult(query, &testutil.StatementResult{
+		Type: testutil.StatementResultResultSet,
+		ResultSet: &spannerpb.ResultSet{
+			Metadata: &spannerpb.ResultSetMetadata{
+				RowType: &spannerpb.StructType{
+					Fields: []*spannerpb.StructType_Field{
+						{Name: "id", Type: &spannerpb.Type{Code: spannerpb.TypeCode_INT64}},
+						{Name: "name", Type: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}},
+						{Name: "_preload_Albums", Type: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}},
+					},
+				},
+			},
+			Rows: []*structpb.ListValue{
+				{Values: []*structpb.Value{
+					{Kind: &structpb.Value_StringValue{StringValue: "1"}},
+					{Kind: &structpb.Value_StringValue{StringValue: "Alice"}},
+					{Kind: &structpb.Value_StringValue{StringValue: `[` +
+						`{"id":9007199254740993,"singer_id":1,"title":"Album 1","_preload_Tracks":[` +
+						`{"id":1,"album_id":9007199254740993,"track_title":"Intro","sample_id":9007199254740995,"deleted_at":null},` +
+						`{"id":2,"album_id":9007199254740993,"track_title":"Outro","deleted_at":null}]},` +
+						`{"id":20,"singer_id":1,"title":"Album 2","_preload_Tracks":[]}]`}},
+				}},
+				{Values: []*structpb.Value{
+					{Kind: &structpb.Value_StringValue{StringValue: "2"}},
+					{Kind: &structpb.Value_StringValue{StringValue: "Bob"}},
+					{Kind: &structpb.Value_StringValue{StringValue: `[]`}},
+				}},
+			},
+		},
+	})
+	var singers []preloadSinger
+	if err := db.Preload("Albums", ArraySubqueryPreload).Preload("Albums.Tracks", ArraySubqueryPreload).Find(&singers).Error; err != nil {
+		t.Fatal(err)
+	}
+	want := []preloadSinger{
+		{ID: 1, Name: "Alice", Albums: []preloadAlbum{
+			{ID: 9007199254740993, SingerID: 1, Title: "Album 1", Tracks: []*preloadTrack{
+				{ID: 1, AlbumID: 9007199254740993, Title: "Intro", SampleID: sql.NullInt64{Int64: 9007199254740995, Valid: true}},
+				{ID: 2, AlbumID: 9007199254740993, Title: "Outro"},
+			}},
+			{ID: 20, SingerID: 1, Title: "Album 2", Tracks: []*preloadTrack{}},
+		}},
+		{ID: 2, Name: "Bob", Albums: []preloadAlbum{}},
+	}
+	if g, w := singers, want; !reflect.DeepEqual(g, w) {
+		t.Fatalf("singers mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	var queries []string
+	for _, req := range requestsOfType(drainRequestsFromServer(server.TestSpanner), reflect.TypeOf(&spannerpb.ExecuteSqlRequest{})) {
+		if sql := req.(*spannerpb.ExecuteSqlRequest).Sql; strings.Contains(sql, "preload_") {
+			queries = append(queries, sql)
+		}
+	}
+	if g, w := queries, []string{query}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("queries mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestArraySubqueryPreloadCallbackOrder(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	// Callbacks that are registered after the dialector has been initialized
+	// must still run before queries with array preloads are built.
+	if err := db.Callback().Query().Before("gorm:query").Register("test:filter", func(tx *gorm.DB) {
+		tx.Statement.AddClause(clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "name = ?", Vars: []interface{}{"Alice"}}}})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	stmt := db.Session(&gorm.Session{DryRun: true}).Preload("Albums", ArraySubqueryPreload).Find(&[]preloadSinger{}).Statement
+	if stmt.Error != nil {
+		t.Fatal(stmt.Error)
+	}
+	want := "SELECT *,TO_JSON_STRING(ARRAY(SELECT AS STRUCT `_preload_Albums`.* FROM `preload_albums` `_preload_Albums` " +
+		"WHERE `_preload_Albums`.`singer_id` = `preload_singers`.`id`)) AS `_preload_Albums` FROM `preload_singers` WHERE name = ?"
+	if g, w := stmt.SQL.String(), want; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestArraySubqueryPreloadErrors(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	tx := db.Session(&gorm.Session{DryRun: true})
+	if err := tx.Preload("Albums", ArraySubqueryPreload, "title = ?", "Album 1").Find(&[]preloadSinger{}).Error; !errors.Is(err, errArrayPreloadConditions) {
+		t.Errorf("error mismatch\n Got: %v\nWant: %v", err, errArrayPreloadConditions)
+	}
+	if err := tx.Preload("Albums", ArraySubqueryPreload).Preload("Albums.Tracks").Find(&[]preloadSinger{}).Error; err == nil {
+		t.Error("missing error for nested default preload")
+	}
+}
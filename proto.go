@@ -0,0 +1,486 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Proto is a PROTO column that contains a proto message of type M. M must be
+// a pointer to a generated proto message, for example *concertspb.TicketOrder.
+// The column type is the fully qualified name of the message. The message
+// type must be added to the proto bundle of the database before the column
+// can be created. AutoMigrate does this automatically if
+// Config.DatabaseAdminClient has been set.
+//
+// The column is NULL if Message is nil.
+//
+// Example:
+//
+//	type TicketSale struct {
+//	  ID          int64
+//	  TicketOrder spannergorm.Proto[*concertspb.TicketOrder]
+//	}
+type Proto[M proto.Message] struct {
+	Message M
+}
+
+// NewProto returns a Proto value containing the given message.
+func NewProto[M proto.Message](message M) Proto[M] {
+	return Proto[M]{Message: message}
+}
+
+func (p Proto[M]) isNull() bool {
+	v := reflect.ValueOf(&p.Message).Elem()
+	return canBeNil(v.Kind()) && v.IsNil()
+}
+
+// Value implements driver.Valuer. The message is sent to Spanner as a
+// serialized proto message.
+func (p Proto[M]) Value() (driver.Value, error) {
+	if p.isNull() {
+		return nil, nil
+	}
+	return proto.Marshal(p.Message)
+}
+
+// Scan implements sql.Scanner. NULL values are scanned as a nil message.
+func (p *Proto[M]) Scan(v any) error {
+	switch val := v.(type) {
+	case nil:
+		p.Message = *new(M)
+		return nil
+	case M:
+		p.Message = val
+		return nil
+	case []byte:
+		if val == nil {
+			p.Message = *new(M)
+			return nil
+		}
+		message, err := newProtoMessage[M]()
+		if err != nil {
+			return err
+		}
+		if err := proto.Unmarshal(val, message); err != nil {
+			return err
+		}
+		p.Message = message
+		return nil
+	}
+	return fmt.Errorf("cannot scan %T into %T", v, p)
+}
+
+// MarshalJSON marshals the message with the JSON mapping of proto messages.
+func (p Proto[M]) MarshalJSON() ([]byte, error) {
+	if p.isNull() {
+		return []byte("null"), nil
+	}
+	return protojson.Marshal(p.Message)
+}
+
+// UnmarshalJSON unmarshals the message with the JSON mapping of proto
+// messages.
+func (p *Proto[M]) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		p.Message = *new(M)
+		return nil
+	}
+	message, err := newProtoMessage[M]()
+	if err != nil {
+		return err
+	}
+	if err := protojson.Unmarshal(b, message); err != nil {
+		return err
+	}
+	p.Message = message
+	return nil
+}
+
+func (p Proto[M]) GormDataType() string {
+	return protoDataType(p.protoDescriptor())
+}
+
+func (p Proto[M]) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	if isPostgreSQL(db) {
+		return "bytea"
+	}
+	return p.GormDataType()
+}
+
+func (p Proto[M]) protoDescriptor() protoreflect.Descriptor {
+	message, err := newProtoMessage[M]()
+	if err != nil {
+		return nil
+	}
+	return message.ProtoReflect().Descriptor()
+}
+
+// newProtoMessage returns a new empty message of type M.
+func newProtoMessage[M proto.Message]() (M, error) {
+	t := reflect.TypeFor[M]()
+	if t.Kind() != reflect.Pointer {
+		return *new(M), fmt.Errorf("%v is not a pointer to a proto message", t)
+	}
+	return reflect.New(t.Elem()).Interface().(M), nil
+}
+
+// protoEnum is implemented by generated proto enum types.
+type protoEnum interface {
+	~int32
+	protoreflect.Enum
+}
+
+// ProtoEnum is an ENUM column that contains a value of the generated proto
+// enum type E. The column type is the fully qualified name of the enum. The
+// enum type must be added to the proto bundle of the database before the
+// column can be created. AutoMigrate does this automatically if
+// Config.DatabaseAdminClient has been set.
+//
+// NULL values are scanned as the zero value of E. Use *ProtoEnum for a
+// column that can be NULL.
+type ProtoEnum[E protoEnum] struct {
+	Enum E
+}
+
+// NewProtoEnum returns a ProtoEnum value containing the given enum value.
+func NewProtoEnum[E protoEnum](enum E) ProtoEnum[E] {
+	return ProtoEnum[E]{Enum: enum}
+}
+
+// Value implements driver.Valuer. The value is sent to Spanner as the number
+// of the enum value.
+func (p ProtoEnum[E]) Value() (driver.Value, error) {
+	return int64(p.Enum), nil
+}
+
+// Scan implements sql.Scanner.
+func (p *ProtoEnum[E]) Scan(v any) error {
+	switch val := v.(type) {
+	case nil:
+		p.Enum = 0
+	case E:
+		p.Enum = val
+	case int64:
+		p.Enum = E(val)
+	case spanner.NullInt64:
+		p.Enum = E(val.Int64)
+	default:
+		return fmt.Errorf("cannot scan %T into %T", v, p)
+	}
+	return nil
+}
+
+// MarshalJSON marshals the name of the enum value.
+func (p ProtoEnum[E]) MarshalJSON() ([]byte, error) {
+	if value := p.Enum.Descriptor().Values().ByNumber(p.Enum.Number()); value != nil {
+		return json.Marshal(string(value.Name()))
+	}
+	return json.Marshal(int32(p.Enum))
+}
+
+// UnmarshalJSON unmarshals the name or the number of an enum value.
+func (p *ProtoEnum[E]) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err != nil {
+		var number int32
+		if err := json.Unmarshal(b, &number); err != nil {
+			return err
+		}
+		p.Enum = E(number)
+		return nil
+	}
+	value := p.Enum.Descriptor().Values().ByName(protoreflect.Name(name))
+	if value == nil {
+		return fmt.Errorf("unknown value %q for enum %s", name, p.Enum.Descriptor().FullName())
+	}
+	p.Enum = E(value.Number())
+	return nil
+}
+
+func (p ProtoEnum[E]) GormDataType() string {
+	return protoDataType(p.protoDescriptor())
+}
+
+func (p ProtoEnum[E]) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	if isPostgreSQL(db) {
+		return "bigint"
+	}
+	return p.GormDataType()
+}
+
+func (p ProtoEnum[E]) protoDescriptor() protoreflect.Descriptor {
+	return p.Enum.Descriptor()
+}
+
+// ProtoArray is an ARRAY column of proto messages of type M. NULL elements
+// are represented by nil messages.
+type ProtoArray[M proto.Message] = Array[M]
+
+// ProtoEnumArray is an ARRAY column of proto enum values of type E. Use
+// NullProtoEnumArray for arrays that can contain NULL elements.
+type ProtoEnumArray[E protoEnum] = Array[E]
+
+// NullProtoEnumArray is an ARRAY column of proto enum values of type E that
+// can contain NULL elements.
+type NullProtoEnumArray[E protoEnum] = NullArray[E]
+
+// protoColumn is implemented by the column types that contain a proto
+// message or enum.
+type protoColumn interface {
+	protoDescriptor() protoreflect.Descriptor
+}
+
+var protoColumnType = reflect.TypeFor[protoColumn]()
+
+// protoDataType returns the data type of a column of the given proto message
+// or enum type.
+func protoDataType(descriptor protoreflect.Descriptor) string {
+	if descriptor == nil {
+		return ""
+	}
+	return fmt.Sprintf("`%s`", descriptor.FullName())
+}
+
+// protoTypeRegexp matches the PROTO<name> and ENUM<name> types that are
+// returned by information_schema for proto columns.
+var protoTypeRegexp = regexp.MustCompile(`^(array<)?(?:proto|enum)<(.+?)>(>)?$`)
+
+// protoTypeAliases returns the data types that are generated for the given
+// proto column type, or nil if the type is not a proto column type.
+func protoTypeAliases(databaseTypeName string) []string {
+	matches := protoTypeRegexp.FindStringSubmatch(strings.ToLower(databaseTypeName))
+	if matches == nil {
+		return nil
+	}
+	return []string{matches[1] + "`" + matches[2] + "`" + matches[3]}
+}
+
+// protoDescriptorOf returns the descriptor of the proto message or enum that
+// is stored in a column of the given type, or nil if the column does not
+// contain a proto message or enum.
+func protoDescriptorOf(t reflect.Type) protoreflect.Descriptor {
+	if t.Implements(protoMessageType) {
+		if t.Kind() != reflect.Pointer {
+			return nil
+		}
+		return reflect.New(t.Elem()).Interface().(proto.Message).ProtoReflect().Descriptor()
+	}
+	if t.Kind() != reflect.Pointer {
+		if t.Implements(protoEnumType) {
+			return reflect.Zero(t).Interface().(protoreflect.Enum).Descriptor()
+		}
+		if t.Implements(protoColumnType) {
+			return reflect.Zero(t).Interface().(protoColumn).protoDescriptor()
+		}
+	}
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return protoDescriptorOf(t.Elem())
+	}
+	return nil
+}
+
+// protoBundleTypes returns the given proto types and all message and enum
+// types that they reference, sorted by name. All these types must be in the
+// proto bundle of the database.
+func protoBundleTypes(types []protoreflect.Descriptor) []protoreflect.Descriptor {
+	seen := make(map[protoreflect.FullName]protoreflect.Descriptor)
+	var add func(d protoreflect.Descriptor)
+	add = func(d protoreflect.Descriptor) {
+		if d == nil {
+			return
+		}
+		if message, ok := d.(protoreflect.MessageDescriptor); ok && message.IsMapEntry() {
+			add(message.Fields().ByNumber(2).Message())
+			add(message.Fields().ByNumber(2).Enum())
+			return
+		}
+		if _, ok := seen[d.FullName()]; ok {
+			return
+		}
+		seen[d.FullName()] = d
+		if message, ok := d.(protoreflect.MessageDescriptor); ok {
+			for i := 0; i < message.Fields().Len(); i++ {
+				add(message.Fields().Get(i).Message())
+				add(message.Fields().Get(i).Enum())
+			}
+		}
+	}
+	for _, d := range types {
+		add(d)
+	}
+	result := make([]protoreflect.Descriptor, 0, len(seen))
+	for _, d := range seen {
+		result = append(result, d)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].FullName() < result[j].FullName() })
+	return result
+}
+
+// protoDescriptorSet returns a serialized FileDescriptorSet that contains the
+// files of the given types and all the files that they import.
+func protoDescriptorSet(types []protoreflect.Descriptor) ([]byte, error) {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	var add func(file protoreflect.FileDescriptor)
+	add = func(file protoreflect.FileDescriptor) {
+		if seen[file.Path()] {
+			return
+		}
+		seen[file.Path()] = true
+		for i := 0; i < file.Imports().Len(); i++ {
+			add(file.Imports().Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(file))
+	}
+	for _, d := range types {
+		add(d.ParentFile())
+	}
+	return proto.Marshal(set)
+}
+
+// protoBundleNames returns the names of the types in the given CREATE PROTO
+// BUNDLE statement.
+func protoBundleNames(statement string) []string {
+	start, end := strings.Index(statement, "("), strings.LastIndex(statement, ")")
+	if start < 0 || end < start {
+		return nil
+	}
+	var names []string
+	for _, name := range strings.Split(statement[start+1:end], ",") {
+		if name = strings.Trim(strings.TrimSpace(name), "`"); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// isCreateProtoBundle returns true if the given DDL statement creates a proto
+// bundle.
+func isCreateProtoBundle(statement string) bool {
+	return strings.HasPrefix(strings.ToUpper(strings.Join(strings.Fields(statement), " ")), "CREATE PROTO BUNDLE")
+}
+
+// protoBundleStatement returns the DDL statement that creates or alters the
+// proto bundle of a database with the given DDL statements and proto
+// descriptors, so that the bundle contains the current version of the given
+// types. The returned statement is empty if the bundle is up to date.
+func protoBundleStatement(statements []string, descriptors []byte, types []protoreflect.Descriptor) string {
+	var bundle []string
+	hasBundle := false
+	for _, statement := range statements {
+		if isCreateProtoBundle(statement) {
+			bundle, hasBundle = protoBundleNames(statement), true
+			break
+		}
+	}
+	if !hasBundle {
+		return "CREATE PROTO BUNDLE (" + strings.Join(protoTypeNames(types), ", ") + ")"
+	}
+	existing := existingProtoFiles(descriptors)
+	var insert, update []protoreflect.Descriptor
+	for _, d := range types {
+		if !slices.Contains(bundle, string(d.FullName())) {
+			insert = append(insert, d)
+		} else if !equalProtoDescriptor(existing, d) {
+			update = append(update, d)
+		}
+	}
+	return alterProtoBundleStatement(insert, update, nil)
+}
+
+// alterProtoBundleStatement returns an ALTER PROTO BUNDLE statement, or an
+// empty string if there are no types to insert, update or delete.
+func alterProtoBundleStatement(insert, update []protoreflect.Descriptor, deleteNames []string) string {
+	if len(insert) == 0 && len(update) == 0 && len(deleteNames) == 0 {
+		return ""
+	}
+	sql := new(strings.Builder)
+	sql.WriteString("ALTER PROTO BUNDLE")
+	if len(insert) > 0 {
+		sql.WriteString(" INSERT (" + strings.Join(protoTypeNames(insert), ", ") + ")")
+	}
+	if len(update) > 0 {
+		sql.WriteString(" UPDATE (" + strings.Join(protoTypeNames(update), ", ") + ")")
+	}
+	if len(deleteNames) > 0 {
+		sql.WriteString(" DELETE (" + strings.Join(deleteNames, ", ") + ")")
+	}
+	return sql.String()
+}
+
+func protoTypeNames(types []protoreflect.Descriptor) []string {
+	names := make([]string, len(types))
+	for i, d := range types {
+		names[i] = string(d.FullName())
+	}
+	return names
+}
+
+// existingProtoFiles returns the files in the given serialized
+// FileDescriptorSet, or nil if the set cannot be parsed.
+func existingProtoFiles(descriptors []byte) *protoregistry.Files {
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(descriptors, set); err != nil {
+		return nil
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil
+	}
+	return files
+}
+
+// equalProtoDescriptor returns true if the given files contain a type with
+// the same name and definition as the given type.
+func equalProtoDescriptor(files *protoregistry.Files, d protoreflect.Descriptor) bool {
+	if files == nil {
+		return false
+	}
+	existing, err := files.FindDescriptorByName(d.FullName())
+	if err != nil {
+		return false
+	}
+	switch d := d.(type) {
+	case protoreflect.MessageDescriptor:
+		other, ok := existing.(protoreflect.MessageDescriptor)
+		return ok && proto.Equal(protodesc.ToDescriptorProto(d), protodesc.ToDescriptorProto(other))
+	case protoreflect.EnumDescriptor:
+		other, ok := existing.(protoreflect.EnumDescriptor)
+		return ok && proto.Equal(protodesc.ToEnumDescriptorProto(d), protodesc.ToEnumDescriptorProto(other))
+	}
+	return false
+}
+
+var errDatabaseAdminClientRequired = errors.New("proto bundles can only be created and altered if Config.DatabaseAdminClient has been set")
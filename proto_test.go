@@ -0,0 +1,320 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	database "cloud.google.com/go/spanner/admin/database/apiv1"
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/googleapis/go-sql-spanner/testutil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+type protoEntity struct {
+	ID       int64 `gorm:"primaryKey;autoIncrement:false"`
+	Duration Proto[*durationpb.Duration]
+	Code     ProtoEnum[spannerpb.TypeCode]
+	Types    ProtoArray[*spannerpb.Type]
+}
+
+const insertProtoEntitySql = "INSERT INTO `proto_entities` (`id`,`duration`,`code`,`types`) VALUES (@p1,@p2,@p3,@p4)"
+
+func TestProtoDataType(t *testing.T) {
+	field := &schema.Field{}
+	googleSQL := &gorm.DB{Config: &gorm.Config{Dialector: New(Config{})}}
+	postgreSQL := &gorm.DB{Config: &gorm.Config{Dialector: postgreSQLDialector{}}}
+	for _, test := range []struct {
+		value      schema.GormDataTypeInterface
+		googleSQL  string
+		postgreSQL string
+	}{
+		{value: Proto[*durationpb.Duration]{}, googleSQL: "`google.protobuf.Duration`", postgreSQL: "bytea"},
+		{value: ProtoEnum[spannerpb.TypeCode]{}, googleSQL: "`google.spanner.v1.TypeCode`", postgreSQL: "bigint"},
+		{value: ProtoArray[*durationpb.Duration]{}, googleSQL: "ARRAY<`google.protobuf.Duration`>", postgreSQL: "bytea[]"},
+		{value: ProtoEnumArray[spannerpb.TypeCode]{}, googleSQL: "ARRAY<`google.spanner.v1.TypeCode`>", postgreSQL: "bigint[]"},
+	} {
+		dbDataType := test.value.(interface {
+			GormDBDataType(*gorm.DB, *schema.Field) string
+		})
+		if g, w := dbDataType.GormDBDataType(googleSQL, field), test.googleSQL; g != w {
+			t.Errorf("%T: GoogleSQL data type mismatch\n Got: %v\nWant: %v", test.value, g, w)
+		}
+		if g, w := dbDataType.GormDBDataType(postgreSQL, field), test.postgreSQL; g != w {
+			t.Errorf("%T: PostgreSQL data type mismatch\n Got: %v\nWant: %v", test.value, g, w)
+		}
+	}
+}
+
+func TestProtoTypeAliases(t *testing.T) {
+	m := spannerMigrator{}
+	for spannerType, alias := range map[string]string{
+		"PROTO<google.protobuf.Duration>":         "`google.protobuf.duration`",
+		"ENUM<google.spanner.v1.TypeCode>":        "`google.spanner.v1.typecode`",
+		"ARRAY<PROTO<google.protobuf.Duration>>":  "array<`google.protobuf.duration`>",
+		"ARRAY<ENUM<google.spanner.v1.TypeCode>>": "array<`google.spanner.v1.typecode`>",
+	} {
+		if g, w := m.GetTypeAliases(spannerType), []string{alias}; !reflect.DeepEqual(g, w) {
+			t.Errorf("%s: aliases mismatch\n Got: %v\nWant: %v", spannerType, g, w)
+		}
+	}
+	if g := protoTypeAliases("STRING"); g != nil {
+		t.Errorf("aliases mismatch\n Got: %v\nWant: nil", g)
+	}
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	_ = server.TestSpanner.PutStatementResult(insertProtoEntitySql, &testutil.StatementResult{
+		Type:        testutil.StatementResultUpdateCount,
+		UpdateCount: 1,
+	})
+	entity := protoEntity{
+		ID:       1,
+		Duration: NewProto(durationpb.New(time.Minute)),
+		Code:     NewProtoEnum(spannerpb.TypeCode_JSON),
+		Types:    ProtoArray[*spannerpb.Type]{{Code: spannerpb.TypeCode_STRING}, nil},
+	}
+	if err := db.Create(&entity).Error; err != nil {
+		t.Fatalf("failed to create entity: %v", err)
+	}
+	req := getLastSqlRequest(server)
+	if g, w := req.Sql, insertProtoEntitySql; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	b, _ := proto.Marshal(durationpb.New(time.Minute))
+	if g, w := req.Params.Fields["p2"].GetStringValue(), base64.StdEncoding.EncodeToString(b); g != w {
+		t.Fatalf("param value mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.Params.Fields["p3"].GetStringValue(), fmt.Sprint(int64(spannerpb.TypeCode_JSON)); g != w {
+		t.Fatalf("param value mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.ParamTypes["p4"].GetArrayElementType().GetProtoTypeFqn(), "google.spanner.v1.Type"; g != w {
+		t.Fatalf("param type mismatch\n Got: %v\nWant: %v", g, w)
+	}
+
+	_ = server.TestSpanner.PutStatementResult("SELECT * FROM `proto_entities` ORDER BY `proto_entities`.`id` LIMIT @p1",
+		&testutil.StatementResult{
+			Type: testutil.StatementResultResultSet,
+			ResultSet: &spannerpb.ResultSet{
+				Metadata: &spannerpb.ResultSetMetadata{
+					RowType: &spannerpb.StructType{
+						Fields: []*spannerpb.StructType_Field{
+							{Name: "id", Type: &spannerpb.Type{Code: spannerpb.TypeCode_INT64}},
+							{Name: "duration", Type: &spannerpb.Type{Code: spannerpb.TypeCode_PROTO, ProtoTypeFqn: "google.protobuf.Duration"}},
+							{Name: "code", Type: &spannerpb.Type{Code: spannerpb.TypeCode_ENUM, ProtoTypeFqn: "google.spanner.v1.TypeCode"}},
+							{Name: "types", Type: req.ParamTypes["p4"]},
+						},
+					},
+				},
+				Rows: []*structpb.ListValue{
+					{Values: []*structpb.Value{
+						{Kind: &structpb.Value_StringValue{StringValue: "1"}},
+						req.Params.Fields["p2"],
+						req.Params.Fields["p3"],
+						req.Params.Fields["p4"],
+					}},
+				},
+			},
+		})
+	var result protoEntity
+	if err := db.First(&result).Error; err != nil {
+		t.Fatalf("failed to fetch entity: %v", err)
+	}
+	if g, w := result.Duration.Message.AsDuration(), time.Minute; g != w {
+		t.Fatalf("duration mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := result.Code, entity.Code; g != w {
+		t.Fatalf("enum mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := len(result.Types), 2; g != w {
+		t.Fatalf("array length mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if !proto.Equal(result.Types[0], entity.Types[0]) || result.Types[1] != nil {
+		t.Fatalf("array mismatch\n Got: %v\nWant: %v", result.Types, entity.Types)
+	}
+}
+
+func TestProtoJSON(t *testing.T) {
+	entity := protoEntity{
+		ID:       1,
+		Duration: NewProto(durationpb.New(time.Minute)),
+		Code:     NewProtoEnum(spannerpb.TypeCode_JSON),
+	}
+	b, err := json.Marshal(entity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := string(b), `{"ID":1,"Duration":"60s","Code":"JSON","Types":null}`; g != w {
+		t.Fatalf("json mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	var result protoEntity
+	if err := json.Unmarshal(b, &result); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(result.Duration.Message, entity.Duration.Message) || result.Code != entity.Code {
+		t.Fatalf("entity mismatch\n Got: %v\nWant: %v", result, entity)
+	}
+}
+
+func TestProtoBundleTypes(t *testing.T) {
+	types := protoBundleTypes([]protoreflect.Descriptor{protoDescriptorOf(reflect.TypeFor[protoEntity]().Field(3).Type)})
+	if g, w := protoTypeNames(types), []string{
+		"google.spanner.v1.StructType",
+		"google.spanner.v1.StructType.Field",
+		"google.spanner.v1.Type",
+		"google.spanner.v1.TypeAnnotationCode",
+		"google.spanner.v1.TypeCode",
+	}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("types mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestProtoBundleStatement(t *testing.T) {
+	duration := protoDescriptorOf(reflect.TypeFor[Proto[*durationpb.Duration]]())
+	typeCode := protoDescriptorOf(reflect.TypeFor[ProtoEnum[spannerpb.TypeCode]]())
+	types := []protoreflect.Descriptor{duration, typeCode}
+	descriptors := func(files ...*descriptorpb.FileDescriptorProto) []byte {
+		b, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: files})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	current, err := protoDescriptorSet(types)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed := protodesc.ToFileDescriptorProto(duration.ParentFile())
+	changed.MessageType[0].Field = changed.MessageType[0].Field[:1]
+
+	for _, test := range []struct {
+		name        string
+		statements  []string
+		descriptors []byte
+		want        string
+	}{
+		{
+			name:       "no bundle",
+			statements: []string{"CREATE TABLE singers (id INT64) PRIMARY KEY (id)"},
+			want:       "CREATE PROTO BUNDLE (google.protobuf.Duration, google.spanner.v1.TypeCode)",
+		},
+		{
+			name:        "up to date",
+			statements:  []string{"CREATE PROTO BUNDLE (\n  google.protobuf.Duration,\n  `google.spanner.v1.TypeCode`,\n)"},
+			descriptors: current,
+		},
+		{
+			name:        "new type",
+			statements:  []string{"CREATE PROTO BUNDLE (google.protobuf.Duration)"},
+			descriptors: current,
+			want:        "ALTER PROTO BUNDLE INSERT (google.spanner.v1.TypeCode)",
+		},
+		{
+			name:        "changed type",
+			statements:  []string{"CREATE PROTO BUNDLE (google.protobuf.Duration)"},
+			descriptors: descriptors(changed),
+			want:        "ALTER PROTO BUNDLE INSERT (google.spanner.v1.TypeCode) UPDATE (google.protobuf.Duration)",
+		},
+	} {
+		if g, w := protoBundleStatement(test.statements, test.descriptors, types), test.want; g != w {
+			t.Errorf("%s: statement mismatch\n Got: %v\nWant: %v", test.name, g, w)
+		}
+	}
+}
+
+func TestCreateProtoBundle(t *testing.T) {
+	t.Parallel()
+
+	server, opts, serverTeardown := testutil.NewMockedSpannerInMemTestServer(t)
+	client, err := database.NewDatabaseAdminClient(context.Background(), opts...)
+	if err != nil {
+		serverTeardown()
+		t.Fatal(err)
+	}
+	defer func() { _ = client.Close() }()
+	db, _, teardown := setupTestGormConnectionWithDialector(t, server, serverTeardown, New(Config{
+		DriverName:          "spanner",
+		DSN:                 fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true", server.Address),
+		DatabaseAdminClient: client,
+	}))
+	defer teardown()
+	anyProto, err := anypb.New(&emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.TestDatabaseAdmin.SetResps([]proto.Message{
+		&longrunningpb.Operation{
+			Name:   "test-operation",
+			Done:   true,
+			Result: &longrunningpb.Operation_Response{Response: anyProto},
+		},
+	})
+
+	if err := db.Migrator().(SpannerMigrator).CreateProtoBundle(durationpb.File_google_protobuf_duration_proto.Messages().Get(0)); err != nil {
+		t.Fatal(err)
+	}
+	requests := server.TestDatabaseAdmin.Reqs()
+	if g, w := len(requests), 1; g != w {
+		t.Fatalf("request count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	request := requests[0].(*databasepb.UpdateDatabaseDdlRequest)
+	if g, w := request.GetDatabase(), "projects/p/instances/i/databases/d"; g != w {
+		t.Fatalf("database mismatch\n Got: %s\nWant: %s", g, w)
+	}
+	if g, w := request.GetStatements(), []string{"CREATE PROTO BUNDLE (google.protobuf.Duration)"}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("statements mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(request.GetProtoDescriptors(), set); err != nil {
+		t.Fatal(err)
+	}
+	if g, w := len(set.File), 1; g != w {
+		t.Fatalf("file count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := set.File[0].GetName(), "google/protobuf/duration.proto"; g != w {
+		t.Fatalf("file name mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestProtoBundleRequiresDatabaseAdminClient(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	if g, w := db.Migrator().(SpannerMigrator).CreateProtoBundle(durationpb.File_google_protobuf_duration_proto.Messages().Get(0)), errDatabaseAdminClientRequired; g != w {
+		t.Fatalf("error mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"time"
+
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	spannerdriver "github.com/googleapis/go-sql-spanner"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// ParameterLogging determines how the parameter values of statements are
+// included in the log records of a QueryLogger.
+type ParameterLogging int
+
+const (
+	// RedactParameters logs statements with parameter placeholders instead of
+	// parameter values. This is the default.
+	RedactParameters ParameterLogging = iota
+	// RedactStringParameters logs the values of numeric, boolean and
+	// timestamp parameters, and redacts all other parameter values.
+	RedactStringParameters
+	// LogParameters logs statements with all parameter values.
+	LogParameters
+)
+
+// redactedParameter replaces parameter values that are redacted.
+const redactedParameter = "<redacted>"
+
+// QueryLogConfig contains the configuration of a QueryLogger.
+type QueryLogConfig struct {
+	// Logger is the structured logger that the records are written to. Use
+	// for example slog.New(slog.NewJSONHandler(os.Stdout, nil)) to write
+	// JSON records. slog.Default() is used if no logger has been set.
+	Logger *slog.Logger
+
+	// LogLevel is the gorm log level. Statements are only logged at
+	// logger.Info. Slow and scan-heavy statements are logged at logger.Warn
+	// and higher. The default is logger.Warn.
+	LogLevel logger.LogLevel
+
+	// SlowThreshold is the minimum execution time of statements that are
+	// logged as slow queries. The default is 200ms.
+	SlowThreshold time.Duration
+
+	// SampleRate is the fraction of queries between 0 and 1 that are
+	// executed in PROFILE mode. The query statistics of these queries are
+	// included in the log records, and are used to detect scan-heavy
+	// queries. No query statistics are captured if no rate has been set.
+	SampleRate float64
+
+	// ScanRatioThreshold is the minimum ratio between the number of scanned
+	// rows and the number of returned rows of sampled queries that are
+	// logged as scan-heavy queries. The default is 100.
+	ScanRatioThreshold float64
+
+	// ParameterLogging determines whether parameter values are included in
+	// the log records. The default is RedactParameters.
+	ParameterLogging ParameterLogging
+
+	// IgnoreRecordNotFoundError skips logging gorm.ErrRecordNotFound errors.
+	IgnoreRecordNotFoundError bool
+}
+
+// QueryLogger is a gorm logger.Interface that writes structured records with
+// log/slog. The records contain the request tag of the statement, and the
+// query statistics of sampled queries. The records of slow queries and
+// queries that scan a large number of rows compared to the number of rows
+// that they return are logged as warnings.
+//
+// Set QueryLog in the configuration of the dialector to use a QueryLogger,
+// or call UseQueryLogger for an existing database.
+type QueryLogger struct {
+	config QueryLogConfig
+}
+
+// NewQueryLogger returns a new QueryLogger with the given configuration. The
+// logger can only include query statistics and request tags in the records
+// if the query logging callbacks have been registered with UseQueryLogger.
+func NewQueryLogger(config QueryLogConfig) *QueryLogger {
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+	if config.LogLevel == 0 {
+		config.LogLevel = logger.Warn
+	}
+	if config.SlowThreshold == 0 {
+		config.SlowThreshold = 200 * time.Millisecond
+	}
+	if config.ScanRatioThreshold == 0 {
+		config.ScanRatioThreshold = 100
+	}
+	return &QueryLogger{config: config}
+}
+
+// UseQueryLogger sets a QueryLogger with the given configuration as the
+// logger of the given database, and registers the callbacks that sample
+// queries and capture the request tags of statements.
+//
+// This function can be used for both GoogleSQL-dialect and PostgreSQL-dialect databases.
+func UseQueryLogger(db *gorm.DB, config QueryLogConfig) error {
+	l := NewQueryLogger(config)
+	callback := db.Callback()
+	for _, c := range []struct {
+		operation     string
+		before, after callbackRegisterer
+	}{
+		{"create", callback.Create().Before("*"), callback.Create().After("*")},
+		{"query", callback.Query().Before("*"), callback.Query().After("*")},
+		{"update", callback.Update().Before("*"), callback.Update().After("*")},
+		{"delete", callback.Delete().Before("*"), callback.Delete().After("*")},
+		{"raw", callback.Raw().Before("*"), callback.Raw().After("*")},
+		{"row", callback.Row().Before("*"), callback.Row().After("*")},
+	} {
+		if err := c.before.Register("gorm:spanner:start_"+c.operation+"_log", l.startStatement(c.operation == "query")); err != nil {
+			return err
+		}
+		if err := c.after.Register("gorm:spanner:end_"+c.operation+"_log", endStatementLog); err != nil {
+			return err
+		}
+	}
+	db.Logger = l
+	return nil
+}
+
+// queryLogInfoKey is the context key of the queryLogInfo of a statement.
+type queryLogInfoKey struct{}
+
+// queryLogInfo contains the information about a statement that gorm does not
+// pass to the logger.
+type queryLogInfo struct {
+	requestTag string
+	// stats is the destination of the query statistics of the statement. It
+	// is nil if the statement is not sampled.
+	stats **spannerpb.ResultSetStats
+	// sampled is true if the statement was sampled by the logger.
+	sampled bool
+}
+
+func (l *QueryLogger) startStatement(query bool) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		info := &queryLogInfo{stats: queryStatsDestination(db)}
+		if query && info.stats == nil && l.config.SampleRate > 0 && rand.Float64() < l.config.SampleRate {
+			var stats *spannerpb.ResultSetStats
+			info.stats, info.sampled = &stats, true
+			db.Statement.Settings.Store(queryStatsKey, info.stats)
+		}
+		db.Statement.Context = context.WithValue(db.Statement.Context, queryLogInfoKey{}, info)
+	}
+}
+
+func endStatementLog(db *gorm.DB) {
+	info, ok := db.Statement.Context.Value(queryLogInfoKey{}).(*queryLogInfo)
+	if !ok {
+		return
+	}
+	info.requestTag = requestTag(db.Statement.Vars)
+	if info.sampled {
+		db.Statement.Settings.Delete(queryStatsKey)
+	}
+}
+
+func (l *QueryLogger) LogMode(level logger.LogLevel) logger.Interface {
+	c := *l
+	c.config.LogLevel = level
+	return &c
+}
+
+func (l *QueryLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.config.LogLevel >= logger.Info {
+		l.config.Logger.InfoContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *QueryLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.config.LogLevel >= logger.Warn {
+		l.config.Logger.WarnContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *QueryLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.config.LogLevel >= logger.Error {
+		l.config.Logger.ErrorContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *QueryLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.config.LogLevel <= logger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+	var stats *QueryStats
+	info, _ := ctx.Value(queryLogInfoKey{}).(*queryLogInfo)
+	if info != nil && info.stats != nil && *info.stats != nil {
+		stats = newQueryStats(*info.stats)
+	}
+
+	var level slog.Level
+	var msg string
+	switch {
+	case err != nil && l.config.LogLevel >= logger.Error && (!errors.Is(err, gorm.ErrRecordNotFound) || !l.config.IgnoreRecordNotFoundError):
+		level, msg = slog.LevelError, "statement failed"
+	case elapsed > l.config.SlowThreshold && l.config.LogLevel >= logger.Warn:
+		level, msg = slog.LevelWarn, "slow statement"
+	case stats != nil && l.scanHeavy(stats) && l.config.LogLevel >= logger.Warn:
+		level, msg = slog.LevelWarn, "scan-heavy statement"
+	case l.config.LogLevel >= logger.Info:
+		level, msg = slog.LevelInfo, "statement"
+	default:
+		return
+	}
+
+	sql, rows := fc()
+	attrs := []slog.Attr{
+		slog.String("sql", sql),
+		slog.Int64("rows", rows),
+		slog.Duration("elapsed", elapsed),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	if info != nil && info.requestTag != "" {
+		attrs = append(attrs, slog.String("request_tag", info.requestTag))
+	}
+	if stats != nil {
+		var fullScans []string
+		for _, scan := range stats.Plan.FullScans() {
+			fullScans = append(fullScans, scan.ScanTarget())
+		}
+		attrs = append(attrs, slog.Group("stats",
+			slog.Duration("elapsed_time", stats.ElapsedTime),
+			slog.Duration("cpu_time", stats.CPUTime),
+			slog.Int64("rows_returned", stats.RowsReturned),
+			slog.Int64("rows_scanned", stats.RowsScanned),
+			slog.Any("full_scans", fullScans),
+		))
+	}
+	l.config.Logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// scanHeavy returns true if the query scanned a large number of rows compared
+// to the number of rows that it returned.
+func (l *QueryLogger) scanHeavy(stats *QueryStats) bool {
+	return float64(stats.RowsScanned)/float64(max(stats.RowsReturned, 1)) >= l.config.ScanRatioThreshold
+}
+
+// ParamsFilter implements logger.ParamsFilter. It redacts the parameter
+// values of statements according to the ParameterLogging of the logger.
+func (l *QueryLogger) ParamsFilter(_ context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if l.config.ParameterLogging == RedactParameters {
+		return sql, nil
+	}
+	filtered := make([]interface{}, 0, len(params))
+	for _, param := range params {
+		switch param.(type) {
+		case spannerdriver.ExecOptions, *spannerdriver.ExecOptions:
+			continue
+		}
+		if l.config.ParameterLogging == RedactStringParameters {
+			switch param.(type) {
+			case bool, *bool, int, *int, int32, *int32, int64, *int64, uint, *uint, uint64, *uint64,
+				float32, *float32, float64, *float64, time.Time, *time.Time, nil:
+			default:
+				param = redactedParameter
+			}
+		}
+		filtered = append(filtered, param)
+	}
+	return sql, filtered
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	spannerdriver "github.com/googleapis/go-sql-spanner"
+	"gorm.io/gorm/logger"
+)
+
+func readLogRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	decoder := json.NewDecoder(buf)
+	for decoder.More() {
+		var record map[string]interface{}
+		if err := decoder.Decode(&record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestQueryLoggerSampling(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	server, _, serverTeardown := setupMockedTestServer(t)
+	db, _, teardown := setupTestGormConnectionWithDialector(t, server, serverTeardown, New(Config{
+		DriverName: "spanner",
+		DSN:        fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true", server.Address),
+		QueryLog: &QueryLogConfig{
+			Logger:             slog.New(slog.NewJSONHandler(&buf, nil)),
+			SampleRate:         1,
+			ScanRatioThreshold: 5,
+		},
+	}))
+	defer teardown()
+	if _, ok := db.Logger.(*QueryLogger); !ok {
+		t.Fatalf("logger type mismatch\n Got: %T\nWant: %T", db.Logger, &QueryLogger{})
+	}
+
+	const query = "SELECT * FROM `plan_singers` WHERE last_name = @p1"
+	putQueryPlanResult(server, query)
+	var singers []planSinger
+	if err := db.Where("last_name = ?", "Allison", spannerdriver.ExecOptions{QueryOptions: spanner.QueryOptions{RequestTag: "find_singers"}}).Find(&singers).Error; err != nil {
+		t.Fatal(err)
+	}
+	if g, w := len(singers), 1; g != w {
+		t.Fatalf("singers length mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := getLastSqlRequest(server).QueryMode, spannerpb.ExecuteSqlRequest_PROFILE; g != w {
+		t.Fatalf("query mode mismatch\n Got: %v\nWant: %v", g, w)
+	}
+
+	records := readLogRecords(t, &buf)
+	if g, w := len(records), 1; g != w {
+		t.Fatalf("record count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	record := records[0]
+	for key, want := range map[string]interface{}{
+		"level":       "WARN",
+		"msg":         "scan-heavy statement",
+		"sql":         "SELECT * FROM `plan_singers` WHERE last_name = ?",
+		"rows":        float64(1),
+		"request_tag": "find_singers",
+	} {
+		if g, w := record[key], want; g != w {
+			t.Errorf("%s mismatch\n Got: %v\nWant: %v", key, g, w)
+		}
+	}
+	stats, _ := record["stats"].(map[string]interface{})
+	if g, w := stats["rows_scanned"], float64(10); g != w {
+		t.Errorf("rows scanned mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := stats["full_scans"], []interface{}{"plan_singers"}; !reflect.DeepEqual(g, w) {
+		t.Errorf("full scans mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestQueryLoggerParameters(t *testing.T) {
+	t.Parallel()
+
+	const query = "SELECT * FROM `plan_singers` WHERE last_name = @p1 AND id > @p2"
+	for _, test := range []struct {
+		parameters ParameterLogging
+		want       string
+	}{
+		{parameters: RedactParameters, want: "SELECT * FROM `plan_singers` WHERE last_name = ? AND id > ?"},
+		{parameters: RedactStringParameters, want: "SELECT * FROM `plan_singers` WHERE last_name = '<redacted>' AND id > 10"},
+		{parameters: LogParameters, want: "SELECT * FROM `plan_singers` WHERE last_name = 'Allison' AND id > 10"},
+	} {
+		db, server, teardown := setupTestGormConnection(t)
+		putQueryPlanResult(server, query)
+		var buf bytes.Buffer
+		if err := UseQueryLogger(db, QueryLogConfig{
+			Logger:           slog.New(slog.NewJSONHandler(&buf, nil)),
+			LogLevel:         logger.Info,
+			ParameterLogging: test.parameters,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		var singers []planSinger
+		if err := db.Where("last_name = ? AND id > ?", "Allison", 10).Find(&singers).Error; err != nil {
+			t.Fatal(err)
+		}
+		records := readLogRecords(t, &buf)
+		if g, w := len(records), 1; g != w {
+			t.Fatalf("%v: record count mismatch\n Got: %v\nWant: %v", test.parameters, g, w)
+		}
+		if g, w := records[0]["msg"], "statement"; g != w {
+			t.Errorf("%v: message mismatch\n Got: %v\nWant: %v", test.parameters, g, w)
+		}
+		if g, w := records[0]["sql"], test.want; g != w {
+			t.Errorf("%v: sql mismatch\n Got: %v\nWant: %v", test.parameters, g, w)
+		}
+		if _, ok := records[0]["stats"]; ok {
+			t.Errorf("%v: unexpected stats for a query that was not sampled", test.parameters)
+		}
+		teardown()
+	}
+}
+
+func TestQueryLoggerErrors(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	l := NewQueryLogger(QueryLogConfig{Logger: slog.New(slog.NewJSONHandler(&buf, nil)), IgnoreRecordNotFoundError: true})
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+	db.Logger = l
+
+	var singer planSinger
+	// The mock server returns an error for statements without a result.
+	if err := db.Take(&singer).Error; err == nil {
+		t.Fatal("missing error")
+	}
+	records := readLogRecords(t, &buf)
+	if g, w := len(records), 1; g != w {
+		t.Fatalf("record count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := records[0]["level"], "ERROR"; g != w {
+		t.Errorf("level mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := records[0]["msg"], "statement failed"; g != w {
+		t.Errorf("message mismatch\n Got: %v\nWant: %v", g, w)
+	}
+
+	silent := l.LogMode(logger.Silent)
+	silent.Error(t.Context(), "error %d", 1)
+	if g, w := buf.Len(), 0; g != w {
+		t.Fatalf("silent logger wrote %v bytes", g)
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/googleapis/go-gorm-spanner/internal/gormutil"
+	spannerdriver "github.com/googleapis/go-sql-spanner"
+	"gorm.io/gorm"
+)
+
+// QueryPlan is the execution plan of a query.
+type QueryPlan struct {
+	// Root is the root node of the plan tree.
+	Root *PlanNode
+	// Nodes contains all nodes of the plan, in the order that they were
+	// returned by Spanner. The index of a node in this slice is equal to the
+	// Index of the node.
+	Nodes []*PlanNode
+}
+
+// PlanNode is a node in the execution plan of a query.
+type PlanNode struct {
+	Index       int32
+	Kind        spannerpb.PlanNode_Kind
+	DisplayName string
+	// Description is the short representation of a scalar node.
+	Description string
+	// Metadata contains the attributes of the node, for example the scan
+	// target of a Scan node.
+	Metadata map[string]interface{}
+	// ExecutionStats contains the execution statistics of the node. This is
+	// only set if the query was executed with Profile.
+	ExecutionStats map[string]interface{}
+	// Children contains the child nodes of the node.
+	Children []*PlanNode
+	// ChildTypes contains the type of the link to each child, for example
+	// "Input" or "Split Range".
+	ChildTypes []string
+}
+
+// FullScan returns true if the node is a scan that reads all rows of a
+// table or index.
+func (n *PlanNode) FullScan() bool {
+	return n.DisplayName == "Scan" && fmt.Sprint(n.Metadata["Full scan"]) == "true"
+}
+
+// ScanTarget returns the name of the table or index that is read by a Scan
+// node, or an empty string if the node is not a scan.
+func (n *PlanNode) ScanTarget() string {
+	if target, ok := n.Metadata["scan_target"].(string); ok {
+		return target
+	}
+	return ""
+}
+
+// FullScans returns the scan nodes in the plan that read all rows of a table
+// or index.
+func (p *QueryPlan) FullScans() []*PlanNode {
+	var scans []*PlanNode
+	for _, node := range p.Nodes {
+		if node.FullScan() {
+			scans = append(scans, node)
+		}
+	}
+	return scans
+}
+
+// QueryStats contains the execution statistics of a query that was executed
+// with Profile.
+type QueryStats struct {
+	Plan         *QueryPlan
+	ElapsedTime  time.Duration
+	CPUTime      time.Duration
+	RowsReturned int64
+	RowsScanned  int64
+	// Values contains all statistics that were returned by Spanner.
+	Values map[string]interface{}
+}
+
+// Explain returns the execution plan of the query that is executed by fc,
+// without executing the query. The query is built by gorm in dry-run mode,
+// which means that fc does not return any records.
+//
+// Example:
+//
+//	plan, err := spannergorm.Explain(db, func(tx *gorm.DB) *gorm.DB {
+//		return tx.Where("last_name = ?", "Allison").Find(&singers)
+//	})
+//
+// This function can be used for both GoogleSQL-dialect and PostgreSQL-dialect databases.
+func Explain(db *gorm.DB, fc func(tx *gorm.DB) *gorm.DB) (*QueryPlan, error) {
+	tx := fc(db.Session(&gorm.Session{DryRun: true}))
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	stmt := tx.Statement
+	stats, err := queryPlan(stmt.Context, stmt.ConnPool, stmt.SQL.String(), stmt.Vars)
+	if err != nil {
+		return nil, err
+	}
+	return newQueryPlan(stats.GetQueryPlan()), nil
+}
+
+// Profile executes the query in fc and returns the execution statistics of
+// the query. The records that are returned by the query are loaded into the
+// destination of fc as usual. Profile only supports queries that are
+// executed with gorm finisher methods like Find, First and Take.
+//
+// Example:
+//
+//	stats, err := spannergorm.Profile(db, func(tx *gorm.DB) *gorm.DB {
+//		return tx.Where("last_name = ?", "Allison").Find(&singers)
+//	})
+//
+// This function can be used for both GoogleSQL-dialect and PostgreSQL-dialect databases.
+func Profile(db *gorm.DB, fc func(tx *gorm.DB) *gorm.DB) (*QueryStats, error) {
+	var stats *spannerpb.ResultSetStats
+	tx := fc(db.Set(queryStatsKey, &stats))
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	if stats == nil {
+		return nil, fmt.Errorf("no query statistics returned, Profile only supports queries that are executed with the gorm query callbacks")
+	}
+	return newQueryStats(stats), nil
+}
+
+// queryStatsKey is the setting that contains the destination of the query
+// statistics of a query that is executed with Profile.
+const queryStatsKey = "gorm:spanner:query_stats"
+
+// queryStatsDestination returns the destination for the statistics of the
+// query in the given statement, or nil if the statistics are not requested.
+func queryStatsDestination(db *gorm.DB) **spannerpb.ResultSetStats {
+	if dest, ok := db.Get(queryStatsKey); ok {
+		return dest.(**spannerpb.ResultSetStats)
+	}
+	return nil
+}
+
+// execOptionsWithMode returns the ExecOptions that instruct the Spanner
+// driver to execute a query in the given mode and to return the statistics.
+func execOptionsWithMode(mode spannerpb.ExecuteSqlRequest_QueryMode) spannerdriver.ExecOptions {
+	return spannerdriver.ExecOptions{
+		QueryOptions:         spanner.QueryOptions{Mode: &mode},
+		ReturnResultSetStats: true,
+	}
+}
+
+// readQueryStats moves to the result set with the statistics of the query in
+// the given rows and reads the statistics. All rows with data must have been
+// read.
+func readQueryStats(rows *sql.Rows) (*spannerpb.ResultSetStats, error) {
+	for rows.Next() {
+	}
+	if !rows.NextResultSet() || !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("no query statistics returned")
+	}
+	var stats *spannerpb.ResultSetStats
+	if err := rows.Scan(&stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// queryPlan returns the statistics with the query plan of the given query.
+func queryPlan(ctx context.Context, conn gorm.ConnPool, query string, vars []interface{}) (*spannerpb.ResultSetStats, error) {
+	args := append(append([]interface{}{}, vars...), execOptionsWithMode(spannerpb.ExecuteSqlRequest_PLAN))
+	rows, err := gormutil.UnpreparedConnPool(conn).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return readQueryStats(rows)
+}
+
+func newQueryPlan(plan *spannerpb.QueryPlan) *QueryPlan {
+	result := &QueryPlan{Nodes: make([]*PlanNode, len(plan.GetPlanNodes()))}
+	for i, node := range plan.GetPlanNodes() {
+		result.Nodes[i] = &PlanNode{
+			Index:          node.Index,
+			Kind:           node.Kind,
+			DisplayName:    node.DisplayName,
+			Description:    node.GetShortRepresentation().GetDescription(),
+			Metadata:       node.GetMetadata().AsMap(),
+			ExecutionStats: node.GetExecutionStats().AsMap(),
+		}
+	}
+	for i, node := range plan.GetPlanNodes() {
+		for _, link := range node.ChildLinks {
+			if link.ChildIndex < 0 || int(link.ChildIndex) >= len(result.Nodes) {
+				continue
+			}
+			result.Nodes[i].Children = append(result.Nodes[i].Children, result.Nodes[link.ChildIndex])
+			result.Nodes[i].ChildTypes = append(result.Nodes[i].ChildTypes, link.Type)
+		}
+	}
+	if len(result.Nodes) > 0 {
+		result.Root = result.Nodes[0]
+	}
+	return result
+}
+
+func newQueryStats(stats *spannerpb.ResultSetStats) *QueryStats {
+	values := stats.GetQueryStats().AsMap()
+	return &QueryStats{
+		Plan:         newQueryPlan(stats.GetQueryPlan()),
+		ElapsedTime:  parseStatsDuration(values["elapsed_time"]),
+		CPUTime:      parseStatsDuration(values["cpu_time"]),
+		RowsReturned: parseStatsInt(values["rows_returned"]),
+		RowsScanned:  parseStatsInt(values["rows_scanned"]),
+		Values:       values,
+	}
+}
+
+// parseStatsDuration parses a duration in the query statistics, for example
+// "1.25 msecs".
+func parseStatsDuration(v interface{}) time.Duration {
+	s, _ := v.(string)
+	value, unit, ok := strings.Cut(strings.TrimSpace(s), " ")
+	if !ok {
+		return 0
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	switch unit {
+	case "usecs":
+		return time.Duration(f * float64(time.Microsecond))
+	case "msecs":
+		return time.Duration(f * float64(time.Millisecond))
+	case "secs":
+		return time.Duration(f * float64(time.Second))
+	}
+	return 0
+}
+
+func parseStatsInt(v interface{}) int64 {
+	s, _ := v.(string)
+	i, _ := strconv.ParseInt(s, 10, 64)
+	return i
+}
+
+const queryPlanCheckerName = "gorm:spanner:query_plan_checker"
+
+// QueryPlanChecker is a gorm plugin for development environments that
+// requests the execution plan of each query that is executed by gorm, and
+// logs a warning with the gorm logger if the plan contains a full scan of a
+// table or index. The warning mentions whether the query has a FORCE_INDEX
+// hint.
+//
+// The plugin executes an additional PLAN request for each query. Do not
+// register the plugin in production environments.
+//
+// Example:
+//
+//	if err := db.Use(spannergorm.NewQueryPlanChecker()); err != nil {
+//		return err
+//	}
+type QueryPlanChecker struct{}
+
+// NewQueryPlanChecker returns a new QueryPlanChecker. Register the plugin
+// with db.Use.
+func NewQueryPlanChecker() *QueryPlanChecker {
+	return &QueryPlanChecker{}
+}
+
+func (c *QueryPlanChecker) Name() string {
+	return queryPlanCheckerName
+}
+
+func (c *QueryPlanChecker) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Query().After("gorm:query").Register("gorm:spanner:check_query_plan", checkQueryPlan); err != nil {
+		return err
+	}
+	return db.Callback().Row().After("gorm:row").Register("gorm:spanner:check_row_query_plan", checkQueryPlan)
+}
+
+func checkQueryPlan(db *gorm.DB) {
+	if db.Error != nil || db.DryRun || db.Statement.SQL.Len() == 0 {
+		return
+	}
+	query := db.Statement.SQL.String()
+	stats, err := queryPlan(db.Statement.Context, db.Statement.ConnPool, query, db.Statement.Vars)
+	if err != nil {
+		db.Logger.Warn(db.Statement.Context, "failed to get query plan: %v", err)
+		return
+	}
+	for _, scan := range newQueryPlan(stats.GetQueryPlan()).FullScans() {
+		forceIndex := "without a FORCE_INDEX hint"
+		if strings.Contains(strings.ToUpper(query), "FORCE_INDEX") {
+			forceIndex = "with a FORCE_INDEX hint"
+		}
+		db.Logger.Warn(db.Statement.Context, "query executes a full scan of %s %s: %s", scan.ScanTarget(), forceIndex, query)
+	}
+}
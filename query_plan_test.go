@@ -0,0 +1,216 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/googleapis/go-sql-spanner/testutil"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type planSinger struct {
+	ID       int64 `gorm:"primaryKey;autoIncrement:false"`
+	LastName string
+}
+
+func putQueryPlanResult(server *testutil.MockedSpannerInMemTestServer, query string) {
+	metadata, _ := structpb.NewStruct(map[string]interface{}{
+		"scan_type":   "TableScan",
+		"scan_target": "plan_singers",
+		"Full scan":   "true",
+	})
+	queryStats, _ := structpb.NewStruct(map[string]interface{}{
+		"elapsed_time":  "1.50 msecs",
+		"cpu_time":      "1.25 msecs",
+		"rows_returned": "1",
+		"rows_scanned":  "10",
+	})
+	_ = server.TestSpanner.PutStatementResult(query, &testutil.StatementResult{
+		Type: testutil.StatementResultResultSet,
+		ResultSet: &spannerpb.ResultSet{
+			Metadata: &spannerpb.ResultSetMetadata{
+				RowType: &spannerpb.StructType{
+					Fields: []*spannerpb.StructType_Field{
+						{Name: "id", Type: &spannerpb.Type{Code: spannerpb.TypeCode_INT64}},
+						{Name: "last_name", Type: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}},
+					},
+				},
+			},
+			Rows: []*structpb.ListValue{
+				{Values: []*structpb.Value{
+					{Kind: &structpb.Value_StringValue{StringValue: "1"}},
+					{Kind: &structpb.Value_StringValue{StringValue: "Allison"}},
+				}},
+			},
+			Stats: &spannerpb.ResultSetStats{
+				QueryPlan: &spannerpb.QueryPlan{PlanNodes: []*spannerpb.PlanNode{
+					{Index: 0, Kind: spannerpb.PlanNode_RELATIONAL, DisplayName: "Distributed Union",
+						ChildLinks: []*spannerpb.PlanNode_ChildLink{{ChildIndex: 1}}},
+					{Index: 1, Kind: spannerpb.PlanNode_RELATIONAL, DisplayName: "Scan", Metadata: metadata,
+						ChildLinks: []*spannerpb.PlanNode_ChildLink{{ChildIndex: 2, Type: "Scan"}}},
+					{Index: 2, Kind: spannerpb.PlanNode_SCALAR, DisplayName: "Reference",
+						ShortRepresentation: &spannerpb.PlanNode_ShortRepresentation{Description: "last_name"}},
+				}},
+				QueryStats: queryStats,
+			},
+		},
+	})
+}
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	const query = "SELECT * FROM `plan_singers` WHERE last_name = @p1"
+	putQueryPlanResult(server, query)
+	var singers []planSinger
+	plan, err := Explain(db, func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("last_name = ?", "Allison").Find(&singers)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := len(singers), 0; g != w {
+		t.Fatalf("singers length mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	req := getLastSqlRequest(server)
+	if g, w := req.Sql, query; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.QueryMode, spannerpb.ExecuteSqlRequest_PLAN; g != w {
+		t.Fatalf("query mode mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := len(plan.Nodes), 3; g != w {
+		t.Fatalf("node count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	scan := plan.Root.Children[0]
+	if g, w := scan.DisplayName, "Scan"; g != w {
+		t.Fatalf("child name mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := scan.Children[0].Description, "last_name"; g != w {
+		t.Fatalf("description mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := scan.ChildTypes, []string{"Scan"}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("child types mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := plan.FullScans(), []*PlanNode{scan}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("full scans mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := scan.ScanTarget(), "plan_singers"; g != w {
+		t.Fatalf("scan target mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestProfile(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	const query = "SELECT * FROM `plan_singers` WHERE last_name = @p1"
+	putQueryPlanResult(server, query)
+	var singers []planSinger
+	stats, err := Profile(db, func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("last_name = ?", "Allison").Find(&singers)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := singers, []planSinger{{ID: 1, LastName: "Allison"}}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("singers mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	// The query is only executed once, and not again by the default gorm
+	// query callback.
+	var requests []*spannerpb.ExecuteSqlRequest
+	for _, req := range requestsOfType(drainRequestsFromServer(server.TestSpanner), reflect.TypeOf(&spannerpb.ExecuteSqlRequest{})) {
+		if req.(*spannerpb.ExecuteSqlRequest).Sql == query {
+			requests = append(requests, req.(*spannerpb.ExecuteSqlRequest))
+		}
+	}
+	if g, w := len(requests), 1; g != w {
+		t.Fatalf("request count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := requests[0].QueryMode, spannerpb.ExecuteSqlRequest_PROFILE; g != w {
+		t.Fatalf("query mode mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := stats.ElapsedTime, 1500*time.Microsecond; g != w {
+		t.Errorf("elapsed time mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := stats.CPUTime, 1250*time.Microsecond; g != w {
+		t.Errorf("cpu time mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := stats.RowsReturned, int64(1); g != w {
+		t.Errorf("rows returned mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := stats.RowsScanned, int64(10); g != w {
+		t.Errorf("rows scanned mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := stats.Plan.Root.DisplayName, "Distributed Union"; g != w {
+		t.Errorf("plan root mismatch\n Got: %v\nWant: %v", g, w)
+	}
+
+	// Queries that are not profiled use the normal query mode.
+	if err := db.Where("last_name = ?", "Allison").Find(&singers).Error; err != nil {
+		t.Fatal(err)
+	}
+	if g, w := getLastSqlRequest(server).QueryMode, spannerpb.ExecuteSqlRequest_NORMAL; g != w {
+		t.Fatalf("query mode mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+type warningLogger struct {
+	logger.Interface
+	warnings []string
+}
+
+func (l *warningLogger) Warn(_ context.Context, msg string, data ...interface{}) {
+	l.warnings = append(l.warnings, fmt.Sprintf(msg, data...))
+}
+
+func TestQueryPlanChecker(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+	l := &warningLogger{Interface: db.Logger}
+	db.Logger = l
+	if err := db.Use(NewQueryPlanChecker()); err != nil {
+		t.Fatal(err)
+	}
+
+	const query = "SELECT * FROM `plan_singers` WHERE last_name = @p1"
+	putQueryPlanResult(server, query)
+	var singers []planSinger
+	if err := db.Where("last_name = ?", "Allison").Find(&singers).Error; err != nil {
+		t.Fatal(err)
+	}
+	if g, w := len(singers), 1; g != w {
+		t.Fatalf("singers length mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	want := []string{"query executes a full scan of plan_singers without a FORCE_INDEX hint: SELECT * FROM `plan_singers` WHERE last_name = ?"}
+	if g, w := l.warnings, want; !reflect.DeepEqual(g, w) {
+		t.Fatalf("warnings mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
@@ -0,0 +1,487 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/googleapis/go-gorm-spanner/internal/gormutil"
+	spannerdriver "github.com/googleapis/go-sql-spanner"
+	"google.golang.org/api/iterator"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+type readStalenessKey struct{}
+
+// WithReadStaleness returns a context that instructs ReadByKeys and
+// ReadUsingIndex to read the rows with the given timestamp bound. Reads use
+// the read-only staleness of the connection by default.
+//
+// Example:
+//
+//	ctx := spannergorm.WithReadStaleness(ctx, spanner.ExactStaleness(15*time.Second))
+//	err := spannergorm.ReadByKeys(db.WithContext(ctx), &singers, spanner.Key{1})
+func WithReadStaleness(ctx context.Context, bound spanner.TimestampBound) context.Context {
+	return context.WithValue(ctx, readStalenessKey{}, bound)
+}
+
+// ReadByKeys reads the rows of the table of the model in dest with the given
+// keys into dest. keys can be a single spanner.Key, a spanner.KeyRange, or a
+// combination of keys and key ranges that is created with spanner.KeySets.
+// The rows are read with the Spanner Read API instead of a SQL query, which
+// means that Spanner does not need to compile a query. This is more
+// efficient for point reads and small key ranges.
+//
+// dest must be a pointer to a struct or a pointer to a slice of structs.
+// ReadByKeys returns gorm.ErrRecordNotFound if dest is a pointer to a struct
+// and no row was found. The rows are returned in the order of the primary
+// key. All columns of the model are read, unless db contains a Select
+// clause. db may contain a Limit clause, but no other conditions.
+//
+// Rows are read with a single-use read-only transaction with the read-only
+// staleness of the connection, or the staleness in the context, see
+// WithReadStaleness. The Spanner database/sql driver does not support the
+// Read API in transactions. ReadByKeys therefore executes an equivalent SQL
+// query if db is a transaction.
+//
+// Example:
+//
+//	var singers []Singer
+//	err := spannergorm.ReadByKeys(db, &singers, spanner.KeySets(spanner.Key{1}, spanner.Key{2}))
+//
+//	// Read all tracks of album 1.
+//	var tracks []Track
+//	err = spannergorm.ReadByKeys(db, &tracks, spanner.Key{1}.AsPrefix())
+//
+// This function can be used for both GoogleSQL-dialect and PostgreSQL-dialect databases.
+func ReadByKeys(db *gorm.DB, dest interface{}, keys spanner.KeySet) error {
+	return read(db, dest, "", keys)
+}
+
+// ReadUsingIndex reads the rows of the table of the model in dest through
+// the given secondary index. keys contains the keys of the index, and the
+// rows are returned in the order of the index. The read can only return the
+// columns that are part of the index key, the primary key, or the STORING
+// clause of the index. Use a Select clause on db to select those columns if
+// the index does not contain all columns of the model.
+//
+// See ReadByKeys for more information.
+//
+// Example:
+//
+//	var singers []Singer
+//	err := spannergorm.ReadUsingIndex(db.Select("id", "last_name"), &singers, "idx_singers_last_name", spanner.Key{"Allison"})
+//
+// This function can be used for both GoogleSQL-dialect and PostgreSQL-dialect databases.
+func ReadUsingIndex(db *gorm.DB, dest interface{}, index string, keys spanner.KeySet) error {
+	if index == "" {
+		return fmt.Errorf("missing index name")
+	}
+	return read(db, dest, index, keys)
+}
+
+// read reads the rows with the given keys into dest.
+func read(db *gorm.DB, dest interface{}, index string, keys spanner.KeySet) error {
+	if keys == nil {
+		return fmt.Errorf("missing key set")
+	}
+	for _, name := range []string{"WHERE", "ORDER BY", "GROUP BY"} {
+		if _, ok := db.Statement.Clauses[name]; ok {
+			return fmt.Errorf("reads by key do not support queries with %s", name)
+		}
+	}
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(dest); err != nil {
+		return err
+	}
+	if _, ok := gormutil.UnpreparedConnPool(db.Statement.ConnPool).(gorm.TxCommitter); ok {
+		return readInTransaction(db, dest, stmt.Schema, index, keys)
+	}
+	fields, err := readFields(stmt.Schema, db.Statement.Selects)
+	if err != nil {
+		return err
+	}
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = field.DBName
+	}
+	options := &spanner.ReadOptions{Index: index}
+	if limit, ok := db.Statement.Clauses["LIMIT"].Expression.(clause.Limit); ok && limit.Limit != nil {
+		options.Limit = *limit.Limit
+	}
+
+	ctx := db.Statement.Context
+	return withSpannerConn(ctx, db, func(conn spannerdriver.SpannerConn) error {
+		client, err := conn.UnderlyingClient()
+		if err != nil {
+			return err
+		}
+		bound, ok := ctx.Value(readStalenessKey{}).(spanner.TimestampBound)
+		if !ok {
+			bound = conn.ReadOnlyStaleness()
+		}
+		it := client.Single().WithTimestampBound(bound).ReadWithOptions(ctx, stmt.Schema.Table, keys, columns, options)
+		defer it.Stop()
+		return scanRows(db, dest, stmt.Schema, fields, it)
+	})
+}
+
+// withSpannerConn calls f with the Spanner connection of db. db may not be a
+// transaction.
+func withSpannerConn(ctx context.Context, db *gorm.DB, f func(conn spannerdriver.SpannerConn) error) error {
+	conn, ok := gormutil.UnpreparedConnPool(db.Statement.ConnPool).(*sql.Conn)
+	if !ok {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		if conn, err = sqlDB.Conn(ctx); err != nil {
+			return err
+		}
+		defer func() { _ = conn.Close() }()
+	}
+	return conn.Raw(func(driverConn any) error {
+		spannerConn, ok := driverConn.(spannerdriver.SpannerConn)
+		if !ok {
+			return fmt.Errorf("reads by key are only supported for Spanner")
+		}
+		return f(spannerConn)
+	})
+}
+
+// readFields returns the fields of the selected columns, or all fields with
+// a column if no columns are selected.
+func readFields(s *schema.Schema, selects []string) ([]*schema.Field, error) {
+	if len(selects) == 0 {
+		fields := make([]*schema.Field, 0, len(s.DBNames))
+		for _, name := range s.DBNames {
+			if field := s.FieldsByDBName[name]; field.Readable {
+				fields = append(fields, field)
+			}
+		}
+		return fields, nil
+	}
+	var fields []*schema.Field
+	for _, selected := range selects {
+		for _, column := range strings.Split(selected, ",") {
+			field := s.LookUpField(strings.TrimSpace(column))
+			if field == nil || field.DBName == "" {
+				return nil, fmt.Errorf("model %s has no column %s", s.Name, strings.TrimSpace(column))
+			}
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+// scanRows loads the rows in the given iterator into dest.
+func scanRows(db *gorm.DB, dest interface{}, s *schema.Schema, fields []*schema.Field, it *spanner.RowIterator) error {
+	ctx := db.Statement.Context
+	target := reflect.Indirect(reflect.ValueOf(dest))
+	isSlice := target.Kind() == reflect.Slice
+	if isSlice {
+		target.SetLen(0)
+	}
+	var count int
+	for {
+		row, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		elem := target
+		if isSlice {
+			elem = reflect.New(s.ModelType).Elem()
+		}
+		for i, field := range fields {
+			value, err := readColumnValue(row, i)
+			if err != nil {
+				return fmt.Errorf("%s: %w", field.DBName, err)
+			}
+			if err := field.Set(ctx, elem, value); err != nil {
+				return fmt.Errorf("%s: %w", field.DBName, err)
+			}
+		}
+		if s.AfterFind {
+			if hook, ok := elem.Addr().Interface().(callbacks.AfterFindInterface); ok {
+				if err := hook.AfterFind(db); err != nil {
+					return err
+				}
+			}
+		}
+		count++
+		if !isSlice {
+			break
+		}
+		if target.Type().Elem().Kind() == reflect.Ptr {
+			elem = elem.Addr()
+		}
+		target.Set(reflect.Append(target, elem))
+	}
+	db.RowsAffected = int64(count)
+	if !isSlice && count == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// readInTransaction reads the rows with the given keys into dest with a SQL
+// query in the transaction of db.
+func readInTransaction(db *gorm.DB, dest interface{}, s *schema.Schema, index string, keys spanner.KeySet) error {
+	var columns []keyColumn
+	// Add the clauses to a copy of the statement, so the query of the caller
+	// can be used again.
+	tx := db.Session(&gorm.Session{})
+	if index == "" {
+		for _, field := range s.PrimaryFields {
+			columns = append(columns, keyColumn{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}})
+		}
+	} else {
+		idx := s.LookIndex(index)
+		if idx == nil {
+			return fmt.Errorf("model %s has no index %s, reads using an index in a transaction require the index definition in the model", s.Name, index)
+		}
+		for _, option := range idx.Fields {
+			columns = append(columns, keyColumn{
+				Column: clause.Column{Table: clause.CurrentTable, Name: option.DBName},
+				Desc:   strings.EqualFold(option.Sort, "DESC"),
+			})
+		}
+		tx = tx.Clauses(ForceIndex(index))
+	}
+	if len(columns) == 0 {
+		return gorm.ErrPrimaryKeyRequired
+	}
+	condition, err := keySetCondition(columns, keys)
+	if err != nil {
+		return err
+	}
+	if condition != nil {
+		tx = tx.Where(condition)
+	}
+	for _, column := range columns {
+		tx = tx.Order(clause.OrderByColumn{Column: column.Column, Desc: column.Desc})
+	}
+	if err := tx.Find(dest).Error; err != nil {
+		return err
+	}
+	if reflect.Indirect(reflect.ValueOf(dest)).Kind() != reflect.Slice && tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+var (
+	allKeysType  = reflect.TypeOf(spanner.AllKeys())
+	keySetsType  = reflect.TypeOf(spanner.KeySets())
+	noKeysClause = clause.Expr{SQL: "FALSE"}
+)
+
+// keySetCondition returns the condition for the rows with a key in the given
+// key set. The condition is nil if the key set contains all keys.
+func keySetCondition(columns []keyColumn, keys spanner.KeySet) (clause.Expression, error) {
+	switch k := keys.(type) {
+	case spanner.Key:
+		if len(k) == 0 || len(k) > len(columns) {
+			return nil, fmt.Errorf("key %v does not match the key columns", k)
+		}
+		conditions := make([]clause.Expression, len(k))
+		for i, value := range k {
+			conditions[i] = clause.Eq{Column: columns[i].Column, Value: value}
+		}
+		return clause.And(conditions...), nil
+	case spanner.KeyRange:
+		if len(k.Start) > len(columns) || len(k.End) > len(columns) {
+			return nil, fmt.Errorf("key range %v does not match the key columns", k)
+		}
+		var conditions []clause.Expression
+		if len(k.Start) > 0 {
+			closed := k.Kind == spanner.ClosedOpen || k.Kind == spanner.ClosedClosed
+			conditions = append(conditions, compareKey(columns, k.Start, true, closed))
+		}
+		if len(k.End) > 0 {
+			closed := k.Kind == spanner.OpenClosed || k.Kind == spanner.ClosedClosed
+			conditions = append(conditions, compareKey(columns, k.End, false, closed))
+		}
+		if len(conditions) == 0 {
+			return nil, nil
+		}
+		return clause.And(conditions...), nil
+	}
+	switch reflect.TypeOf(keys) {
+	case allKeysType:
+		return nil, nil
+	case keySetsType:
+		// The union of key sets is a slice of key sets.
+		union := reflect.ValueOf(keys)
+		if union.Len() == 0 {
+			return noKeysClause, nil
+		}
+		conditions := make([]clause.Expression, union.Len())
+		for i := range conditions {
+			condition, err := keySetCondition(columns, union.Index(i).Interface().(spanner.KeySet))
+			if err != nil {
+				return nil, err
+			}
+			if condition == nil {
+				return nil, nil
+			}
+			conditions[i] = condition
+		}
+		if len(conditions) == 1 {
+			return conditions[0], nil
+		}
+		return clause.Or(conditions...), nil
+	}
+	return nil, fmt.Errorf("unsupported key set type %T", keys)
+}
+
+// readColumnValue returns the value of the given column in the same form as
+// the value that is returned by the Spanner database/sql driver for a query.
+func readColumnValue(row *spanner.Row, i int) (interface{}, error) {
+	t := row.ColumnType(i)
+	switch t.Code {
+	case spannerpb.TypeCode_INT64, spannerpb.TypeCode_ENUM:
+		var v spanner.NullInt64
+		if err := row.Column(i, &v); err != nil || !v.Valid {
+			return nil, err
+		}
+		return v.Int64, nil
+	case spannerpb.TypeCode_FLOAT32:
+		var v spanner.NullFloat32
+		if err := row.Column(i, &v); err != nil || !v.Valid {
+			return nil, err
+		}
+		return v.Float32, nil
+	case spannerpb.TypeCode_FLOAT64:
+		var v spanner.NullFloat64
+		if err := row.Column(i, &v); err != nil || !v.Valid {
+			return nil, err
+		}
+		return v.Float64, nil
+	case spannerpb.TypeCode_NUMERIC:
+		if t.TypeAnnotation == spannerpb.TypeAnnotationCode_PG_NUMERIC {
+			var v spanner.PGNumeric
+			if err := row.Column(i, &v); err != nil || !v.Valid {
+				return nil, err
+			}
+			return v.Numeric, nil
+		}
+		var v spanner.NullNumeric
+		if err := row.Column(i, &v); err != nil || !v.Valid {
+			return nil, err
+		}
+		return v.Numeric, nil
+	case spannerpb.TypeCode_STRING:
+		var v spanner.NullString
+		if err := row.Column(i, &v); err != nil || !v.Valid {
+			return nil, err
+		}
+		return v.StringVal, nil
+	case spannerpb.TypeCode_JSON:
+		// JSON values are returned as a JSON struct, also for NULL values.
+		if t.TypeAnnotation == spannerpb.TypeAnnotationCode_PG_JSONB {
+			var v spanner.PGJsonB
+			err := row.Column(i, &v)
+			return v, err
+		}
+		var v spanner.NullJSON
+		err := row.Column(i, &v)
+		return v, err
+	case spannerpb.TypeCode_UUID:
+		var v spanner.NullUUID
+		if err := row.Column(i, &v); err != nil || !v.Valid {
+			return nil, err
+		}
+		return v.UUID.String(), nil
+	case spannerpb.TypeCode_BYTES, spannerpb.TypeCode_PROTO:
+		var v []byte
+		err := row.Column(i, &v)
+		return v, err
+	case spannerpb.TypeCode_BOOL:
+		var v spanner.NullBool
+		if err := row.Column(i, &v); err != nil || !v.Valid {
+			return nil, err
+		}
+		return v.Bool, nil
+	case spannerpb.TypeCode_DATE:
+		var v spanner.NullDate
+		if err := row.Column(i, &v); err != nil || !v.Valid {
+			return nil, err
+		}
+		return v.Date.String(), nil
+	case spannerpb.TypeCode_TIMESTAMP:
+		var v spanner.NullTime
+		if err := row.Column(i, &v); err != nil || !v.Valid {
+			return nil, err
+		}
+		return v.Time, nil
+	case spannerpb.TypeCode_ARRAY:
+		elemType, ok := readArrayElementTypes[t.ArrayElementType.Code]
+		if !ok {
+			return nil, fmt.Errorf("unsupported array element type %v", t.ArrayElementType.Code)
+		}
+		if t.ArrayElementType.Code == spannerpb.TypeCode_NUMERIC && t.ArrayElementType.TypeAnnotation == spannerpb.TypeAnnotationCode_PG_NUMERIC {
+			elemType = reflect.TypeOf(spanner.PGNumeric{})
+		}
+		v := reflect.New(reflect.SliceOf(elemType))
+		if err := row.Column(i, v.Interface()); err != nil {
+			return nil, err
+		}
+		if t.ArrayElementType.Code == spannerpb.TypeCode_JSON && t.ArrayElementType.TypeAnnotation == spannerpb.TypeAnnotationCode_PG_JSONB {
+			values := v.Elem().Interface().([]spanner.NullJSON)
+			if values == nil {
+				return []spanner.PGJsonB(nil), nil
+			}
+			jsonb := make([]spanner.PGJsonB, len(values))
+			for i, value := range values {
+				jsonb[i] = spanner.PGJsonB{Value: value.Value, Valid: value.Valid}
+			}
+			return jsonb, nil
+		}
+		return v.Elem().Interface(), nil
+	}
+	return nil, fmt.Errorf("unsupported column type %v", t.Code)
+}
+
+// readArrayElementTypes contains the element types that are used to decode
+// arrays, which are the same types as the Spanner database/sql driver uses.
+var readArrayElementTypes = map[spannerpb.TypeCode]reflect.Type{
+	spannerpb.TypeCode_INT64:     reflect.TypeOf(spanner.NullInt64{}),
+	spannerpb.TypeCode_ENUM:      reflect.TypeOf(spanner.NullInt64{}),
+	spannerpb.TypeCode_FLOAT32:   reflect.TypeOf(spanner.NullFloat32{}),
+	spannerpb.TypeCode_FLOAT64:   reflect.TypeOf(spanner.NullFloat64{}),
+	spannerpb.TypeCode_NUMERIC:   reflect.TypeOf(spanner.NullNumeric{}),
+	spannerpb.TypeCode_STRING:    reflect.TypeOf(spanner.NullString{}),
+	spannerpb.TypeCode_JSON:      reflect.TypeOf(spanner.NullJSON{}),
+	spannerpb.TypeCode_UUID:      reflect.TypeOf(spanner.NullUUID{}),
+	spannerpb.TypeCode_BYTES:     reflect.TypeOf([]byte{}),
+	spannerpb.TypeCode_PROTO:     reflect.TypeOf([]byte{}),
+	spannerpb.TypeCode_BOOL:      reflect.TypeOf(spanner.NullBool{}),
+	spannerpb.TypeCode_DATE:      reflect.TypeOf(spanner.NullDate{}),
+	spannerpb.TypeCode_TIMESTAMP: reflect.TypeOf(spanner.NullTime{}),
+}
@@ -0,0 +1,268 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/googleapis/go-sql-spanner/testutil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func getLastReadRequest(server *testutil.MockedSpannerInMemTestServer) *spannerpb.ReadRequest {
+	reqs := requestsOfType(drainRequestsFromServer(server.TestSpanner), reflect.TypeOf(&spannerpb.ReadRequest{}))
+	if len(reqs) > 0 {
+		return reqs[len(reqs)-1].(*spannerpb.ReadRequest)
+	}
+	return &spannerpb.ReadRequest{}
+}
+
+func keyValues(values ...string) *structpb.ListValue {
+	list := &structpb.ListValue{}
+	for _, value := range values {
+		list.Values = append(list.Values, structpb.NewStringValue(value))
+	}
+	return list
+}
+
+func TestReadByKeys(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+	putTrackResult(server, "SELECT album_id, track_number, title FROM page_tracks", pageTrack{1, 1, "One"}, pageTrack{2, 1, "Two"})
+
+	var tracks []pageTrack
+	if err := ReadByKeys(db, &tracks, spanner.KeySets(
+		spanner.Key{1, 1},
+		spanner.KeyRange{Start: spanner.Key{2}, End: spanner.Key{3}, Kind: spanner.ClosedOpen},
+	)); err != nil {
+		t.Fatal(err)
+	}
+	if g, w := tracks, []pageTrack{{1, 1, "One"}, {2, 1, "Two"}}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("tracks mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	req := getLastReadRequest(server)
+	if g, w := req.Table, "page_tracks"; g != w {
+		t.Fatalf("table mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.Columns, []string{"album_id", "track_number", "title"}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("columns mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	wantKeys := &spannerpb.KeySet{
+		Keys: []*structpb.ListValue{keyValues("1", "1")},
+		Ranges: []*spannerpb.KeyRange{{
+			StartKeyType: &spannerpb.KeyRange_StartClosed{StartClosed: keyValues("2")},
+			EndKeyType:   &spannerpb.KeyRange_EndOpen{EndOpen: keyValues("3")},
+		}},
+	}
+	if g, w := req.KeySet, wantKeys; !proto.Equal(g, w) {
+		t.Fatalf("key set mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g := req.Transaction.GetSingleUse().GetReadOnly().GetStrong(); !g {
+		t.Fatalf("read is not strong: %v", req.Transaction)
+	}
+}
+
+func TestReadByKeysWithStaleness(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+	putTrackResult(server, "SELECT album_id, track_number, title FROM page_tracks", pageTrack{1, 1, "One"})
+
+	var track pageTrack
+	ctx := WithReadStaleness(context.Background(), spanner.ExactStaleness(10*time.Second))
+	if err := ReadByKeys(db.WithContext(ctx), &track, spanner.Key{1, 1}); err != nil {
+		t.Fatal(err)
+	}
+	if g, w := track, (pageTrack{1, 1, "One"}); g != w {
+		t.Fatalf("track mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	req := getLastReadRequest(server)
+	if g, w := req.Transaction.GetSingleUse().GetReadOnly().GetExactStaleness(), durationpb.New(10*time.Second); !proto.Equal(g, w) {
+		t.Fatalf("staleness mismatch\n Got: %v\nWant: %v", g, w)
+	}
+
+	putTrackResult(server, "SELECT album_id, track_number, title FROM page_tracks")
+	if err := ReadByKeys(db, &track, spanner.Key{1, 2}); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("error mismatch\n Got: %v\nWant: %v", err, gorm.ErrRecordNotFound)
+	}
+	if err := ReadByKeys(db.Where("title IS NULL"), &track, spanner.Key{1, 2}); err == nil {
+		t.Fatal("missing error for read with WHERE")
+	}
+}
+
+func TestReadUsingIndex(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+	_ = server.TestSpanner.PutStatementResult("SELECT title, album_id FROM page_tracks", &testutil.StatementResult{
+		Type: testutil.StatementResultResultSet,
+		ResultSet: &spannerpb.ResultSet{
+			Metadata: &spannerpb.ResultSetMetadata{
+				RowType: &spannerpb.StructType{
+					Fields: []*spannerpb.StructType_Field{
+						{Name: "title", Type: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}},
+						{Name: "album_id", Type: &spannerpb.Type{Code: spannerpb.TypeCode_INT64}},
+					},
+				},
+			},
+			Rows: []*structpb.ListValue{
+				{Values: []*structpb.Value{structpb.NewStringValue("One"), structpb.NewStringValue("1")}},
+				{Values: []*structpb.Value{structpb.NewStringValue("One"), structpb.NewNullValue()}},
+			},
+		},
+	})
+
+	var tracks []*pageTrack
+	if err := ReadUsingIndex(db.Select("title", "album_id").Limit(2), &tracks, "idx_tracks_title", spanner.Key{"One"}); err != nil {
+		t.Fatal(err)
+	}
+	if g, w := len(tracks), 2; g != w {
+		t.Fatalf("track count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := *tracks[0], (pageTrack{AlbumID: 1, Title: "One"}); g != w {
+		t.Fatalf("track mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := *tracks[1], (pageTrack{Title: "One"}); g != w {
+		t.Fatalf("track mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	req := getLastReadRequest(server)
+	if g, w := req.Index, "idx_tracks_title"; g != w {
+		t.Fatalf("index mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.Limit, int64(2); g != w {
+		t.Fatalf("limit mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestReadByKeysInTransaction(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+	const query = "SELECT * FROM `page_tracks` WHERE ((`page_tracks`.`album_id` = @p1 AND `page_tracks`.`track_number` = @p2) OR " +
+		"(`page_tracks`.`album_id` >= @p3 AND (`page_tracks`.`album_id` < @p4 OR (`page_tracks`.`album_id` = @p5 AND `page_tracks`.`track_number` <= @p6)))) " +
+		"ORDER BY `page_tracks`.`album_id`,`page_tracks`.`track_number`"
+	putTrackResult(server, query, pageTrack{1, 1, "One"})
+
+	var tracks []pageTrack
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		return ReadByKeys(tx, &tracks, spanner.KeySets(
+			spanner.Key{1, 1},
+			spanner.KeyRange{Start: spanner.Key{2}, End: spanner.Key{3, 5}, Kind: spanner.ClosedClosed},
+		))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if g, w := tracks, []pageTrack{{1, 1, "One"}}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("tracks mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	req := getLastSqlRequest(server)
+	if g, w := req.Sql, query; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if req.Transaction.GetId() == nil && req.Transaction.GetBegin() == nil {
+		t.Fatalf("query was not executed in the transaction: %v", req.Transaction)
+	}
+}
+
+func TestReadByKeysInTransactionReusesQuery(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+	const query = "SELECT * FROM `page_tracks` WHERE `page_tracks`.`album_id` = @p1 AND `page_tracks`.`track_number` = @p2 " +
+		"ORDER BY `page_tracks`.`album_id`,`page_tracks`.`track_number` LIMIT @p3"
+	putTrackResult(server, query, pageTrack{1, 1, "One"})
+
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		q := tx.Limit(10)
+		for i := 0; i < 2; i++ {
+			var tracks []pageTrack
+			if err := ReadByKeys(q, &tracks, spanner.Key{1, 1}); err != nil {
+				return err
+			}
+			if g, w := getLastSqlRequest(server).Sql, query; g != w {
+				t.Fatalf("%d: sql mismatch\n Got: %v\nWant: %v", i, g, w)
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestKeySetCondition(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+	dryRun := db.Session(&gorm.Session{DryRun: true})
+	columns := []keyColumn{{Column: clause.Column{Table: clause.CurrentTable, Name: "title"}, Desc: true}, {Column: clause.Column{Table: clause.CurrentTable, Name: "album_id"}}}
+
+	for _, test := range []struct {
+		keys spanner.KeySet
+		sql  string
+	}{
+		{
+			keys: spanner.AllKeys(),
+			sql:  "SELECT * FROM `page_tracks`",
+		},
+		{
+			keys: spanner.KeySets(),
+			sql:  "SELECT * FROM `page_tracks` WHERE FALSE",
+		},
+		{
+			keys: spanner.Key{"One"}.AsPrefix(),
+			sql:  "SELECT * FROM `page_tracks` WHERE `page_tracks`.`title` <= ? AND `page_tracks`.`title` >= ?",
+		},
+		{
+			keys: spanner.KeyRange{Start: spanner.Key{"A", 1}, Kind: spanner.OpenOpen},
+			sql:  "SELECT * FROM `page_tracks` WHERE (`page_tracks`.`title` < ? OR (`page_tracks`.`title` = ? AND `page_tracks`.`album_id` > ?))",
+		},
+		{
+			keys: spanner.KeySets(spanner.Key{"A"}, spanner.AllKeys()),
+			sql:  "SELECT * FROM `page_tracks`",
+		},
+	} {
+		condition, err := keySetCondition(columns, test.keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx := dryRun
+		if condition != nil {
+			tx = tx.Where(condition)
+		}
+		if g, w := tx.Find(&[]pageTrack{}).Statement.SQL.String(), test.sql; g != w {
+			t.Fatalf("sql mismatch for %v\n Got: %v\nWant: %v", test.keys, g, w)
+		}
+	}
+	if _, err := keySetCondition(columns, spanner.Key{"A", 1, 2}); err == nil {
+		t.Fatal("missing error for key with too many values")
+	}
+}
@@ -32,7 +32,19 @@ import (
 // gorm database, and retries the transaction if it is aborted by Spanner.
 //
 // This function can be used for both GoogleSQL-dialect and PostgreSQL-dialect databases.
+//
+// The transaction is traced if an OpenTelemetryPlugin has been registered for
+// the database.
 func RunTransaction(ctx context.Context, db *gorm.DB, fc func(tx *gorm.DB) error, opts ...*sql.TxOptions) error {
+	if plugin := openTelemetryPlugin(db); plugin != nil {
+		return plugin.runTransaction(ctx, db, fc, opts...)
+	}
+	return runTransaction(ctx, db, fc, nil, opts...)
+}
+
+// runTransaction executes and retries a transaction. The onRetry function is
+// called with the error of each attempt that was aborted, if it is not nil.
+func runTransaction(ctx context.Context, db *gorm.DB, fc func(tx *gorm.DB) error, onRetry func(err error), opts ...*sql.TxOptions) error {
 	// Disable internal (checksum-based) retries on the Spanner database/SQL connection.
 	// Note: gorm also only uses the first option, so it is safe to pick just the first element in the slice.
 	if len(opts) > 0 && opts[0] != nil {
@@ -47,6 +59,9 @@ func RunTransaction(ctx context.Context, db *gorm.DB, fc func(tx *gorm.DB) error
 		if !ok || s.Code() != codes.Aborted {
 			return err
 		}
+		if onRetry != nil {
+			onRetry(err)
+		}
 		delay, ok := spanner.ExtractRetryDelay(err)
 		if !ok {
 			// Use a random backoff time if no backoff time was included in the error.
@@ -0,0 +1,181 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// TableGrant is a privilege on a table or view that is granted to a database
+// role.
+type TableGrant struct {
+	// Privilege is the privilege that is granted, for example SELECT or UPDATE.
+	Privilege string
+	// Role is the database role that the privilege is granted to.
+	Role string
+	// Columns optionally limits the privilege to the given columns. The
+	// privilege is granted on the entire table if no columns are specified.
+	Columns []string
+}
+
+// TableGrants can be implemented by models to declare the privileges on the
+// table or view of the model. AutoMigrate creates the roles and grants the
+// privileges that do not yet exist. AutoMigrate does not revoke privileges
+// that are not returned by TableGrants.
+//
+// Example:
+//
+//	func (Singer) TableGrants() []spannergorm.TableGrant {
+//	  return []spannergorm.TableGrant{
+//	    {Privilege: "SELECT", Role: "analyst", Columns: []string{"id", "full_name"}},
+//	    {Privilege: "SELECT", Role: "admin"},
+//	  }
+//	}
+type TableGrants interface {
+	TableGrants() []TableGrant
+}
+
+// ErrDatabaseRoleRequiresDSN is returned if a database role is used with a
+// dialector that was not created with a DSN, or that uses an existing
+// connection.
+var ErrDatabaseRoleRequiresDSN = errors.New("a database role can only be used in combination with a DSN")
+
+// dataSourcer is implemented by dialectors of other packages, such as the
+// PostgreSQL dialector, that open connections with a DSN.
+type dataSourcer interface {
+	DataSource() (driverName, dsn string)
+}
+
+// databaseRolePool is a connection pool that has been opened by
+// WithDatabaseRole, and the number of sessions that use the pool.
+type databaseRolePool struct {
+	db   *sql.DB
+	refs int
+}
+
+// databaseRolePools contains the connection pools that are in use by
+// sessions that were returned by WithDatabaseRole. The key is the DSN
+// including the database role.
+var (
+	databaseRolePoolsMu sync.Mutex
+	databaseRolePools   = make(map[string]*databaseRolePool)
+)
+
+// WithDatabaseRole returns a new session of the given gorm.DB that executes
+// all statements with the given database role. The session uses a separate
+// connection pool, which is shared by all sessions for the same database and
+// role. The dialector of the gorm.DB must have been created with a DSN.
+//
+// The returned release function must be called when the session is no
+// longer used. The connection pool is closed when all sessions that use the
+// pool have been released.
+//
+// Example:
+//
+//	analystDB, release, err := spannergorm.WithDatabaseRole(db, "analyst")
+//	if err != nil {
+//	  return err
+//	}
+//	defer release()
+//	var singers []Singer
+//	err = analystDB.Select("id", "full_name").Find(&singers).Error
+//
+// This function can be used for both GoogleSQL-dialect and PostgreSQL-dialect databases.
+func WithDatabaseRole(db *gorm.DB, role string) (*gorm.DB, func() error, error) {
+	var driverName, dsn string
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		if d.Config != nil && d.Conn == nil {
+			driverName, dsn = d.DriverName, d.DSN
+		}
+	case Dialector:
+		if d.Config != nil && d.Conn == nil {
+			driverName, dsn = d.DriverName, d.DSN
+		}
+	case dataSourcer:
+		driverName, dsn = d.DataSource()
+	}
+	if dsn == "" {
+		return nil, nil, ErrDatabaseRoleRequiresDSN
+	}
+	if driverName == "" {
+		driverName = "spanner"
+	}
+	pool, release, err := acquireDatabaseRolePool(driverName, DSNWithDatabaseRole(dsn, role))
+	if err != nil {
+		return nil, nil, err
+	}
+	tx := db.Session(&gorm.Session{})
+	tx.Statement.ConnPool = pool
+	return tx, release, nil
+}
+
+// acquireDatabaseRolePool returns the connection pool for the given DSN, and
+// a function that releases the pool. The pool is opened if it is not in use.
+func acquireDatabaseRolePool(driverName, dsn string) (*sql.DB, func() error, error) {
+	databaseRolePoolsMu.Lock()
+	defer databaseRolePoolsMu.Unlock()
+	pool, ok := databaseRolePools[dsn]
+	if !ok {
+		db, err := sql.Open(driverName, dsn)
+		if err != nil {
+			return nil, nil, err
+		}
+		pool = &databaseRolePool{db: db}
+		databaseRolePools[dsn] = pool
+	}
+	pool.refs++
+	var once sync.Once
+	release := func() (err error) {
+		once.Do(func() {
+			databaseRolePoolsMu.Lock()
+			defer databaseRolePoolsMu.Unlock()
+			if pool.refs--; pool.refs == 0 {
+				delete(databaseRolePools, dsn)
+				err = pool.db.Close()
+			}
+		})
+		return err
+	}
+	return pool.db, release, nil
+}
+
+// DSNWithDatabaseRole adds the given database role to a connection string.
+// A database role that is already in the connection string is replaced.
+func DSNWithDatabaseRole(dsn, role string) string {
+	if role == "" {
+		return dsn
+	}
+	// The connection properties follow the first '?' or ';' and are separated
+	// by ';'.
+	base, properties := dsn, ""
+	if i := strings.IndexAny(dsn, "?;"); i > -1 {
+		base, properties = dsn[:i+1], dsn[i+1:]
+	} else {
+		base += ";"
+	}
+	var res []string
+	for _, property := range strings.Split(properties, ";") {
+		if key, _, _ := strings.Cut(property, "="); property != "" && !strings.EqualFold(strings.TrimSpace(key), "databaseRole") {
+			res = append(res, property)
+		}
+	}
+	return base + strings.Join(append(res, "databaseRole="+role), ";")
+}
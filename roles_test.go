@@ -0,0 +1,216 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type grantedSinger struct {
+	ID       int64
+	FullName string
+	Secret   string
+}
+
+func (grantedSinger) TableGrants() []TableGrant {
+	return []TableGrant{
+		{Privilege: "SELECT", Role: "analyst", Columns: []string{"ID", "full_name"}},
+		{Privilege: "select", Role: "admin"},
+		{Privilege: "UPDATE", Role: "admin"},
+	}
+}
+
+func TestAutoMigrateGrants(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	statements, err := db.Migrator().(SpannerMigrator).AutoMigrateDryRun(&grantedSinger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, statement := range statements[1:] {
+		got = append(got, statement.SQL)
+	}
+	want := []string{
+		"CREATE ROLE `analyst`",
+		"GRANT SELECT(`id`,`full_name`) ON TABLE `granted_singers` TO ROLE `analyst`",
+		"CREATE ROLE `admin`",
+		"GRANT SELECT ON TABLE `granted_singers` TO ROLE `admin`",
+		"GRANT UPDATE ON TABLE `granted_singers` TO ROLE `admin`",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("statements mismatch\n Got: %v\nWant: %v", got, want)
+	}
+}
+
+func TestHasPrivilege(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	_ = putCountStatementResult(server, "SELECT count(*) FROM information_schema.column_privileges WHERE table_schema = @p1 AND table_name = @p2 AND privilege_type = @p3 AND grantee = @p4 AND column_name IN (@p5,@p6)", 2)
+	_ = putCountStatementResult(server, "SELECT count(*) FROM information_schema.column_privileges WHERE table_schema = @p1 AND table_name = @p2 AND privilege_type = @p3 AND grantee = @p4 AND column_name IN (@p5,@p6,@p7)", 2)
+
+	m := db.Migrator().(SpannerMigrator)
+	if !m.HasPrivilege(&grantedSinger{}, "SELECT", "analyst", "id", "full_name") {
+		t.Fatal("HasPrivilege returned false")
+	}
+	if m.HasPrivilege(&grantedSinger{}, "SELECT", "analyst", "id", "full_name", "secret") {
+		t.Fatal("HasPrivilege returned true for missing column")
+	}
+	if err := m.GrantPrivilege(&grantedSinger{}, "DROP", "analyst"); err == nil {
+		t.Fatal("missing error for unsupported privilege")
+	}
+}
+
+func TestDatabaseRole(t *testing.T) {
+	t.Parallel()
+
+	server, _, serverTeardown := setupMockedTestServer(t)
+	db, server, teardown := setupTestGormConnectionWithDialector(t, server, serverTeardown, New(Config{
+		DriverName:   "spanner",
+		DSN:          fmt.Sprintf("%s/projects/p/instances/i/databases/role-test?useplaintext=true", server.Address),
+		DatabaseRole: "analyst",
+	}))
+	defer teardown()
+
+	var n int64
+	if err := db.Raw("SELECT 1").Scan(&n).Error; err != nil {
+		t.Fatal(err)
+	}
+	adminDB, release, err := WithDatabaseRole(db, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = release() }()
+	if err := adminDB.Raw("SELECT 1").Scan(&n).Error; err != nil {
+		t.Fatal(err)
+	}
+	reqs := drainRequestsFromServer(server.TestSpanner)
+	roles := make(map[string]bool)
+	for _, req := range requestsOfType(reqs, reflect.TypeOf(&spannerpb.CreateSessionRequest{})) {
+		roles[req.(*spannerpb.CreateSessionRequest).Session.CreatorRole] = true
+	}
+	for _, role := range []string{"analyst", "admin"} {
+		if !roles[role] {
+			t.Fatalf("missing session for role %s, got sessions for %v", role, roles)
+		}
+	}
+}
+
+func TestWithDatabaseRoleRequiresDSN(t *testing.T) {
+	t.Parallel()
+
+	if _, _, err := WithDatabaseRole(&gorm.DB{Config: &gorm.Config{Dialector: New(Config{})}}, "admin"); err != ErrDatabaseRoleRequiresDSN {
+		t.Fatalf("error mismatch\n Got: %v\nWant: %v", err, ErrDatabaseRoleRequiresDSN)
+	}
+}
+
+func TestDatabaseRoleWithConn(t *testing.T) {
+	t.Parallel()
+
+	_, err := gorm.Open(New(Config{
+		DSN:          "projects/p/instances/i/databases/d",
+		Conn:         &sql.DB{},
+		DatabaseRole: "analyst",
+	}), &gorm.Config{})
+	if err != ErrDatabaseRoleRequiresDSN {
+		t.Fatalf("error mismatch\n Got: %v\nWant: %v", err, ErrDatabaseRoleRequiresDSN)
+	}
+}
+
+func TestDSNWithDatabaseRole(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		dsn, want string
+	}{
+		{"projects/p/instances/i/databases/d", "projects/p/instances/i/databases/d;databaseRole=admin"},
+		{"projects/p/instances/i/databases/d;usePlainText=true", "projects/p/instances/i/databases/d;usePlainText=true;databaseRole=admin"},
+		{"localhost:9010/projects/p/instances/i/databases/d?useplaintext=true", "localhost:9010/projects/p/instances/i/databases/d?useplaintext=true;databaseRole=admin"},
+		{"projects/p/instances/i/databases/d;databaseRole=analyst;numChannels=1", "projects/p/instances/i/databases/d;numChannels=1;databaseRole=admin"},
+		{"projects/p/instances/i/databases/d?DatabaseRole=analyst", "projects/p/instances/i/databases/d?databaseRole=admin"},
+	} {
+		if g, w := DSNWithDatabaseRole(test.dsn, "admin"), test.want; g != w {
+			t.Errorf("dsn mismatch\n Got: %v\nWant: %v", g, w)
+		}
+	}
+}
+
+func TestWithDatabaseRoleRelease(t *testing.T) {
+	t.Parallel()
+
+	db := &gorm.DB{Config: &gorm.Config{Dialector: New(Config{
+		DSN: "localhost:9010/projects/p/instances/i/databases/role-release?useplaintext=true",
+	})}}
+	db.Statement = &gorm.Statement{DB: db, Clauses: map[string]clause.Clause{}}
+	analystDB, releaseAnalyst, err := WithDatabaseRole(db, "analyst")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherDB, releaseOther, err := WithDatabaseRole(db, "analyst")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := analystDB.Statement.ConnPool.(*sql.DB)
+	// A canceled context prevents the pool from connecting to the server,
+	// while the pool still returns an error if it has been closed.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	isClosed := func() bool {
+		_, err := pool.Conn(ctx)
+		return err != nil && !errors.Is(err, context.Canceled)
+	}
+	if otherDB.Statement.ConnPool != pool {
+		t.Fatal("sessions for the same role should share the connection pool")
+	}
+	if err := releaseAnalyst(); err != nil {
+		t.Fatal(err)
+	}
+	// Releasing a session twice must not close the pool of the other session.
+	if err := releaseAnalyst(); err != nil {
+		t.Fatal(err)
+	}
+	if isClosed() {
+		t.Fatal("pool was closed while still in use")
+	}
+	if err := releaseOther(); err != nil {
+		t.Fatal(err)
+	}
+	if !isClosed() {
+		t.Fatal("pool was not closed after all sessions were released")
+	}
+	newDB, releaseNew, err := WithDatabaseRole(db, "analyst")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = releaseNew() }()
+	if newDB.Statement.ConnPool == pool {
+		t.Fatal("a released pool should not be reused")
+	}
+}
@@ -43,20 +43,19 @@ func ProtobufColumns(projectId, instanceId, databaseId string) error {
 		return err
 	}
 	// Create a TicketSale object with a protobuf value.
-	// Note that concertspb.TicketOrder (the protobuf struct) implements the
-	// driver.Valuer and sql.Scanner interfaces.
-	// See the sample_model/protos/concertspb folder for the implementation.
+	// The TicketOrder field uses the spannergorm.Proto type, which marshals
+	// and unmarshals the protobuf message.
 	ticketSale := sample_model.TicketSale{
 		Concert:      concert,
 		CustomerName: "Christin Chukwuma",
-		TicketOrder: concertspb.CreateTicketOrder(
+		TicketOrder: spannergorm.NewProto(concertspb.CreateTicketOrder(
 			"2349587234",
 			time.Now().UnixMilli(),
 			concertspb.CreateAddress("Main Street 1", "Suns-ville", "NB", "US"),
 			[]*concertspb.Item{
 				concertspb.CreateItem("Concert ticket", 2),
 				concertspb.CreateItem("Consumption voucher", 4),
-			}),
+			})),
 	}
 	if err := db.Create(&ticketSale).Error; err != nil {
 		return err
@@ -68,7 +67,7 @@ func ProtobufColumns(projectId, instanceId, databaseId string) error {
 	if err := db.First(&ts).Error; err != nil {
 		return err
 	}
-	fmt.Printf("Found ticket sale %v with protobuf value %v\n", ticketSale.ID, ticketSale.TicketOrder)
+	fmt.Printf("Found ticket sale %v with protobuf value %v\n", ts.ID, ts.TicketOrder.Message)
 
 	return nil
 }
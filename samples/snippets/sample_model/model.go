@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"cloud.google.com/go/spanner"
+	spannergorm "github.com/googleapis/go-gorm-spanner"
 	"github.com/googleapis/go-gorm-spanner/samples/snippets/sample_model/protos/concertspb"
 	"gorm.io/gorm"
 )
@@ -81,5 +82,5 @@ type TicketSale struct {
 	// Seats        []spanner.NullString
 	Concert     Concert
 	ConcertId   int64
-	TicketOrder *concertspb.TicketOrder
+	TicketOrder spannergorm.Proto[*concertspb.TicketOrder]
 }
@@ -1,12 +1,5 @@
 package concertspb
 
-import (
-	"database/sql/driver"
-	"fmt"
-
-	"github.com/golang/protobuf/proto"
-)
-
 func CreateTicketOrder(orderNumber string, date int64, shippingAddress *Address, items []*Item) *TicketOrder {
 	return &TicketOrder{
 		OrderNumber:     &orderNumber,
@@ -31,15 +24,3 @@ func CreateItem(productName string, quantity int32) *Item {
 		Quantity:    &quantity,
 	}
 }
-
-func (x *TicketOrder) Value() (driver.Value, error) {
-	return proto.Marshal(x)
-}
-
-func (x *TicketOrder) Scan(dest interface{}) error {
-	b, ok := dest.([]byte)
-	if !ok {
-		return fmt.Errorf("invalid type for protobuf value: %v", dest)
-	}
-	return proto.Unmarshal(b, x)
-}
@@ -17,17 +17,15 @@ package snippets
 import (
 	"fmt"
 
-	"github.com/google/uuid"
 	spannergorm "github.com/googleapis/go-gorm-spanner"
 	"gorm.io/gorm"
 )
 
 type user struct {
 	// gorm automatically assumes that the field with the name ID is the primary
-	// key of the table. It also assumes that it is not auto-generated if the type
-	// is a string. We limit the length of the column to 36 characters, so it fits
-	// a well-formatted UUID string.
-	ID   string `gorm:"size:36"`
+	// key of the table. spannergorm.UUID is mapped to the native UUID data type
+	// of Spanner.
+	ID   spannergorm.UUID
 	Name string
 }
 
@@ -54,10 +52,10 @@ func UuidPrimaryKey(projectId, instanceId, databaseId string) error {
 	}
 	// Insert some user records in the database.
 	if err := db.CreateInBatches([]*user{
-		{ID: uuid.NewString(), Name: "User 1"},
-		{ID: uuid.NewString(), Name: "User 2"},
-		{ID: uuid.NewString(), Name: "User 3"},
-		{ID: uuid.NewString(), Name: "User 4"},
+		{ID: spannergorm.NewUUID(), Name: "User 1"},
+		{ID: spannergorm.NewUUID(), Name: "User 2"},
+		{ID: spannergorm.NewUUID(), Name: "User 3"},
+		{ID: spannergorm.NewUUID(), Name: "User 4"},
 	}, 100).Error; err != nil {
 		return err
 	}
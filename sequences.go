@@ -0,0 +1,159 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	gormSpannerSequenceSkipRangeMinTag     = "gorm_sequence_skip_range_min"
+	gormSpannerSequenceSkipRangeMaxTag     = "gorm_sequence_skip_range_max"
+	gormSpannerSequenceStartWithCounterTag = "gorm_sequence_start_with_counter"
+)
+
+// Sequence contains the name and the options of a sequence.
+//
+// The options of a sequence that is used for an auto-increment primary key
+// can be set with tags on the primary key field:
+//
+//	type Singer struct {
+//	  ID int64 `gorm:"primarykey" gorm_sequence_name:"singer_sequence" gorm_sequence_skip_range_min:"1" gorm_sequence_skip_range_max:"1000" gorm_sequence_start_with_counter:"5000"`
+//	}
+type Sequence struct {
+	// Name is the name of the sequence, including the schema if the sequence
+	// is in a named schema.
+	Name string
+	// Kind is the kind of the sequence. The default is bit_reversed_positive.
+	Kind string
+	// SkipRangeMin and SkipRangeMax define a range of values that the
+	// sequence will not return. Both must be set or both must be nil.
+	SkipRangeMin *int64
+	SkipRangeMax *int64
+	// StartWithCounter is the first value of the internal counter of the
+	// sequence. The internal counter is bit-reversed to produce the values
+	// that are returned by the sequence.
+	StartWithCounter *int64
+}
+
+func (s Sequence) kind() string {
+	if s.Kind == "" {
+		return "bit_reversed_positive"
+	}
+	return strings.ToLower(s.Kind)
+}
+
+// EqualOptions returns true if the given sequence has the same options as
+// this sequence. The start_with_counter option is only compared if it is set
+// for this sequence.
+func (s Sequence) EqualOptions(other Sequence) bool {
+	return s.kind() == other.kind() &&
+		equalInt64Ptr(s.SkipRangeMin, other.SkipRangeMin) &&
+		equalInt64Ptr(s.SkipRangeMax, other.SkipRangeMax) &&
+		(s.StartWithCounter == nil || equalInt64Ptr(s.StartWithCounter, other.StartWithCounter))
+}
+
+func equalInt64Ptr(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// options returns the OPTIONS clause of a CREATE or ALTER SEQUENCE
+// statement. An ALTER SEQUENCE statement resets the skip range if it is not
+// set. The start_with_counter option is only included if it is set, as
+// setting it restarts the sequence.
+func (s Sequence) options(alter bool) string {
+	options := []string{fmt.Sprintf("sequence_kind = %q", s.kind())}
+	if alter || s.SkipRangeMin != nil || s.SkipRangeMax != nil {
+		options = append(options, "skip_range_min = "+formatInt64Ptr(s.SkipRangeMin))
+		options = append(options, "skip_range_max = "+formatInt64Ptr(s.SkipRangeMax))
+	}
+	if s.StartWithCounter != nil {
+		options = append(options, "start_with_counter = "+formatInt64Ptr(s.StartWithCounter))
+	}
+	return "OPTIONS (" + strings.Join(options, ", ") + ")"
+}
+
+func formatInt64Ptr(v *int64) string {
+	if v == nil {
+		return "NULL"
+	}
+	return strconv.FormatInt(*v, 10)
+}
+
+// sequenceOf returns the sequence that is declared by the tags of the given
+// field. The name of the sequence is the given name if the field does not
+// have a gorm_sequence_name tag.
+func sequenceOf(field *schema.Field, name string) (Sequence, error) {
+	sequence := Sequence{Name: name}
+	if tag := field.Tag.Get(gormSpannerSequenceTag); tag != "" {
+		sequence.Name = tag
+	}
+	for tag, dest := range map[string]**int64{
+		gormSpannerSequenceSkipRangeMinTag:     &sequence.SkipRangeMin,
+		gormSpannerSequenceSkipRangeMaxTag:     &sequence.SkipRangeMax,
+		gormSpannerSequenceStartWithCounterTag: &sequence.StartWithCounter,
+	} {
+		if value := field.Tag.Get(tag); value != "" {
+			v, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return Sequence{}, fmt.Errorf("invalid value for %s on field %s: %w", tag, field.Name, err)
+			}
+			*dest = &v
+		}
+	}
+	return sequence, nil
+}
+
+// NamedSequence returns the sequence that is declared with a
+// gorm_sequence_name tag on the given auto-increment field, including the
+// options that are set with the other gorm_sequence_* tags. The second
+// return value is false if the field does not use a named sequence.
+func NamedSequence(field *schema.Field) (Sequence, bool, error) {
+	if !field.AutoIncrement || field.Tag.Get(gormSpannerSequenceTag) == "" {
+		return Sequence{}, false, nil
+	}
+	sequence, err := sequenceOf(field, "")
+	return sequence, true, err
+}
+
+// NextSequenceValues returns the next n values of the given sequence in a
+// single round-trip. Use this to pre-allocate primary key values on the
+// client. The values are fetched in the current transaction if db is in a
+// transaction, and otherwise in a new read/write transaction.
+func NextSequenceValues(db *gorm.DB, sequence string, n int) ([]int64, error) {
+	values := make([]int64, 0, n)
+	if n <= 0 {
+		return values, nil
+	}
+	query := func(tx *gorm.DB) error {
+		return tx.Raw(
+			"SELECT GET_NEXT_SEQUENCE_VALUE(SEQUENCE ?) FROM UNNEST(GENERATE_ARRAY(1, ?))",
+			clause.Table{Name: sequence}, n,
+		).Scan(&values).Error
+	}
+	if _, ok := db.Statement.ConnPool.(gorm.TxCommitter); ok {
+		return values, query(db)
+	}
+	return values, db.Transaction(query)
+}
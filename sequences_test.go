@@ -0,0 +1,186 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/googleapis/go-sql-spanner/testutil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type ticket struct {
+	ID   int64 `gorm:"primarykey" gorm_sequence_name:"ticket_seq" gorm_sequence_skip_range_min:"1" gorm_sequence_skip_range_max:"1000" gorm_sequence_start_with_counter:"5000"`
+	Name string
+}
+
+const getSequencesSql = "SELECT s.`schema`, s.name, o.option_name, o.option_value " +
+	"FROM information_schema.sequences s " +
+	"LEFT JOIN information_schema.sequence_options o ON o.`schema` = s.`schema` AND o.name = s.name "
+
+func TestCreateSequenceWithOptions(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	statements, err := db.Migrator().(SpannerMigrator).AutoMigrateDryRun(&ticket{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := len(statements), 2; g != w {
+		t.Fatalf("statement count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := statements[0].SQL,
+		`CREATE SEQUENCE IF NOT EXISTS ticket_seq OPTIONS (sequence_kind = "bit_reversed_positive", skip_range_min = 1, skip_range_max = 1000, start_with_counter = 5000)`; g != w {
+		t.Fatalf("create sequence statement text mismatch\n Got: %s\nWant: %s", g, w)
+	}
+}
+
+func TestMigrateSequenceOptionDrift(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+	anyProto, err := anypb.New(&emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.TestDatabaseAdmin.SetResps([]proto.Message{
+		&longrunningpb.Operation{
+			Name:   "test-operation",
+			Done:   true,
+			Result: &longrunningpb.Operation_Response{Response: anyProto},
+		},
+	})
+	// The existing sequence has a different skip range.
+	putSequencesResult(server, getSequencesSql+"WHERE s.`schema` = @p1 AND s.name = @p2 ORDER BY s.`schema`, s.name", [][]string{
+		{"", "ticket_seq", "sequence_kind", "bit_reversed_positive"},
+		{"", "ticket_seq", "skip_range_min", "1"},
+		{"", "ticket_seq", "skip_range_max", "100"},
+		{"", "ticket_seq", "start_with_counter", "5000"},
+	})
+
+	m := db.Migrator().(spannerMigrator)
+	if err := m.migrateSequences(&ticket{}); err != nil {
+		t.Fatal(err)
+	}
+	requests := server.TestDatabaseAdmin.Reqs()
+	if g, w := len(requests), 1; g != w {
+		t.Fatalf("request count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := requests[0].(*databasepb.UpdateDatabaseDdlRequest).GetStatements()[0],
+		`ALTER SEQUENCE ticket_seq SET OPTIONS (sequence_kind = "bit_reversed_positive", skip_range_min = 1, skip_range_max = 1000, start_with_counter = 5000)`; g != w {
+		t.Fatalf("alter sequence statement text mismatch\n Got: %s\nWant: %s", g, w)
+	}
+}
+
+func TestGetSequences(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+	putSequencesResult(server, getSequencesSql+"ORDER BY s.`schema`, s.name", [][]string{
+		{"", "singers_seq", "sequence_kind", "bit_reversed_positive"},
+		{"sales", "orders_seq", "sequence_kind", "bit_reversed_positive"},
+		{"sales", "orders_seq", "start_with_counter", "100"},
+	})
+
+	sequences, err := db.Migrator().(SpannerMigrator).GetSequences()
+	if err != nil {
+		t.Fatal(err)
+	}
+	startWithCounter := int64(100)
+	if g, w := sequences, []Sequence{
+		{Name: "singers_seq", Kind: "bit_reversed_positive"},
+		{Name: "sales.orders_seq", Kind: "bit_reversed_positive", StartWithCounter: &startWithCounter},
+	}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("sequences mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestNextSequenceValues(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+	sql := "SELECT GET_NEXT_SEQUENCE_VALUE(SEQUENCE `ticket_seq`) FROM UNNEST(GENERATE_ARRAY(1, @p1))"
+	_ = server.TestSpanner.PutStatementResult(sql, &testutil.StatementResult{
+		Type: testutil.StatementResultResultSet,
+		ResultSet: &spannerpb.ResultSet{
+			Metadata: &spannerpb.ResultSetMetadata{
+				RowType: &spannerpb.StructType{
+					Fields: []*spannerpb.StructType_Field{
+						{Type: &spannerpb.Type{Code: spannerpb.TypeCode_INT64}, Name: ""},
+					},
+				},
+			},
+			Rows: []*structpb.ListValue{
+				{Values: []*structpb.Value{{Kind: &structpb.Value_StringValue{StringValue: "1152921504606846976"}}}},
+				{Values: []*structpb.Value{{Kind: &structpb.Value_StringValue{StringValue: "576460752303423488"}}}},
+				{Values: []*structpb.Value{{Kind: &structpb.Value_StringValue{StringValue: "1729382256910270464"}}}},
+			},
+		},
+	})
+
+	values, err := NextSequenceValues(db, "ticket_seq", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := values, []int64{1152921504606846976, 576460752303423488, 1729382256910270464}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("values mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	req := getLastSqlRequest(server)
+	if g, w := req.Sql, sql; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if req.Transaction.GetBegin().GetReadWrite() == nil {
+		t.Fatal("values were not fetched in a read/write transaction")
+	}
+}
+
+func putSequencesResult(server *testutil.MockedSpannerInMemTestServer, sql string, rows [][]string) {
+	var values []*structpb.ListValue
+	for _, row := range rows {
+		var rowValues []*structpb.Value
+		for _, v := range row {
+			rowValues = append(rowValues, &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: v}})
+		}
+		values = append(values, &structpb.ListValue{Values: rowValues})
+	}
+	_ = server.TestSpanner.PutStatementResult(sql, &testutil.StatementResult{
+		Type: testutil.StatementResultResultSet,
+		ResultSet: &spannerpb.ResultSet{
+			Metadata: &spannerpb.ResultSetMetadata{
+				RowType: &spannerpb.StructType{
+					Fields: []*spannerpb.StructType_Field{
+						{Type: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}, Name: "schema"},
+						{Type: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}, Name: "name"},
+						{Type: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}, Name: "option_name"},
+						{Type: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}, Name: "option_value"},
+					},
+				},
+			},
+			Rows: values,
+		},
+	})
+}
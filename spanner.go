@@ -20,6 +20,7 @@ import (
 	"database/sql/driver"
 	"fmt"
 
+	database "cloud.google.com/go/spanner/admin/database/apiv1"
 	"gorm.io/gorm"
 	"gorm.io/gorm/callbacks"
 	"gorm.io/gorm/clause"
@@ -27,6 +28,7 @@ import (
 	"gorm.io/gorm/migrator"
 	"gorm.io/gorm/schema"
 
+	"github.com/googleapis/go-gorm-spanner/internal/gormutil"
 	_ "github.com/googleapis/go-sql-spanner"
 )
 
@@ -67,6 +69,49 @@ type Config struct {
 	// Set this configuration option to DISABLED to fall back to using sequences
 	// for auto-increment primary keys.
 	DefaultSequenceKind string
+
+	// DefaultSchema is the named schema that is used for all models whose table
+	// name is generated by gorm. Tables are created in and read from the default
+	// schema of the database if no value has been set. Models that implement
+	// the schema.Tabler interface must include the schema in the table name,
+	// for example `sales.orders`.
+	DefaultSchema string
+
+	// DatabaseRole is the database role that is used for all statements on
+	// connections that are opened by this dialector. The privileges of the
+	// IAM principal are used if no role has been set. This option can only
+	// be used in combination with DSN. Use WithDatabaseRole to execute
+	// statements with a different role.
+	DatabaseRole string
+
+	// InListThreshold is the minimum number of values in an IN list for the
+	// list to be sent to Spanner as a single array parameter. This ensures
+	// that the SQL string of the statement does not depend on the number of
+	// values in the list, which allows Spanner to cache the query plan. IN
+	// lists are not rewritten if no value has been set.
+	InListThreshold int
+
+	// DatabaseAdminClient is used to create and alter the proto bundle of the
+	// database. Proto bundles cannot be changed through the database/sql
+	// driver, as the DDL statements must include the proto descriptors of the
+	// types in the bundle. AutoMigrate adds the proto message and enum types
+	// that are used by the models to the proto bundle if this client has been
+	// set.
+	DatabaseAdminClient *database.DatabaseAdminClient
+
+	// UUIDAsString stores UUID columns as STRING(36) instead of using the
+	// native UUID data type. Use this option for databases that store UUIDs
+	// as strings.
+	UUIDAsString bool
+
+	// QueryLog replaces the logger of the gorm database with a QueryLogger
+	// with this configuration, if it has been set.
+	QueryLog *QueryLogConfig
+
+	// RequestOrigin annotates all statements that are generated by gorm with
+	// the service, route, caller and model of the statement, if it has been
+	// set. Use WithRequestOrigin to override the origin for a context.
+	RequestOrigin *RequestOriginConfig
 }
 
 type Dialector struct {
@@ -93,6 +138,9 @@ func (dialector Dialector) Initialize(db *gorm.DB) (err error) {
 	if dialector.Connector != nil && dialector.DSN != "" {
 		return fmt.Errorf("only set one of Connector and DSN in the configuration")
 	}
+	if dialector.DatabaseRole != "" && (dialector.DSN == "" || dialector.Conn != nil) {
+		return ErrDatabaseRoleRequiresDSN
+	}
 
 	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{
 		CreateClauses: []string{"INSERT", "VALUES", "RETURNING"},
@@ -100,6 +148,9 @@ func (dialector Dialector) Initialize(db *gorm.DB) (err error) {
 	if dialector.DriverName == "" {
 		dialector.DriverName = "spanner"
 	}
+	if dialector.DefaultSchema != "" {
+		db.NamingStrategy = gormutil.SchemaNamer{Namer: db.NamingStrategy, DefaultSchema: dialector.DefaultSchema}
+	}
 	// Register an UPDATE callback that will ensure that primary key columns are
 	// never included in the SET clause of the statement.
 	updateCallback := db.Callback().Update()
@@ -109,19 +160,42 @@ func (dialector Dialector) Initialize(db *gorm.DB) (err error) {
 		Register("gorm:spanner:remove_primary_key_from_update", BeforeUpdate); err != nil {
 		return err
 	}
+	if err := RegisterRejectWritesToViews(db); err != nil {
+		return err
+	}
+	if err := RegisterInListToArray(db, dialector.InListThreshold); err != nil {
+		return err
+	}
+	if err := registerValidateNumerics(db); err != nil {
+		return err
+	}
+	if err := RegisterQueryCallback(db); err != nil {
+		return err
+	}
+	if dialector.QueryLog != nil {
+		if err := UseQueryLogger(db, *dialector.QueryLog); err != nil {
+			return err
+		}
+	}
+	if dialector.RequestOrigin != nil {
+		if err := UseRequestOrigin(db, *dialector.RequestOrigin); err != nil {
+			return err
+		}
+	}
 
 	if dialector.Conn != nil {
 		db.ConnPool = dialector.Conn
 	} else if dialector.Connector != nil {
 		db.ConnPool = sql.OpenDB(dialector.Connector)
 	} else {
-		db.ConnPool, err = sql.Open(dialector.DriverName, dialector.DSN)
+		db.ConnPool, err = sql.Open(dialector.DriverName, DSNWithDatabaseRole(dialector.DSN, dialector.DatabaseRole))
 		if err != nil {
 			return err
 		}
 	}
 
 	db.ClauseBuilders[clause.Insert{}.Name()] = insertHandler
+	RegisterIntervalReads(db)
 	db.ClauseBuilders[clause.Returning{}.Name()] = func(c clause.Clause, builder clause.Builder) {
 		builder.WriteString("THEN RETURN ")
 		returning, ok := c.Expression.(clause.Returning)
@@ -169,6 +243,10 @@ func insertHandler(c clause.Clause, builder clause.Builder) {
 	} else if onConflict.DoNothing {
 		insert.Modifier = "INSERT OR IGNORE"
 	}
+	if c.BeforeExpression != nil {
+		c.BeforeExpression.Build(builder)
+		builder.WriteByte(' ')
+	}
 	insert.Build(builder)
 }
 
@@ -256,7 +334,7 @@ func (dialector Dialector) QuoteTo(writer clause.Writer, str string) {
 }
 
 func (dialector Dialector) Explain(sql string, vars ...interface{}) string {
-	return logger.ExplainSQL(sql, nil, `'`, vars...)
+	return logger.ExplainSQL(sql, nil, `'`, withoutExecOptions(vars)...)
 }
 
 func (dialector Dialector) DataTypeOf(field *schema.Field) string {
@@ -289,6 +367,12 @@ func (dialector Dialector) DataTypeOf(field *schema.Field) string {
 	case schema.Time:
 		return "TIMESTAMP"
 	}
+	if isNumericField(field) {
+		return "NUMERIC"
+	}
+	if isIntervalField(field) {
+		return "INTERVAL"
+	}
 
 	return string(field.DataType)
 }
@@ -0,0 +1,530 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package spannergormtest contains a test harness for applications that use
+// gorm with Spanner. The harness starts an in-memory mock Spanner server and
+// opens a gorm database for the mock server. Tests register the results of
+// the statements that the application executes, and verify the requests that
+// the application sent to Spanner.
+//
+// Example:
+//
+//	func TestFindSingers(t *testing.T) {
+//		db, server := spannergormtest.Open(t, spannergormtest.Config{})
+//		server.PutQueryResult("SELECT * FROM `singers`", []Singer{{ID: 1, Name: "Alice"}})
+//
+//		var singers []Singer
+//		if err := db.Find(&singers).Error; err != nil {
+//			t.Fatal(err)
+//		}
+//		server.AssertExecuted("SELECT * FROM `singers`", nil)
+//	}
+package spannergormtest
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/google/uuid"
+	spannergorm "github.com/googleapis/go-gorm-spanner"
+	spannerpg "github.com/googleapis/go-gorm-spanner/postgresql"
+	"github.com/googleapis/go-sql-spanner/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+)
+
+// Dialect is the SQL dialect of the mock database.
+type Dialect int
+
+const (
+	// GoogleSQL opens the database with the GoogleSQL dialector. This is the
+	// default.
+	GoogleSQL Dialect = iota
+	// PostgreSQL opens the database with the PostgreSQL dialector.
+	PostgreSQL
+)
+
+// Config contains the configuration of the database that is opened by Open.
+type Config struct {
+	// Dialect is the dialect of the database. The default is GoogleSQL.
+	Dialect Dialect
+
+	// GoogleSQL is the configuration of the GoogleSQL dialector. The
+	// connection fields DriverName, DSN, Connector and Conn are set by Open.
+	GoogleSQL spannergorm.Config
+
+	// PostgreSQL is the configuration of the PostgreSQL dialector.
+	PostgreSQL spannerpg.SpannerConfig
+
+	// Gorm is the gorm configuration of the database. The default is a
+	// configuration that uses prepared statements and a silent logger.
+	Gorm *gorm.Config
+}
+
+// Server is an in-memory mock Spanner server that is used by a gorm
+// database that was opened with Open. All methods report failures to the
+// test that opened the database.
+type Server struct {
+	*testutil.MockedSpannerInMemTestServer
+
+	t       testing.TB
+	db      *gorm.DB
+	dialect Dialect
+
+	mu       sync.Mutex
+	requests []interface{}
+}
+
+// Open starts a mock Spanner server and opens a gorm database for the
+// server. The server is stopped when the test and all its subtests have
+// completed.
+func Open(t testing.TB, config Config) (*gorm.DB, *Server) {
+	t.Helper()
+	mock, _, teardown := testutil.NewMockedSpannerInMemTestServer(t)
+	t.Cleanup(teardown)
+	server := &Server{MockedSpannerInMemTestServer: mock, t: t, dialect: config.Dialect}
+
+	dsn := fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true", mock.Address)
+	var dialector gorm.Dialector
+	switch config.Dialect {
+	case GoogleSQL:
+		c := config.GoogleSQL
+		c.DriverName, c.DSN, c.Connector, c.Conn = "spanner", dsn, nil, nil
+		dialector = spannergorm.New(c)
+	case PostgreSQL:
+		mock.SetupSelectDialectResult(databasepb.DatabaseDialect_POSTGRESQL)
+		dialector = spannerpg.NewWithSpannerConfig(postgres.Config{DSN: dsn}, config.PostgreSQL)
+	default:
+		t.Fatalf("unknown dialect: %v", config.Dialect)
+	}
+	gormConfig := config.Gorm
+	if gormConfig == nil {
+		gormConfig = &gorm.Config{PrepareStmt: true, Logger: logger.Default.LogMode(logger.Silent)}
+	}
+	db, err := gorm.Open(dialector, gormConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	server.db = db
+	return db, server
+}
+
+// PutQueryResult registers the given models as the result of the given
+// query. The models must be a slice of structs or of pointers to structs.
+// The columns of the result are the columns of the gorm schema of the model,
+// and the values of the columns are taken from the models.
+func (s *Server) PutQueryResult(query string, models interface{}) {
+	s.t.Helper()
+	resultSet, err := s.resultSet(models)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	s.putResult(query, &testutil.StatementResult{Type: testutil.StatementResultResultSet, ResultSet: resultSet})
+}
+
+// PutUpdateCount registers the update count of the given DML statement.
+func (s *Server) PutUpdateCount(statement string, count int64) {
+	s.t.Helper()
+	s.putResult(statement, &testutil.StatementResult{Type: testutil.StatementResultUpdateCount, UpdateCount: count})
+}
+
+// PutError registers the error that is returned for the given statement.
+// Use a status error with a Spanner error code, for example
+// status.Error(codes.NotFound, "Table not found").
+func (s *Server) PutError(statement string, err error) {
+	s.t.Helper()
+	s.putResult(statement, &testutil.StatementResult{Type: testutil.StatementResultError, Err: err})
+}
+
+func (s *Server) putResult(statement string, result *testutil.StatementResult) {
+	s.t.Helper()
+	if err := s.TestSpanner.PutStatementResult(statement, result); err != nil {
+		s.t.Fatal(err)
+	}
+}
+
+// FailNext returns the given error for the next call of the given method of
+// the Spanner API, for example testutil.MethodExecuteStreamingSql.
+func (s *Server) FailNext(method string, err error) {
+	s.TestSpanner.PutExecutionTime(method, testutil.SimulatedExecutionTime{Errors: []error{err}})
+}
+
+// AbortNextCommit aborts the next transaction that is committed. gorm
+// transactions that are executed with spannergorm.RunTransaction are
+// retried.
+func (s *Server) AbortNextCommit() {
+	s.FailNext(testutil.MethodCommitTransaction, status.Error(codes.Aborted, "Transaction was aborted"))
+}
+
+// Requests returns all requests that the mock server has received since it
+// was started or since the last call to ClearRequests.
+func (s *Server) Requests() []interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, s.TestSpanner.DrainRequestsFromServer()...)
+	return append([]interface{}(nil), s.requests...)
+}
+
+// ClearRequests removes all requests that the mock server has received.
+func (s *Server) ClearRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.TestSpanner.DrainRequestsFromServer()
+	s.requests = nil
+}
+
+// ExecuteSqlRequests returns all ExecuteSqlRequests that the mock server
+// has received. This includes both queries and DML statements.
+func (s *Server) ExecuteSqlRequests() []*spannerpb.ExecuteSqlRequest {
+	var result []*spannerpb.ExecuteSqlRequest
+	for _, req := range s.Requests() {
+		if r, ok := req.(*spannerpb.ExecuteSqlRequest); ok {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// CommitRequests returns all CommitRequests that the mock server has
+// received.
+func (s *Server) CommitRequests() []*spannerpb.CommitRequest {
+	var result []*spannerpb.CommitRequest
+	for _, req := range s.Requests() {
+		if r, ok := req.(*spannerpb.CommitRequest); ok {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// Mutations returns the mutations in all CommitRequests that the mock server
+// has received.
+func (s *Server) Mutations() []*spannerpb.Mutation {
+	var result []*spannerpb.Mutation
+	for _, req := range s.CommitRequests() {
+		result = append(result, req.Mutations...)
+	}
+	return result
+}
+
+// AssertExecuted verifies that the mock server has received an
+// ExecuteSqlRequest for the given statement. The parameters of the request
+// are also verified if params is not nil. The keys of params are the
+// parameter names in the request, for example p1, and the values are Go
+// values, for example int64(1).
+func (s *Server) AssertExecuted(statement string, params map[string]interface{}) {
+	s.t.Helper()
+	var found []*spannerpb.ExecuteSqlRequest
+	for _, req := range s.ExecuteSqlRequests() {
+		if req.Sql == statement {
+			found = append(found, req)
+		}
+	}
+	if len(found) == 0 {
+		s.t.Fatalf("statement was not executed: %s", statement)
+	}
+	if params == nil {
+		return
+	}
+	want := make(map[string]*structpb.Value, len(params))
+	for name, param := range params {
+		v, err := encodeValue(param)
+		if err != nil {
+			s.t.Fatal(err)
+		}
+		want[name] = v
+	}
+	for _, req := range found {
+		if proto.Equal(req.Params, &structpb.Struct{Fields: want}) || (len(want) == 0 && len(req.Params.GetFields()) == 0) {
+			return
+		}
+	}
+	s.t.Fatalf("params mismatch for statement %s\n Got: %v\nWant: %v", statement, found[len(found)-1].Params.GetFields(), want)
+}
+
+// resultSet returns a ResultSet with the given models.
+func (s *Server) resultSet(models interface{}) (*spannerpb.ResultSet, error) {
+	value := reflect.Indirect(reflect.ValueOf(models))
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, fmt.Errorf("models must be a slice, got %T", models)
+	}
+	modelSchema, err := schema.Parse(models, &sync.Map{}, s.db.NamingStrategy)
+	if err != nil {
+		return nil, err
+	}
+	var fields []*schema.Field
+	for _, field := range modelSchema.Fields {
+		if field.DBName != "" && field.Readable {
+			fields = append(fields, field)
+		}
+	}
+	metadata := &spannerpb.ResultSetMetadata{RowType: &spannerpb.StructType{Fields: make([]*spannerpb.StructType_Field, len(fields))}}
+	for i, field := range fields {
+		metadata.RowType.Fields[i] = &spannerpb.StructType_Field{Name: field.DBName, Type: s.columnType(field)}
+	}
+	rows := make([]*structpb.ListValue, value.Len())
+	for i := range rows {
+		model := reflect.Indirect(value.Index(i))
+		row := &structpb.ListValue{Values: make([]*structpb.Value, len(fields))}
+		for j, field := range fields {
+			fieldValue, _ := field.ValueOf(context.Background(), model)
+			if row.Values[j], err = encodeValue(fieldValue); err != nil {
+				return nil, fmt.Errorf("column %s: %w", field.DBName, err)
+			}
+			// Spanner returns NUMERIC values as strings.
+			if number, ok := row.Values[j].GetKind().(*structpb.Value_NumberValue); ok && metadata.RowType.Fields[j].Type.GetCode() == spannerpb.TypeCode_NUMERIC {
+				row.Values[j] = structpb.NewStringValue(strconv.FormatFloat(number.NumberValue, 'f', -1, 64))
+			}
+		}
+		rows[i] = row
+	}
+	return &spannerpb.ResultSet{Metadata: metadata, Rows: rows}, nil
+}
+
+// columnType returns the Spanner type of the column of the given field. The
+// type is derived from the data type that AutoMigrate would use for the
+// column.
+func (s *Server) columnType(field *schema.Field) *spannerpb.Type {
+	dataType := ""
+	if typer, ok := reflect.New(field.IndirectFieldType).Interface().(migrator.GormDataTypeInterface); ok {
+		dataType = typer.GormDBDataType(s.db, field)
+	}
+	if dataType == "" {
+		dataType = s.db.Dialector.DataTypeOf(field)
+	}
+	if t := parseDataType(dataType, s.dialect == PostgreSQL); t != nil {
+		return t
+	}
+	return &spannerpb.Type{Code: typeCode(field)}
+}
+
+// dataTypeCodes contains the type codes of the GoogleSQL and PostgreSQL data
+// types without length.
+var dataTypeCodes = map[string]spannerpb.TypeCode{
+	"bool": spannerpb.TypeCode_BOOL, "boolean": spannerpb.TypeCode_BOOL,
+	"int64": spannerpb.TypeCode_INT64, "bigint": spannerpb.TypeCode_INT64, "int": spannerpb.TypeCode_INT64,
+	"int8": spannerpb.TypeCode_INT64, "integer": spannerpb.TypeCode_INT64, "serial": spannerpb.TypeCode_INT64,
+	"bigserial": spannerpb.TypeCode_INT64,
+	"float32":   spannerpb.TypeCode_FLOAT32, "float4": spannerpb.TypeCode_FLOAT32, "real": spannerpb.TypeCode_FLOAT32,
+	"float64": spannerpb.TypeCode_FLOAT64, "float8": spannerpb.TypeCode_FLOAT64, "double precision": spannerpb.TypeCode_FLOAT64,
+	"string": spannerpb.TypeCode_STRING, "text": spannerpb.TypeCode_STRING, "varchar": spannerpb.TypeCode_STRING,
+	"character varying": spannerpb.TypeCode_STRING,
+	"bytes":             spannerpb.TypeCode_BYTES, "bytea": spannerpb.TypeCode_BYTES,
+	"timestamp": spannerpb.TypeCode_TIMESTAMP, "timestamptz": spannerpb.TypeCode_TIMESTAMP,
+	"timestamp with time zone": spannerpb.TypeCode_TIMESTAMP,
+	"date":                     spannerpb.TypeCode_DATE,
+	"numeric":                  spannerpb.TypeCode_NUMERIC, "decimal": spannerpb.TypeCode_NUMERIC,
+	"json": spannerpb.TypeCode_JSON, "jsonb": spannerpb.TypeCode_JSON,
+	"uuid": spannerpb.TypeCode_UUID,
+	// The dialects select INTERVAL columns as strings, as the Spanner
+	// database/sql driver does not decode INTERVAL values.
+	"interval": spannerpb.TypeCode_STRING,
+}
+
+// parseDataType returns the Spanner type of the given GoogleSQL or
+// PostgreSQL data type, or nil if the data type is unknown.
+func parseDataType(dataType string, postgreSQL bool) *spannerpb.Type {
+	dataType = strings.TrimSpace(dataType)
+	lower := strings.ToLower(dataType)
+	switch {
+	case strings.HasPrefix(lower, "array<") && strings.HasSuffix(lower, ">"):
+		return arrayOf(parseDataType(dataType[len("array<"):len(dataType)-1], postgreSQL))
+	case strings.HasSuffix(lower, "[]"):
+		return arrayOf(parseDataType(dataType[:len(dataType)-2], postgreSQL))
+	}
+	if i := strings.Index(lower, "("); i > -1 {
+		lower = strings.TrimSpace(lower[:i])
+	}
+	if code, ok := dataTypeCodes[lower]; ok {
+		t := &spannerpb.Type{Code: code}
+		switch {
+		case postgreSQL && code == spannerpb.TypeCode_NUMERIC:
+			t.TypeAnnotation = spannerpb.TypeAnnotationCode_PG_NUMERIC
+		case postgreSQL && code == spannerpb.TypeCode_JSON:
+			t.TypeAnnotation = spannerpb.TypeAnnotationCode_PG_JSONB
+		}
+		return t
+	}
+	// Proto columns use the fully qualified name of the proto message or
+	// enum as the data type.
+	name := protoreflect.FullName(strings.Trim(dataType, "`"))
+	if _, err := protoregistry.GlobalTypes.FindMessageByName(name); err == nil {
+		return &spannerpb.Type{Code: spannerpb.TypeCode_PROTO, ProtoTypeFqn: string(name)}
+	}
+	if _, err := protoregistry.GlobalTypes.FindEnumByName(name); err == nil {
+		return &spannerpb.Type{Code: spannerpb.TypeCode_ENUM, ProtoTypeFqn: string(name)}
+	}
+	return nil
+}
+
+func arrayOf(element *spannerpb.Type) *spannerpb.Type {
+	if element == nil {
+		return nil
+	}
+	return &spannerpb.Type{Code: spannerpb.TypeCode_ARRAY, ArrayElementType: element}
+}
+
+// typeCode returns the Spanner type of the column of the given field based
+// on the gorm data type of the field. This is used for fields with a data
+// type that is not known by parseDataType.
+func typeCode(field *schema.Field) spannerpb.TypeCode {
+	switch field.DataType {
+	case schema.Bool:
+		return spannerpb.TypeCode_BOOL
+	case schema.Int, schema.Uint:
+		return spannerpb.TypeCode_INT64
+	case schema.Float:
+		if field.Size == 32 {
+			return spannerpb.TypeCode_FLOAT32
+		}
+		return spannerpb.TypeCode_FLOAT64
+	case schema.Time:
+		return spannerpb.TypeCode_TIMESTAMP
+	case schema.Bytes:
+		return spannerpb.TypeCode_BYTES
+	}
+	return spannerpb.TypeCode_STRING
+}
+
+// encodeValue returns the Spanner representation of the given Go value.
+func encodeValue(v interface{}) (*structpb.Value, error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || ((rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map) && rv.IsNil()) {
+		return structpb.NewNullValue(), nil
+	}
+	switch value := v.(type) {
+	case spanner.GenericColumnValue:
+		return value.Value, nil
+	case spanner.NullJSON:
+		return encodeJSON(value.Valid, value.Value)
+	case spanner.PGJsonB:
+		return encodeJSON(value.Valid, value.Value)
+	case spanner.NullNumeric:
+		if !value.Valid {
+			return structpb.NewNullValue(), nil
+		}
+		return structpb.NewStringValue(spanner.NumericString(&value.Numeric)), nil
+	case spanner.NullableValue:
+		if value.IsNull() {
+			return structpb.NewNullValue(), nil
+		}
+		// The Spanner null types contain the value in the first field.
+		if rv.Kind() == reflect.Struct {
+			return encodeValue(rv.Field(0).Interface())
+		}
+	case time.Time:
+		return structpb.NewStringValue(value.UTC().Format(time.RFC3339Nano)), nil
+	case civil.Date:
+		return structpb.NewStringValue(value.String()), nil
+	case big.Rat:
+		return structpb.NewStringValue(spanner.NumericString(&value)), nil
+	case *big.Rat:
+		return structpb.NewStringValue(spanner.NumericString(value)), nil
+	case uuid.UUID:
+		return structpb.NewStringValue(value.String()), nil
+	case []byte:
+		return structpb.NewStringValue(base64.StdEncoding.EncodeToString(value)), nil
+	case driver.Valuer:
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return structpb.NewNullValue(), nil
+		}
+		converted, err := value.Value()
+		if err != nil {
+			return nil, err
+		}
+		if converted != nil && reflect.TypeOf(converted) == rv.Type() {
+			return nil, fmt.Errorf("unsupported value type: %T", v)
+		}
+		return encodeValue(converted)
+	}
+	if rv.Kind() == reflect.Pointer {
+		return encodeValue(rv.Elem().Interface())
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		return structpb.NewBoolValue(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return structpb.NewStringValue(strconv.FormatInt(rv.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return structpb.NewStringValue(strconv.FormatUint(rv.Uint(), 10)), nil
+	case reflect.Float32, reflect.Float64:
+		// Spanner returns NaN and infinity as strings.
+		switch f := rv.Float(); {
+		case math.IsNaN(f):
+			return structpb.NewStringValue("NaN"), nil
+		case math.IsInf(f, 1):
+			return structpb.NewStringValue("Infinity"), nil
+		case math.IsInf(f, -1):
+			return structpb.NewStringValue("-Infinity"), nil
+		default:
+			return structpb.NewNumberValue(f), nil
+		}
+	case reflect.String:
+		return structpb.NewStringValue(rv.String()), nil
+	case reflect.Slice, reflect.Array:
+		list := &structpb.ListValue{Values: make([]*structpb.Value, rv.Len())}
+		for i := range list.Values {
+			element, err := encodeValue(rv.Index(i).Interface())
+			if err != nil {
+				return nil, fmt.Errorf("index %d: %w", i, err)
+			}
+			list.Values[i] = element
+		}
+		return structpb.NewListValue(list), nil
+	case reflect.Map, reflect.Struct:
+		return encodeJSON(true, v)
+	}
+	return nil, fmt.Errorf("unsupported value type: %T", v)
+}
+
+// encodeJSON returns the given value as a JSON string.
+func encodeJSON(valid bool, v interface{}) (*structpb.Value, error) {
+	if !valid {
+		return structpb.NewNullValue(), nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return structpb.NewStringValue(string(b)), nil
+}
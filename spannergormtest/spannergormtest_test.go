@@ -0,0 +1,250 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spannergormtest_test
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/google/uuid"
+	spannergorm "github.com/googleapis/go-gorm-spanner"
+	"github.com/googleapis/go-gorm-spanner/spannergormtest"
+	spannerdriver "github.com/googleapis/go-sql-spanner"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type Singer struct {
+	ID        int64
+	Name      string
+	Active    bool
+	Rating    float64
+	BirthDate *time.Time
+	Picture   []byte
+	Nickname  sql.NullString
+	CreatedAt time.Time
+	DeletedAt gorm.DeletedAt
+}
+
+func TestQueryResult(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		dialect spannergormtest.Dialect
+		query   string
+	}{
+		{spannergormtest.GoogleSQL, "SELECT * FROM `singers` WHERE name = @p1 AND `singers`.`deleted_at` IS NULL"},
+		{spannergormtest.PostgreSQL, `SELECT * FROM "singers" WHERE name = $1 AND "singers"."deleted_at" IS NULL`},
+	} {
+		db, server := spannergormtest.Open(t, spannergormtest.Config{Dialect: test.dialect})
+		birthDate := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+		want := []Singer{
+			{ID: 1, Name: "Alice", Active: true, Rating: 4.5, BirthDate: &birthDate, Picture: []byte("pic"),
+				Nickname: sql.NullString{String: "Al", Valid: true}, CreatedAt: birthDate},
+			{ID: 2, Name: "Alice", CreatedAt: birthDate},
+		}
+		server.PutQueryResult(test.query, want)
+
+		var singers []Singer
+		if err := db.Where("name = ?", "Alice").Find(&singers).Error; err != nil {
+			t.Fatal(err)
+		}
+		for i := range singers {
+			if singers[i].BirthDate != nil {
+				*singers[i].BirthDate = singers[i].BirthDate.UTC()
+			}
+			singers[i].CreatedAt = singers[i].CreatedAt.UTC()
+		}
+		if g, w := singers, want; !reflect.DeepEqual(g, w) {
+			t.Fatalf("%v: singers mismatch\n Got: %v\nWant: %v", test.dialect, g, w)
+		}
+		server.AssertExecuted(test.query, map[string]interface{}{"p1": "Alice"})
+	}
+}
+
+func TestAbortNextCommit(t *testing.T) {
+	t.Parallel()
+
+	db, server := spannergormtest.Open(t, spannergormtest.Config{})
+	const insert = "INSERT INTO `singers` (`id`,`name`) VALUES (@p1,@p2)"
+	server.PutUpdateCount(insert, 1)
+	server.AbortNextCommit()
+
+	type singer struct {
+		ID   int64 `gorm:"primaryKey;autoIncrement:false"`
+		Name string
+	}
+	attempts := 0
+	if err := spannergorm.RunTransaction(context.Background(), db, func(tx *gorm.DB) error {
+		attempts++
+		return tx.Create(&singer{ID: 1, Name: "Alice"}).Error
+	}, &sql.TxOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if g, w := attempts, 2; g != w {
+		t.Fatalf("attempts mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	server.AssertExecuted(insert, map[string]interface{}{"p1": int64(1), "p2": "Alice"})
+	if g, w := len(server.CommitRequests()), 2; g != w {
+		t.Fatalf("commit count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestErrorsAndMutations(t *testing.T) {
+	t.Parallel()
+
+	db, server := spannergormtest.Open(t, spannergormtest.Config{})
+	const query = "SELECT * FROM `singers`"
+	server.PutError(query, status.Error(codes.PermissionDenied, "Permission denied"))
+	var singers []Singer
+	if err := db.Unscoped().Find(&singers).Error; spanner.ErrCode(err) != codes.PermissionDenied {
+		t.Fatalf("error code mismatch\n Got: %v\nWant: %v", spanner.ErrCode(err), codes.PermissionDenied)
+	}
+
+	server.ClearRequests()
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := sqlDB.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+	if err := conn.Raw(func(driverConn any) error {
+		_, err := driverConn.(spannerdriver.SpannerConn).Apply(context.Background(), []*spanner.Mutation{
+			spanner.Insert("singers", []string{"id", "name"}, []interface{}{int64(1), "Alice"}),
+		})
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	mutations := server.Mutations()
+	if g, w := len(mutations), 1; g != w {
+		t.Fatalf("mutation count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := mutations[0].GetInsert().GetTable(), "singers"; g != w {
+		t.Fatalf("table mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+type typeEntity struct {
+	ID       int64 `gorm:"primaryKey;autoIncrement:false"`
+	Tags     spannergorm.StringArray
+	Price    spannergorm.Numeric
+	Details  spannergorm.JSON[map[string]string]
+	Released spanner.NullDate
+	Key      spannergorm.UUID
+	Score    float64 `gorm:"type:float8"`
+	Timeout  time.Duration
+}
+
+func TestQueryResultTypes(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		dialect spannergormtest.Dialect
+		query   string
+		types   []string
+	}{
+		{
+			dialect: spannergormtest.GoogleSQL,
+			query:   "SELECT * FROM `type_entities`",
+			types:   []string{"INT64", "ARRAY<STRING>", "NUMERIC", "JSON", "DATE", "UUID", "FLOAT64", "INT64"},
+		},
+		{
+			dialect: spannergormtest.PostgreSQL,
+			query:   `SELECT * FROM "type_entities"`,
+			types:   []string{"INT64", "ARRAY<STRING>", "NUMERIC<PG_NUMERIC>", "JSON<PG_JSONB>", "DATE", "UUID", "FLOAT64", "INT64"},
+		},
+	} {
+		db, server := spannergormtest.Open(t, spannergormtest.Config{Dialect: test.dialect})
+		want := []typeEntity{
+			{
+				ID:       1,
+				Tags:     spannergorm.StringArray{"a", "b"},
+				Price:    spannergorm.NewNumeric(big.NewRat(314, 100)),
+				Details:  spannergorm.NewJSON(map[string]string{"genre": "rock"}),
+				Released: spanner.NullDate{Date: civil.Date{Year: 2000, Month: 1, Day: 1}, Valid: true},
+				Key:      spannergorm.UUID(uuid.MustParse("ffd2c8f3-23a5-4cd5-8e38-d8dc2a2bbfb3")),
+				Score:    math.Inf(1),
+				Timeout:  time.Second,
+			},
+			{ID: 2, Score: math.Inf(-1)},
+		}
+		server.PutQueryResult(test.query, want)
+
+		sqlDB, err := db.DB()
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows, err := sqlDB.QueryContext(context.Background(), test.query, spannerdriver.ExecOptions{ReturnResultSetMetadata: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var metadata *spannerpb.ResultSetMetadata
+		if !rows.Next() {
+			t.Fatalf("%v: missing metadata", test.dialect)
+		}
+		if err := rows.Scan(&metadata); err != nil {
+			t.Fatal(err)
+		}
+		_ = rows.Close()
+		var types []string
+		for _, field := range metadata.RowType.Fields {
+			types = append(types, typeName(field.Type))
+		}
+		if g, w := types, test.types; !reflect.DeepEqual(g, w) {
+			t.Fatalf("%v: types mismatch\n Got: %v\nWant: %v", test.dialect, g, w)
+		}
+
+		var entities []typeEntity
+		if err := db.Raw(test.query).Scan(&entities).Error; err != nil {
+			t.Fatal(err)
+		}
+		if g, w := len(entities), 2; g != w {
+			t.Fatalf("%v: entity count mismatch\n Got: %v\nWant: %v", test.dialect, g, w)
+		}
+		got := entities[0]
+		if !reflect.DeepEqual(got.Tags, want[0].Tags) || got.Price.Cmp(&want[0].Price.Rat) != 0 ||
+			!reflect.DeepEqual(got.Details.Data, want[0].Details.Data) || got.Released != want[0].Released ||
+			got.Key != want[0].Key || got.Score != want[0].Score || got.Timeout != want[0].Timeout {
+			t.Fatalf("%v: entity mismatch\n Got: %+v\nWant: %+v", test.dialect, got, want[0])
+		}
+		if g, w := entities[1].Score, math.Inf(-1); g != w {
+			t.Fatalf("%v: score mismatch\n Got: %v\nWant: %v", test.dialect, g, w)
+		}
+	}
+}
+
+func typeName(t *spannerpb.Type) string {
+	name := t.Code.String()
+	if t.Code == spannerpb.TypeCode_ARRAY {
+		name += "<" + typeName(t.ArrayElementType) + ">"
+	}
+	if t.TypeAnnotation != spannerpb.TypeAnnotationCode_TYPE_ANNOTATION_CODE_UNSPECIFIED {
+		name += "<" + t.TypeAnnotation.String() + ">"
+	}
+	return name
+}
@@ -0,0 +1,517 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gorm.io/gorm/schema"
+)
+
+// Struct is a GoogleSQL STRUCT value that is mapped to a Go struct of type T.
+// The fields of the STRUCT are mapped to the fields of T using the gorm
+// column naming rules, which means that a `gorm:"column:name"` tag can be
+// used to map a field to a STRUCT field with a different name.
+//
+// Struct can be used as a query parameter, and to scan STRUCT values in
+// query results. The Spanner database/sql driver only returns STRUCT values
+// for queries that use spannerdriver.DecodeOptionProto. Use TO_JSON to
+// select a STRUCT in other queries. Struct scans the JSON object that is
+// returned by TO_JSON.
+//
+// NULL values are scanned as the zero value of T. Use a pointer type for T to
+// distinguish NULL from an empty value.
+//
+// Spanner does not support STRUCT columns. Add the `gorm:"->;-:migration"` tag
+// to a field of this type in a model to only use it in query results.
+type Struct[T any] struct {
+	Data T
+}
+
+// NewStruct returns a Struct value containing the given data.
+func NewStruct[T any](data T) Struct[T] {
+	return Struct[T]{Data: data}
+}
+
+// Value implements driver.Valuer. The value is sent to Spanner as a STRUCT
+// value.
+func (s Struct[T]) Value() (driver.Value, error) {
+	fields, err := structFieldsOf(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	types := make([]*spannerpb.Type, len(fields))
+	value, err := encodeStruct(fields, reflect.ValueOf(&s.Data).Elem(), types)
+	if err != nil {
+		return nil, err
+	}
+	return spanner.GenericColumnValue{Type: structType(fields, types), Value: value}, nil
+}
+
+// Scan implements sql.Scanner. Struct can scan STRUCT values and JSON
+// objects.
+func (s *Struct[T]) Scan(v any) error {
+	dst := reflect.ValueOf(&s.Data).Elem()
+	switch val := v.(type) {
+	case nil:
+		dst.SetZero()
+		return nil
+	case spanner.GenericColumnValue:
+		return decodeStructValue(dst, val)
+	}
+	b, err := jsonBytes(v)
+	if err != nil {
+		return fmt.Errorf("cannot scan %T into %T: %w", v, s, err)
+	}
+	return unmarshalStruct(dst, b)
+}
+
+func (s Struct[T]) GormDataType() string {
+	return "STRUCT"
+}
+
+// MarshalJSON marshals the value as a JSON object with the column names of
+// the fields as keys.
+func (s Struct[T]) MarshalJSON() ([]byte, error) {
+	return marshalStruct(reflect.ValueOf(&s.Data).Elem())
+}
+
+// UnmarshalJSON unmarshals a JSON object with the column names of the fields
+// as keys.
+func (s *Struct[T]) UnmarshalJSON(b []byte) error {
+	return unmarshalStruct(reflect.ValueOf(&s.Data).Elem(), b)
+}
+
+// StructArray is an ARRAY<STRUCT> value with elements that are mapped to Go
+// structs of type T. The fields are mapped in the same way as for Struct.
+// NULL elements are represented by nil if T is a pointer type.
+//
+// StructArray can be used as a query parameter, for example to filter or
+// join with a list of rows:
+//
+//	keys := spannergorm.StructArray[AlbumKey]{{SingerID: 1, AlbumID: 2}, {SingerID: 3, AlbumID: 4}}
+//	db.Joins("JOIN UNNEST(?) AS k ON k.singer_id = albums.singer_id AND k.album_id = albums.album_id", keys).Find(&albums)
+//
+// StructArray scans ARRAY<STRUCT> values that are returned for queries that
+// use spannerdriver.DecodeOptionProto, and JSON arrays, for example the
+// result of TO_JSON(ARRAY(SELECT AS STRUCT ...)):
+//
+//	type AlbumWithTracks struct {
+//	  AlbumID int64
+//	  Tracks  spannergorm.StructArray[Track]
+//	}
+//	db.Raw("SELECT album_id, TO_JSON(ARRAY(SELECT AS STRUCT * FROM tracks WHERE tracks.album_id = albums.album_id)) AS tracks FROM albums").Scan(&albums)
+type StructArray[T any] []T
+
+// Value implements driver.Valuer. The value is sent to Spanner as an
+// ARRAY<STRUCT> value.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (a StructArray[T]) Value() (driver.Value, error) {
+	fields, err := structFieldsOf(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	types := make([]*spannerpb.Type, len(fields))
+	var value *structpb.Value
+	if a == nil {
+		value = structpb.NewNullValue()
+	} else {
+		values := make([]*structpb.Value, len(a))
+		for i := range a {
+			if values[i], err = encodeStruct(fields, reflect.ValueOf(a).Index(i), types); err != nil {
+				return nil, fmt.Errorf("index %d of %T: %w", i, a, err)
+			}
+		}
+		value = structpb.NewListValue(&structpb.ListValue{Values: values})
+	}
+	return spanner.GenericColumnValue{
+		Type:  &spannerpb.Type{Code: spannerpb.TypeCode_ARRAY, ArrayElementType: structType(fields, types)},
+		Value: value,
+	}, nil
+}
+
+// Scan implements sql.Scanner. StructArray can scan ARRAY<STRUCT> values and
+// JSON arrays.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (a *StructArray[T]) Scan(v any) error {
+	dst := reflect.ValueOf(a).Elem()
+	switch val := v.(type) {
+	case nil:
+		dst.SetZero()
+		return nil
+	case spanner.GenericColumnValue:
+		return decodeStructValue(dst, val)
+	}
+	b, err := jsonBytes(v)
+	if err != nil {
+		return fmt.Errorf("cannot scan %T into %T: %w", v, a, err)
+	}
+	return unmarshalStructArray(dst, b)
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (a StructArray[T]) GormDataType() string {
+	return "ARRAY<STRUCT>"
+}
+
+// MarshalJSON marshals the value as a JSON array of objects with the column
+// names of the fields as keys.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (a StructArray[T]) MarshalJSON() ([]byte, error) {
+	if a == nil {
+		return []byte("null"), nil
+	}
+	elements := make([]json.RawMessage, len(a))
+	for i := range a {
+		b, err := marshalStruct(reflect.ValueOf(a).Index(i))
+		if err != nil {
+			return nil, fmt.Errorf("index %d of %T: %w", i, a, err)
+		}
+		elements[i] = b
+	}
+	return json.Marshal(elements)
+}
+
+// UnmarshalJSON unmarshals a JSON array of objects with the column names of
+// the fields as keys.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (a *StructArray[T]) UnmarshalJSON(b []byte) error {
+	return unmarshalStructArray(reflect.ValueOf(a).Elem(), b)
+}
+
+// structSchemas caches the parsed schemas of the Go structs that are used
+// for STRUCT values.
+var structSchemas sync.Map
+
+// structFieldsOf returns the fields of the given struct type that are mapped
+// to STRUCT fields. Pointer types are dereferenced.
+func structFieldsOf(t reflect.Type) ([]*schema.Field, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	s, err := schema.Parse(reflect.New(t).Interface(), &structSchemas, schema.NamingStrategy{})
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]*schema.Field, 0, len(s.Fields))
+	for _, field := range s.Fields {
+		if field.DBName != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+// encodeStruct encodes the given struct as a STRUCT value. The Spanner types
+// of the fields that are not NULL are stored in types, if the type of the
+// field has not yet been set.
+func encodeStruct(fields []*schema.Field, v reflect.Value, types []*spannerpb.Type) (*structpb.Value, error) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return structpb.NewNullValue(), nil
+		}
+		v = v.Elem()
+	}
+	values := make([]*structpb.Value, len(fields))
+	for i, field := range fields {
+		fieldValue, _ := field.ValueOf(context.Background(), v)
+		var value driver.Value
+		if fieldValue != nil {
+			var err error
+			if value, err = arrayElementValue(reflect.ValueOf(fieldValue)); err != nil {
+				return nil, fmt.Errorf("field %s: %w", field.Name, err)
+			}
+		}
+		if value == nil {
+			values[i] = structpb.NewNullValue()
+			continue
+		}
+		row, err := spanner.NewRow([]string{field.DBName}, []interface{}{value})
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		values[i] = row.ColumnValue(0)
+		if types[i] == nil {
+			types[i] = row.ColumnType(0)
+		}
+	}
+	return structpb.NewListValue(&structpb.ListValue{Values: values}), nil
+}
+
+// structType returns the STRUCT type with the given fields. The type of a
+// field that is not in types is derived from the Go type of the field.
+func structType(fields []*schema.Field, types []*spannerpb.Type) *spannerpb.Type {
+	structFields := make([]*spannerpb.StructType_Field, len(fields))
+	for i, field := range fields {
+		fieldType := types[i]
+		if fieldType == nil {
+			element := arrayElementTypeOf(field.FieldType)
+			fieldType = &spannerpb.Type{Code: element.code, ProtoTypeFqn: element.protoName}
+		}
+		structFields[i] = &spannerpb.StructType_Field{Name: field.DBName, Type: fieldType}
+	}
+	return &spannerpb.Type{Code: spannerpb.TypeCode_STRUCT, StructType: &spannerpb.StructType{Fields: structFields}}
+}
+
+// decodeStructValue assigns a STRUCT or ARRAY<STRUCT> value to dst, which
+// must be a settable struct or slice of structs, or a pointer to one.
+func decodeStructValue(dst reflect.Value, v spanner.GenericColumnValue) error {
+	if _, ok := v.Value.GetKind().(*structpb.Value_NullValue); ok || v.Value == nil {
+		dst.SetZero()
+		return nil
+	}
+	if dst.Kind() == reflect.Pointer {
+		p := reflect.New(dst.Type().Elem())
+		if err := decodeStructValue(p.Elem(), v); err != nil {
+			return err
+		}
+		dst.Set(p)
+		return nil
+	}
+	switch v.Type.GetCode() {
+	case spannerpb.TypeCode_ARRAY:
+		if dst.Kind() != reflect.Slice {
+			return fmt.Errorf("cannot assign %v to %s", v.Type.GetCode(), dst.Type())
+		}
+		values := v.Value.GetListValue().GetValues()
+		res := reflect.MakeSlice(dst.Type(), len(values), len(values))
+		for i, value := range values {
+			element := spanner.GenericColumnValue{Type: v.Type.ArrayElementType, Value: value}
+			if err := decodeStructValue(res.Index(i), element); err != nil {
+				return fmt.Errorf("index %d of %s: %w", i, dst.Type(), err)
+			}
+		}
+		dst.Set(res)
+		return nil
+	case spannerpb.TypeCode_STRUCT:
+		fields, err := structFieldsOf(dst.Type())
+		if err != nil {
+			return err
+		}
+		values := v.Value.GetListValue().GetValues()
+		for i, structField := range v.Type.StructType.GetFields() {
+			field := lookupStructField(fields, structField.Name)
+			if field == nil || i >= len(values) {
+				continue
+			}
+			value := spanner.GenericColumnValue{Type: structField.Type, Value: values[i]}
+			if err := decodeStructField(field.ReflectValueOf(context.Background(), dst), value); err != nil {
+				return fmt.Errorf("field %s: %w", field.Name, err)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("cannot assign %v to %s", v.Type.GetCode(), dst.Type())
+}
+
+// decodeStructField assigns the value of a single STRUCT field to dst.
+func decodeStructField(dst reflect.Value, v spanner.GenericColumnValue) error {
+	_, isScanner := dst.Addr().Interface().(sql.Scanner)
+	if isStructType(v.Type) && !isScanner {
+		return decodeStructValue(dst, v)
+	}
+	value, err := decodeGenericValue(v)
+	if err != nil {
+		return err
+	}
+	return scanArrayElement(dst, reflect.ValueOf(value))
+}
+
+func isStructType(t *spannerpb.Type) bool {
+	return t.GetCode() == spannerpb.TypeCode_STRUCT ||
+		t.GetCode() == spannerpb.TypeCode_ARRAY && t.ArrayElementType.GetCode() == spannerpb.TypeCode_STRUCT
+}
+
+// genericValueTypes contains the Go types that the values of STRUCT fields
+// are decoded to. These are the same types that are returned by the Spanner
+// database/sql driver.
+var genericValueTypes = map[spannerpb.TypeCode]reflect.Type{
+	spannerpb.TypeCode_BOOL:      reflect.TypeFor[spanner.NullBool](),
+	spannerpb.TypeCode_INT64:     reflect.TypeFor[spanner.NullInt64](),
+	spannerpb.TypeCode_ENUM:      reflect.TypeFor[spanner.NullInt64](),
+	spannerpb.TypeCode_FLOAT32:   reflect.TypeFor[spanner.NullFloat32](),
+	spannerpb.TypeCode_FLOAT64:   reflect.TypeFor[spanner.NullFloat64](),
+	spannerpb.TypeCode_NUMERIC:   reflect.TypeFor[spanner.NullNumeric](),
+	spannerpb.TypeCode_STRING:    reflect.TypeFor[spanner.NullString](),
+	spannerpb.TypeCode_BYTES:     reflect.TypeFor[[]byte](),
+	spannerpb.TypeCode_PROTO:     reflect.TypeFor[[]byte](),
+	spannerpb.TypeCode_JSON:      reflect.TypeFor[spanner.NullJSON](),
+	spannerpb.TypeCode_DATE:      reflect.TypeFor[spanner.NullDate](),
+	spannerpb.TypeCode_TIMESTAMP: reflect.TypeFor[spanner.NullTime](),
+	spannerpb.TypeCode_UUID:      reflect.TypeFor[spanner.NullUUID](),
+	spannerpb.TypeCode_INTERVAL:  reflect.TypeFor[spanner.NullInterval](),
+}
+
+// decodeGenericValue decodes the given value to the Go type that the Spanner
+// database/sql driver uses for values of the same type. Values of types
+// that are not supported are returned unmodified.
+func decodeGenericValue(v spanner.GenericColumnValue) (any, error) {
+	var t reflect.Type
+	if v.Type.GetCode() == spannerpb.TypeCode_ARRAY {
+		if element, ok := genericValueTypes[v.Type.ArrayElementType.GetCode()]; ok {
+			t = reflect.SliceOf(element)
+		}
+	} else {
+		t = genericValueTypes[v.Type.GetCode()]
+	}
+	if t == nil {
+		return v, nil
+	}
+	p := reflect.New(t)
+	if err := v.Decode(p.Interface()); err != nil {
+		return nil, err
+	}
+	return p.Elem().Interface(), nil
+}
+
+// lookupStructField returns the field with the given column name or field
+// name, or nil if there is no such field.
+func lookupStructField(fields []*schema.Field, name string) *schema.Field {
+	for _, field := range fields {
+		if field.DBName == name {
+			return field
+		}
+	}
+	for _, field := range fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// jsonBytes returns the JSON document in the given value.
+func jsonBytes(v any) ([]byte, error) {
+	switch val := v.(type) {
+	case spanner.NullJSON:
+		if !val.Valid {
+			return []byte("null"), nil
+		}
+		return json.Marshal(val.Value)
+	case string:
+		return []byte(val), nil
+	case []byte:
+		return val, nil
+	}
+	return nil, fmt.Errorf("unsupported type %T", v)
+}
+
+// marshalStruct marshals the given struct as a JSON object with the column
+// names of the fields as keys.
+func marshalStruct(v reflect.Value) ([]byte, error) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return []byte("null"), nil
+		}
+		v = v.Elem()
+	}
+	fields, err := structFieldsOf(v.Type())
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, field := range fields {
+		fieldValue, _ := field.ValueOf(context.Background(), v)
+		key, err := json.Marshal(field.DBName)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(fieldValue)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// unmarshalStruct unmarshals a JSON object with the column names of the
+// fields as keys into dst, which must be a settable struct or a pointer to a
+// struct. Keys that do not match a field are ignored.
+func unmarshalStruct(dst reflect.Value, b []byte) error {
+	if bytes.Equal(bytes.TrimSpace(b), []byte("null")) {
+		dst.SetZero()
+		return nil
+	}
+	if dst.Kind() == reflect.Pointer {
+		p := reflect.New(dst.Type().Elem())
+		if err := unmarshalStruct(p.Elem(), b); err != nil {
+			return err
+		}
+		dst.Set(p)
+		return nil
+	}
+	fields, err := structFieldsOf(dst.Type())
+	if err != nil {
+		return err
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(b, &object); err != nil {
+		return err
+	}
+	for name, value := range object {
+		field := lookupStructField(fields, name)
+		if field == nil {
+			continue
+		}
+		fieldValue := field.ReflectValueOf(context.Background(), dst)
+		if err := json.Unmarshal(value, fieldValue.Addr().Interface()); err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+// unmarshalStructArray unmarshals a JSON array of objects into dst, which
+// must be a settable slice.
+func unmarshalStructArray(dst reflect.Value, b []byte) error {
+	var elements []json.RawMessage
+	if err := json.Unmarshal(b, &elements); err != nil {
+		return err
+	}
+	if elements == nil {
+		dst.SetZero()
+		return nil
+	}
+	res := reflect.MakeSlice(dst.Type(), len(elements), len(elements))
+	for i, element := range elements {
+		if err := unmarshalStruct(res.Index(i), element); err != nil {
+			return fmt.Errorf("index %d of %s: %w", i, dst.Type(), err)
+		}
+	}
+	dst.Set(res)
+	return nil
+}
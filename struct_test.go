@@ -0,0 +1,219 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/googleapis/go-sql-spanner/testutil"
+)
+
+type structAlbumKey struct {
+	SingerID int64
+	AlbumID  int64
+}
+
+type structTrack struct {
+	TrackNumber int64
+	Title       string `gorm:"column:track_title"`
+	Duration    *float64
+}
+
+type structAlbum struct {
+	AlbumID int64
+	Title   string
+	Tracks  StructArray[structTrack] `gorm:"->;-:migration"`
+}
+
+func TestStructArrayParam(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	const query = "SELECT `struct_albums`.`album_id`,`struct_albums`.`title`,`struct_albums`.`tracks` FROM `struct_albums` " +
+		"JOIN UNNEST(@p1) AS k ON k.album_id = struct_albums.album_id"
+	_ = server.TestSpanner.PutStatementResult(query, &testutil.StatementResult{
+		Type:      testutil.StatementResultResultSet,
+		ResultSet: &spannerpb.ResultSet{Metadata: &spannerpb.ResultSetMetadata{RowType: &spannerpb.StructType{}}},
+	})
+	keys := StructArray[structAlbumKey]{{SingerID: 1, AlbumID: 2}, {SingerID: 3, AlbumID: 4}}
+	var albums []structAlbum
+	if err := db.Joins("JOIN UNNEST(?) AS k ON k.album_id = struct_albums.album_id", keys).Find(&albums).Error; err != nil {
+		t.Fatal(err)
+	}
+	req := getLastSqlRequest(server)
+	if g, w := req.Sql, query; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	paramType := req.ParamTypes["p1"]
+	if g, w := paramType.GetCode(), spannerpb.TypeCode_ARRAY; g != w {
+		t.Fatalf("param type mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	var fields []string
+	for _, field := range paramType.GetArrayElementType().GetStructType().GetFields() {
+		fields = append(fields, fmt.Sprintf("%s %v", field.Name, field.Type.Code))
+	}
+	if g, w := fields, []string{"singer_id INT64", "album_id INT64"}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("struct fields mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	values := req.Params.Fields["p1"].GetListValue().GetValues()
+	if g, w := len(values), 2; g != w {
+		t.Fatalf("array length mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := values[1].GetListValue().GetValues()[1].GetStringValue(), "4"; g != w {
+		t.Fatalf("struct field value mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestStructParamNullFields(t *testing.T) {
+	value, err := NewStruct(structTrack{TrackNumber: 1, Title: "Intro"}).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	generic := value.(spanner.GenericColumnValue)
+	var fields []string
+	for _, field := range generic.Type.GetStructType().GetFields() {
+		fields = append(fields, fmt.Sprintf("%s %v", field.Name, field.Type.Code))
+	}
+	if g, w := fields, []string{"track_number INT64", "track_title STRING", "duration FLOAT64"}; !reflect.DeepEqual(g, w) {
+		t.Fatalf("struct fields mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if _, ok := generic.Value.GetListValue().GetValues()[2].GetKind().(*structpb.Value_NullValue); !ok {
+		t.Fatalf("field value mismatch\n Got: %v\nWant: NULL", generic.Value.GetListValue().GetValues()[2])
+	}
+
+	value, err = StructArray[*structTrack]{nil}.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := value.(spanner.GenericColumnValue).Value.GetListValue().GetValues()[0].GetKind().(*structpb.Value_NullValue); !ok {
+		t.Fatalf("element mismatch\n Got: %v\nWant: NULL", value)
+	}
+}
+
+func TestStructArrayScanJSON(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	const query = "SELECT album_id, title, TO_JSON(ARRAY(SELECT AS STRUCT track_number, track_title, duration FROM tracks WHERE tracks.album_id = albums.album_id)) AS tracks FROM albums"
+	_ = server.TestSpanner.PutStatementResult(query, &testutil.StatementResult{
+		Type: testutil.StatementResultResultSet,
+		ResultSet: &spannerpb.ResultSet{
+			Metadata: &spannerpb.ResultSetMetadata{
+				RowType: &spannerpb.StructType{
+					Fields: []*spannerpb.StructType_Field{
+						{Name: "album_id", Type: &spannerpb.Type{Code: spannerpb.TypeCode_INT64}},
+						{Name: "title", Type: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}},
+						{Name: "tracks", Type: &spannerpb.Type{Code: spannerpb.TypeCode_JSON}},
+					},
+				},
+			},
+			Rows: []*structpb.ListValue{
+				{Values: []*structpb.Value{
+					{Kind: &structpb.Value_StringValue{StringValue: "1"}},
+					{Kind: &structpb.Value_StringValue{StringValue: "Album 1"}},
+					{Kind: &structpb.Value_StringValue{StringValue: `[{"track_number":1,"track_title":"Intro","duration":65.5},{"track_number":2,"track_title":"Outro","duration":null}]`}},
+				}},
+				{Values: []*structpb.Value{
+					{Kind: &structpb.Value_StringValue{StringValue: "2"}},
+					{Kind: &structpb.Value_StringValue{StringValue: "Album 2"}},
+					{Kind: &structpb.Value_NullValue{}},
+				}},
+			},
+		},
+	})
+	var albums []structAlbum
+	if err := db.Raw(query).Scan(&albums).Error; err != nil {
+		t.Fatal(err)
+	}
+	duration := 65.5
+	want := []structAlbum{
+		{AlbumID: 1, Title: "Album 1", Tracks: StructArray[structTrack]{
+			{TrackNumber: 1, Title: "Intro", Duration: &duration},
+			{TrackNumber: 2, Title: "Outro"},
+		}},
+		{AlbumID: 2, Title: "Album 2"},
+	}
+	if g, w := albums, want; !reflect.DeepEqual(g, w) {
+		t.Fatalf("albums mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestStructScanGenericValue(t *testing.T) {
+	type spannerTrack struct {
+		TrackNumber int64    `spanner:"track_number"`
+		Title       string   `spanner:"track_title"`
+		Duration    *float64 `spanner:"duration"`
+		Ignored     string   `spanner:"ignored"`
+	}
+	duration := 120.25
+	row, err := spanner.NewRow([]string{"tracks"}, []interface{}{[]*spannerTrack{
+		{TrackNumber: 1, Title: "Intro", Duration: &duration},
+		nil,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tracks StructArray[*structTrack]
+	if err := tracks.Scan(spanner.GenericColumnValue{Type: row.ColumnType(0), Value: row.ColumnValue(0)}); err != nil {
+		t.Fatal(err)
+	}
+	want := StructArray[*structTrack]{{TrackNumber: 1, Title: "Intro", Duration: &duration}, nil}
+	if g, w := tracks, want; !reflect.DeepEqual(g, w) {
+		t.Fatalf("tracks mismatch\n Got: %v\nWant: %v", g, w)
+	}
+
+	var album Struct[structAlbum]
+	row, err = spanner.NewRow([]string{"album"}, []interface{}{struct {
+		AlbumID int64           `spanner:"album_id"`
+		Tracks  []*spannerTrack `spanner:"tracks"`
+	}{AlbumID: 3, Tracks: []*spannerTrack{{TrackNumber: 2, Title: "Outro"}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := album.Scan(spanner.GenericColumnValue{Type: row.ColumnType(0), Value: row.ColumnValue(0)}); err != nil {
+		t.Fatal(err)
+	}
+	if g, w := album.Data, (structAlbum{AlbumID: 3, Tracks: StructArray[structTrack]{{TrackNumber: 2, Title: "Outro"}}}); !reflect.DeepEqual(g, w) {
+		t.Fatalf("album mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestStructJSON(t *testing.T) {
+	album := NewStruct(structAlbum{AlbumID: 1, Title: "Album 1", Tracks: StructArray[structTrack]{{TrackNumber: 1, Title: "Intro"}}})
+	b, err := json.Marshal(album)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := string(b), `{"album_id":1,"title":"Album 1","tracks":[{"track_number":1,"track_title":"Intro","duration":null}]}`; g != w {
+		t.Fatalf("json mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	var scanned Struct[structAlbum]
+	if err := scanned.Scan(`{"album_id":1,"title":"Album 1","tracks":[{"track_number":1,"track_title":"Intro"}]}`); err != nil {
+		t.Fatal(err)
+	}
+	if g, w := scanned, album; !reflect.DeepEqual(g, w) {
+		t.Fatalf("struct mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+
+	"cloud.google.com/go/spanner"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// UUID is a UUID column. The column type is UUID for GoogleSQL and uuid for
+// PostgreSQL, or STRING(36) and varchar(36) if UUIDAsString has been set in
+// the configuration of the dialector. UUID can be converted to and from
+// uuid.UUID of the github.com/google/uuid package.
+//
+// Use the NEW_UUID() function as the default value to let Spanner generate
+// the value of a primary key. The generated value is returned to gorm in a
+// THEN RETURN clause. NEW_UUID() returns a UUID, and GENERATE_UUID() returns a
+// STRING, so use GENERATE_UUID() only if UUIDAsString has been set.
+//
+// NULL values are scanned as the zero UUID. Use *UUID for a column that can be
+// NULL.
+//
+// Example:
+//
+//	type User struct {
+//	  ID   spannergorm.UUID `gorm:"primaryKey;default:NEW_UUID()"`
+//	  Name string
+//	}
+type UUID uuid.UUID
+
+// NewUUID returns a new random UUID.
+func NewUUID() UUID {
+	return UUID(uuid.New())
+}
+
+// ParseUUID parses a UUID in one of the formats that are supported by
+// uuid.Parse, for example "f81d4fae-7dec-11d0-a765-00a0c91e6bf6".
+func ParseUUID(s string) (UUID, error) {
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return UUID{}, err
+	}
+	return UUID(u), nil
+}
+
+// String returns the UUID in the standard format
+// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+func (u UUID) String() string {
+	return uuid.UUID(u).String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (u UUID) MarshalText() ([]byte, error) {
+	return uuid.UUID(u).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (u *UUID) UnmarshalText(b []byte) error {
+	return (*uuid.UUID)(u).UnmarshalText(b)
+}
+
+// Value implements driver.Valuer. The value is sent to Spanner as a UUID
+// value. gorm uses GormValue, which also supports UUIDAsString.
+func (u UUID) Value() (driver.Value, error) {
+	return uuid.UUID(u), nil
+}
+
+// GormValue returns the value as a UUID value, or as a string value if UUID
+// columns are stored as strings.
+func (u UUID) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	if uuidAsString(db) {
+		return clause.Expr{SQL: "?", Vars: []interface{}{u.String()}}
+	}
+	return clause.Expr{SQL: "?", Vars: []interface{}{uuid.UUID(u)}}
+}
+
+// Scan implements sql.Scanner. NULL values are scanned as the zero UUID.
+func (u *UUID) Scan(v any) error {
+	switch val := v.(type) {
+	case nil:
+		*u = UUID{}
+	case uuid.UUID:
+		*u = UUID(val)
+	case spanner.NullUUID:
+		*u = UUID(val.UUID)
+	case spanner.NullString:
+		if !val.Valid {
+			*u = UUID{}
+			return nil
+		}
+		return u.Scan(val.StringVal)
+	case string:
+		parsed, err := uuid.Parse(val)
+		if err != nil {
+			return err
+		}
+		*u = UUID(parsed)
+	case []byte:
+		return (*uuid.UUID)(u).Scan(val)
+	default:
+		return fmt.Errorf("cannot scan %T into %T", v, u)
+	}
+	return nil
+}
+
+func (u UUID) GormDataType() string {
+	return "UUID"
+}
+
+func (u UUID) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	switch {
+	case isPostgreSQL(db) && uuidAsString(db):
+		return fmt.Sprintf("varchar(%d)", uuidStringLength)
+	case isPostgreSQL(db):
+		return "uuid"
+	case uuidAsString(db):
+		return fmt.Sprintf("STRING(%d)", uuidStringLength)
+	}
+	return "UUID"
+}
+
+// uuidStringLength is the length of the string columns that are used for
+// UUIDs if UUID columns are stored as strings.
+const uuidStringLength = 36
+
+// UUIDArray is an Array of UUIDs. This type cannot contain any NULL elements.
+// The column type is ARRAY<UUID> for GoogleSQL and uuid[] for PostgreSQL, or
+// ARRAY<STRING(36)> and varchar(36)[] if UUIDAsString has been set in the
+// configuration of the dialector.
+type UUIDArray Array[UUID]
+
+// Value implements driver.Valuer. The value is sent to Spanner as an array of
+// UUID values. gorm uses GormValue, which also supports UUIDAsString.
+func (a UUIDArray) Value() (driver.Value, error) {
+	return Array[UUID](a).Value()
+}
+
+// GormValue returns the value as an array of UUID values, or as an array of
+// string values if UUID columns are stored as strings.
+func (a UUIDArray) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	if !uuidAsString(db) || a == nil {
+		return clause.Expr{SQL: "?", Vars: []interface{}{Array[UUID](a)}}
+	}
+	values := make(Array[spanner.NullString], len(a))
+	for i, u := range a {
+		values[i] = spanner.NullString{StringVal: u.String(), Valid: true}
+	}
+	return clause.Expr{SQL: "?", Vars: []interface{}{values}}
+}
+
+// Scan implements sql.Scanner.
+func (a *UUIDArray) Scan(v any) error {
+	return (*Array[UUID])(a).Scan(v)
+}
+
+func (a UUIDArray) GormDataType() string {
+	return Array[UUID](a).GormDataType()
+}
+
+func (a UUIDArray) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if uuidAsString(db) {
+		return uuidStringArrayDataType(db)
+	}
+	return Array[UUID](a).GormDBDataType(db, field)
+}
+
+// NullUUIDArray is an Array of spanner.NullUUID. The column type is the same
+// as for UUIDArray.
+type NullUUIDArray Array[spanner.NullUUID]
+
+// Value implements driver.Valuer. The value is sent to Spanner as an array of
+// UUID values. gorm uses GormValue, which also supports UUIDAsString.
+func (a NullUUIDArray) Value() (driver.Value, error) {
+	return Array[spanner.NullUUID](a).Value()
+}
+
+// GormValue returns the value as an array of UUID values, or as an array of
+// string values if UUID columns are stored as strings.
+func (a NullUUIDArray) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	if !uuidAsString(db) || a == nil {
+		return clause.Expr{SQL: "?", Vars: []interface{}{Array[spanner.NullUUID](a)}}
+	}
+	values := make(Array[spanner.NullString], len(a))
+	for i, u := range a {
+		if u.Valid {
+			values[i] = spanner.NullString{StringVal: u.UUID.String(), Valid: true}
+		}
+	}
+	return clause.Expr{SQL: "?", Vars: []interface{}{values}}
+}
+
+// Scan implements sql.Scanner. NULL elements of arrays of strings are
+// scanned as invalid spanner.NullUUID values.
+func (a *NullUUIDArray) Scan(v any) error {
+	strings, ok := v.([]spanner.NullString)
+	if !ok {
+		return (*Array[spanner.NullUUID])(a).Scan(v)
+	}
+	if strings == nil {
+		*a = nil
+		return nil
+	}
+	values := make(NullUUIDArray, len(strings))
+	for i, s := range strings {
+		if !s.Valid {
+			continue
+		}
+		if err := values[i].Scan(s.StringVal); err != nil {
+			return fmt.Errorf("index %d of %T: %w", i, v, err)
+		}
+	}
+	*a = values
+	return nil
+}
+
+func (a NullUUIDArray) GormDataType() string {
+	return Array[spanner.NullUUID](a).GormDataType()
+}
+
+func (a NullUUIDArray) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if uuidAsString(db) {
+		return uuidStringArrayDataType(db)
+	}
+	return Array[spanner.NullUUID](a).GormDBDataType(db, field)
+}
+
+// uuidStringArrayDataType returns the data type of an array of UUIDs that
+// are stored as strings.
+func uuidStringArrayDataType(db *gorm.DB) string {
+	if isPostgreSQL(db) {
+		return "varchar(36)[]"
+	}
+	return "ARRAY<STRING(36)>"
+}
+
+// uuidAsStringer is implemented by dialectors that store UUID columns as
+// strings.
+type uuidAsStringer interface {
+	UUIDAsString() bool
+}
+
+// uuidAsString returns true if UUID columns are stored as strings.
+func uuidAsString(db *gorm.DB) bool {
+	if db == nil || db.Config == nil {
+		return false
+	}
+	switch dialector := db.Dialector.(type) {
+	case *Dialector:
+		return dialector.Config != nil && dialector.Config.UUIDAsString
+	case Dialector:
+		return dialector.Config != nil && dialector.Config.UUIDAsString
+	case uuidAsStringer:
+		return dialector.UUIDAsString()
+	}
+	return false
+}
@@ -0,0 +1,249 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/google/uuid"
+	"github.com/googleapis/go-sql-spanner/testutil"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+type uuidUser struct {
+	ID      UUID `gorm:"primaryKey;default:NEW_UUID()"`
+	Name    string
+	Friends UUIDArray
+}
+
+const insertUuidUserSql = "INSERT INTO `uuid_users` (`name`,`friends`) VALUES (@p1,@p2) THEN RETURN `id`"
+
+func TestUUIDDataType(t *testing.T) {
+	field := &schema.Field{}
+	for _, test := range []struct {
+		dialector gorm.Dialector
+		want      string
+		wantArray string
+	}{
+		{dialector: New(Config{}), want: "UUID", wantArray: "ARRAY<UUID>"},
+		{dialector: New(Config{UUIDAsString: true}), want: "STRING(36)", wantArray: "ARRAY<STRING(36)>"},
+		{dialector: postgreSQLDialector{}, want: "uuid", wantArray: "uuid[]"},
+	} {
+		db := &gorm.DB{Config: &gorm.Config{Dialector: test.dialector}}
+		if g, w := (UUID{}).GormDBDataType(db, field), test.want; g != w {
+			t.Errorf("%s: data type mismatch\n Got: %v\nWant: %v", test.dialector.Name(), g, w)
+		}
+		if g, w := (UUIDArray{}).GormDBDataType(db, field), test.wantArray; g != w {
+			t.Errorf("%s: array data type mismatch\n Got: %v\nWant: %v", test.dialector.Name(), g, w)
+		}
+	}
+}
+
+func TestUUIDDryRun(t *testing.T) {
+	t.Parallel()
+
+	db, _, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	statements, err := db.Migrator().(SpannerMigrator).AutoMigrateDryRun(&uuidUser{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := len(statements), 1; g != w {
+		t.Fatalf("statement count mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := statements[0].SQL,
+		"CREATE TABLE `uuid_users` (`id` UUID DEFAULT (NEW_UUID()),`name` STRING(MAX),`friends` ARRAY<UUID>) PRIMARY KEY (`id`)"; g != w {
+		t.Fatalf("create table statement mismatch\n Got: %s\nWant: %s", g, w)
+	}
+}
+
+func TestUUIDRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	id := NewUUID()
+	friend := NewUUID()
+	_ = server.TestSpanner.PutStatementResult(insertUuidUserSql, &testutil.StatementResult{
+		Type: testutil.StatementResultResultSet,
+		ResultSet: &spannerpb.ResultSet{
+			Metadata: &spannerpb.ResultSetMetadata{
+				RowType: &spannerpb.StructType{
+					Fields: []*spannerpb.StructType_Field{
+						{Name: "id", Type: &spannerpb.Type{Code: spannerpb.TypeCode_UUID}},
+					},
+				},
+			},
+			Rows: []*structpb.ListValue{
+				{Values: []*structpb.Value{{Kind: &structpb.Value_StringValue{StringValue: id.String()}}}},
+			},
+		},
+		UpdateCount: 1,
+	})
+	user := uuidUser{Name: "User 1", Friends: UUIDArray{friend}}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	if g, w := user.ID, id; g != w {
+		t.Fatalf("id mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	req := getLastSqlRequest(server)
+	if g, w := req.Sql, insertUuidUserSql; g != w {
+		t.Fatalf("sql mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.ParamTypes["p2"].GetArrayElementType().GetCode(), spannerpb.TypeCode_UUID; g != w {
+		t.Fatalf("param type mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if g, w := req.Params.Fields["p2"].GetListValue().GetValues()[0].GetStringValue(), friend.String(); g != w {
+		t.Fatalf("param value mismatch\n Got: %v\nWant: %v", g, w)
+	}
+
+	_ = server.TestSpanner.PutStatementResult("SELECT * FROM `uuid_users` WHERE `uuid_users`.`id` = @p1 ORDER BY `uuid_users`.`id` LIMIT @p2",
+		&testutil.StatementResult{
+			Type: testutil.StatementResultResultSet,
+			ResultSet: &spannerpb.ResultSet{
+				Metadata: &spannerpb.ResultSetMetadata{
+					RowType: &spannerpb.StructType{
+						Fields: []*spannerpb.StructType_Field{
+							{Name: "id", Type: &spannerpb.Type{Code: spannerpb.TypeCode_UUID}},
+							{Name: "name", Type: &spannerpb.Type{Code: spannerpb.TypeCode_STRING}},
+							{Name: "friends", Type: req.ParamTypes["p2"]},
+						},
+					},
+				},
+				Rows: []*structpb.ListValue{
+					{Values: []*structpb.Value{
+						{Kind: &structpb.Value_StringValue{StringValue: id.String()}},
+						{Kind: &structpb.Value_StringValue{StringValue: "User 1"}},
+						req.Params.Fields["p2"],
+					}},
+				},
+			},
+		})
+	var result uuidUser
+	if err := db.First(&result, "`uuid_users`.`id` = ?", id).Error; err != nil {
+		t.Fatalf("failed to fetch user: %v", err)
+	}
+	if g, w := fmt.Sprint(result), fmt.Sprint(user); g != w {
+		t.Fatalf("user mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	req = getLastSqlRequest(server)
+	if g, w := req.ParamTypes["p1"].GetCode(), spannerpb.TypeCode_UUID; g != w {
+		t.Fatalf("param type mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestUUIDAsString(t *testing.T) {
+	t.Parallel()
+
+	server, _, serverTeardown := setupMockedTestServer(t)
+	db, _, teardown := setupTestGormConnectionWithDialector(t, server, serverTeardown, New(Config{
+		DriverName:   "spanner",
+		DSN:          fmt.Sprintf("%s/projects/p/instances/i/databases/d?useplaintext=true", server.Address),
+		UUIDAsString: true,
+	}))
+	defer teardown()
+
+	id := NewUUID()
+	_ = server.TestSpanner.PutStatementResult("DELETE FROM `uuid_users` WHERE `uuid_users`.`id` = @p1", &testutil.StatementResult{
+		Type:        testutil.StatementResultUpdateCount,
+		UpdateCount: 1,
+	})
+	if err := db.Delete(&uuidUser{ID: id}).Error; err != nil {
+		t.Fatal(err)
+	}
+	req := getLastSqlRequest(server)
+	if g := req.ParamTypes["p1"].GetCode(); g == spannerpb.TypeCode_UUID {
+		t.Fatalf("param type mismatch\n Got: %v\nWant: STRING or untyped", g)
+	}
+	if g, w := req.Params.Fields["p1"].GetStringValue(), id.String(); g != w {
+		t.Fatalf("param value mismatch\n Got: %v\nWant: %v", g, w)
+	}
+
+	friend := NewUUID()
+	_ = server.TestSpanner.PutStatementResult("UPDATE `uuid_users` SET `friends`=@p1 WHERE `id` = @p2", &testutil.StatementResult{
+		Type:        testutil.StatementResultUpdateCount,
+		UpdateCount: 1,
+	})
+	if err := db.Model(&uuidUser{ID: id}).Update("friends", UUIDArray{friend}).Error; err != nil {
+		t.Fatal(err)
+	}
+	req = getLastSqlRequest(server)
+	if g := req.ParamTypes["p1"].GetArrayElementType().GetCode(); g != spannerpb.TypeCode_STRING {
+		t.Fatalf("array param type mismatch\n Got: %v\nWant: STRING", g)
+	}
+	if g, w := req.Params.Fields["p1"].GetListValue().GetValues()[0].GetStringValue(), friend.String(); g != w {
+		t.Fatalf("array param value mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
+
+func TestScanUUIDArrayFromStrings(t *testing.T) {
+	u := NewUUID()
+	strings := []spanner.NullString{{StringVal: u.String(), Valid: true}, {}}
+	var uuids NullUUIDArray
+	if err := uuids.Scan(strings); err != nil {
+		t.Fatal(err)
+	}
+	if g, w := uuids, (NullUUIDArray{{UUID: uuid.UUID(u), Valid: true}, {}}); !reflect.DeepEqual(g, w) {
+		t.Fatalf("array mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	var nonNull UUIDArray
+	if err := nonNull.Scan(strings[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if g, w := nonNull, (UUIDArray{u}); !reflect.DeepEqual(g, w) {
+		t.Fatalf("array mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	if err := nonNull.Scan(strings); !errors.Is(err, errNullArrayElement) {
+		t.Fatalf("error mismatch\n Got: %v\nWant: %v", err, errNullArrayElement)
+	}
+}
+
+func TestUUIDConversion(t *testing.T) {
+	u := uuid.New()
+	var id UUID
+	for _, v := range []any{u, u.String(), []byte(u.String()), u[:]} {
+		if err := id.Scan(v); err != nil {
+			t.Fatalf("failed to scan %T: %v", v, err)
+		}
+		if g, w := uuid.UUID(id), u; g != w {
+			t.Fatalf("%T: uuid mismatch\n Got: %v\nWant: %v", v, g, w)
+		}
+	}
+	b, err := json.Marshal(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := string(b), `"`+u.String()+`"`; g != w {
+		t.Fatalf("json mismatch\n Got: %v\nWant: %v", g, w)
+	}
+	parsed, err := ParseUUID(u.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := parsed, UUID(u); g != w {
+		t.Fatalf("parsed uuid mismatch\n Got: %v\nWant: %v", g, w)
+	}
+}
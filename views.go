@@ -0,0 +1,171 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+)
+
+// View can be implemented by models that are backed by a view instead of a
+// table. AutoMigrate creates the view with the query that is returned by
+// ViewQuery, and replaces the view if the query has changed.
+//
+// Models that implement View are read-only. Create, update and delete
+// operations on these models return ErrViewIsReadOnly.
+//
+// Example:
+//
+//	type ActiveSinger struct {
+//	  ID       int64
+//	  FullName string
+//	}
+//
+//	func (ActiveSinger) ViewQuery(db *gorm.DB) *gorm.DB {
+//	  return db.Model(&Singer{}).Select("id", "full_name").Where("active")
+//	}
+type View interface {
+	ViewQuery(db *gorm.DB) *gorm.DB
+}
+
+// ErrViewIsReadOnly is returned when a model that implements View is created,
+// updated or deleted.
+var ErrViewIsReadOnly = errors.New("models that are backed by a view are read-only")
+
+var errViewCheckOptionNotSupported = errors.New("spanner does not support check options for views")
+
+// RejectWritesToViews returns ErrViewIsReadOnly for write operations on
+// models that implement View.
+func RejectWritesToViews(db *gorm.DB) {
+	if db.Statement.Schema != nil && isView(db.Statement.Schema) {
+		_ = db.AddError(ErrViewIsReadOnly)
+	}
+}
+
+func isView(s *schema.Schema) bool {
+	_, ok := reflect.New(s.ModelType).Interface().(View)
+	return ok
+}
+
+// RegisterRejectWritesToViews registers RejectWritesToViews for the create,
+// update and delete operations of the given gorm.DB.
+func RegisterRejectWritesToViews(db *gorm.DB) error {
+	if err := db.Callback().Create().
+		Before("gorm:begin_transaction").
+		Register("gorm:spanner:reject_writes_to_views", RejectWritesToViews); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().
+		Before("gorm:begin_transaction").
+		Register("gorm:spanner:reject_writes_to_views", RejectWritesToViews); err != nil {
+		return err
+	}
+	return db.Callback().Delete().
+		Before("gorm:begin_transaction").
+		Register("gorm:spanner:reject_writes_to_views", RejectWritesToViews)
+}
+
+// ViewMigrator creates and migrates the views of models that implement View.
+// It is used by the migrators of both GoogleSQL-dialect and
+// PostgreSQL-dialect databases.
+type ViewMigrator struct {
+	migrator.Migrator
+	// CurrentSchema returns the schema and the name of the given table or
+	// view.
+	CurrentSchema func(stmt *gorm.Statement, table string) (interface{}, interface{})
+	// QueryRaw returns a query on the information schema. The default is
+	// DB.Raw.
+	QueryRaw func(sql string, values ...interface{}) *gorm.DB
+}
+
+func (m ViewMigrator) queryRaw(sql string, values ...interface{}) *gorm.DB {
+	if m.QueryRaw != nil {
+		return m.QueryRaw(sql, values...)
+	}
+	return m.DB.Raw(sql, values...)
+}
+
+// MigrateView creates the view of the given model, or replaces the view if
+// the query of the view has changed.
+func (m ViewMigrator) MigrateView(value interface{}) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		query := value.(View).ViewQuery(m.DB.Session(&gorm.Session{NewDB: true}))
+		if query == nil {
+			return gorm.ErrSubQueryRequired
+		}
+		var definitions []string
+		currentSchema, curTable := m.CurrentSchema(stmt, stmt.Table)
+		if err := m.queryRaw(
+			"SELECT view_definition FROM information_schema.views WHERE table_schema = ? AND table_name = ?",
+			currentSchema, curTable,
+		).Scan(&definitions).Error; err == nil && len(definitions) == 1 && strings.TrimSpace(definitions[0]) == m.viewQuery(query) {
+			return nil
+		}
+		return m.CreateView(stmt.Schema.Table, gorm.ViewOption{Replace: true, Query: query})
+	})
+}
+
+// viewQuery returns the SQL string of the query of a view with all
+// parameters inlined, as Spanner does not support query parameters in DDL.
+func (m ViewMigrator) viewQuery(query *gorm.DB) string {
+	stmt := &gorm.Statement{DB: m.DB, Context: m.DB.Statement.Context}
+	sql := new(strings.Builder)
+	stmt.AddVar(sql, query)
+	return m.Dialector.Explain(sql.String(), stmt.Vars...)
+}
+
+// CreateView creates a view with SQL SECURITY INVOKER. Spanner does not
+// support check options for views.
+func (m ViewMigrator) CreateView(name string, option gorm.ViewOption) error {
+	if option.Query == nil {
+		return gorm.ErrSubQueryRequired
+	}
+	if option.CheckOption != "" {
+		return errViewCheckOptionNotSupported
+	}
+
+	sql := new(strings.Builder)
+	sql.WriteString("CREATE ")
+	if option.Replace {
+		sql.WriteString("OR REPLACE ")
+	}
+	sql.WriteString("VIEW ")
+	m.Dialector.QuoteTo(sql, name)
+	sql.WriteString(" SQL SECURITY INVOKER AS ")
+	sql.WriteString(m.viewQuery(option.Query))
+	return m.DB.Exec(sql.String()).Error
+}
+
+// DropView drops the view with the given name.
+func (m ViewMigrator) DropView(name string) error {
+	return m.DB.Exec("DROP VIEW ?", clause.Table{Name: name}).Error
+}
+
+// HasView returns true if the database contains a view with the given name.
+func (m ViewMigrator) HasView(name string) bool {
+	var count int64
+	currentSchema, curTable := m.CurrentSchema(&gorm.Statement{DB: m.DB}, name)
+	m.queryRaw(
+		"SELECT count(*) FROM information_schema.views WHERE table_schema = ? AND table_name = ?",
+		currentSchema, curTable,
+	).Scan(&count)
+	return count > 0
+}
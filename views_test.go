@@ -0,0 +1,47 @@
+// Copyright 2026 Google LLC.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+)
+
+func TestViewIsReadOnly(t *testing.T) {
+	t.Parallel()
+
+	db, server, teardown := setupTestGormConnection(t)
+	defer teardown()
+
+	if err := db.Create(&activeSinger{ID: 1, FullName: "Alice"}).Error; !errors.Is(err, ErrViewIsReadOnly) {
+		t.Fatalf("create error mismatch\n Got: %v\nWant: %v", err, ErrViewIsReadOnly)
+	}
+	if err := db.Model(&activeSinger{ID: 1}).Update("full_name", "Bob").Error; !errors.Is(err, ErrViewIsReadOnly) {
+		t.Fatalf("update error mismatch\n Got: %v\nWant: %v", err, ErrViewIsReadOnly)
+	}
+	if err := db.Delete(&activeSinger{ID: 1}).Error; !errors.Is(err, ErrViewIsReadOnly) {
+		t.Fatalf("delete error mismatch\n Got: %v\nWant: %v", err, ErrViewIsReadOnly)
+	}
+	reqs := drainRequestsFromServer(server.TestSpanner)
+	for _, req := range requestsOfType(reqs, reflect.TypeOf(&spannerpb.ExecuteSqlRequest{})) {
+		if sql := req.(*spannerpb.ExecuteSqlRequest).Sql; strings.Contains(sql, "active_singers") {
+			t.Fatalf("unexpected statement: %s", sql)
+		}
+	}
+}